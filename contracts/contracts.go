@@ -0,0 +1,176 @@
+// Package contracts generates the NPC delivery contracts posted on the contract board. A round's
+// contracts are derived from the seed, the round and the market alone, so a seed replays the same
+// board for the same prices however often it is generated.
+package contracts
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+)
+
+// Minimum qualities a contract may ask for.
+var Qualities = []float64{0.5, 0.6, 0.7, 0.8, 0.9}
+
+// Hours a contract may give for delivery once accepted.
+var DeliveryHours = []int{24, 48, 72}
+
+type Config struct {
+	Seed uint64
+
+	// Contracts posted each round, and how long a round's offers stay on the board.
+	PerRound   int
+	RoundHours int
+
+	// Reward over the market value of the delivery, before the bonus for quality above 0.5.
+	Premium float64
+
+	// Collateral held on acceptance and lost on failure, as a share of the reward.
+	PenaltyRate float64
+}
+
+// DefaultConfig is used for anything the CONTRACT_* env variables leave unset.
+func DefaultConfig() Config {
+	return Config{
+		Seed:        utils.WorldSeed(),
+		PerRound:    5,
+		RoundHours:  6,
+		Premium:     0.2,
+		PenaltyRate: 0.25,
+	}
+}
+
+// ConfigFromEnv reads CONTRACT_SEED, CONTRACT_PER_ROUND, CONTRACT_ROUND_HOURS, CONTRACT_PREMIUM and
+// CONTRACT_PENALTY_RATE over the defaults.
+func ConfigFromEnv() Config {
+	config := DefaultConfig()
+
+	if seed, err := strconv.ParseUint(os.Getenv("CONTRACT_SEED"), 10, 64); err == nil {
+		config.Seed = seed
+	}
+
+	readInt("CONTRACT_PER_ROUND", func(v int) { config.PerRound = v })
+	readInt("CONTRACT_ROUND_HOURS", func(v int) { config.RoundHours = v })
+	readFloat("CONTRACT_PREMIUM", func(v float64) { config.Premium = v })
+	readFloat("CONTRACT_PENALTY_RATE", func(v float64) { config.PenaltyRate = v })
+
+	return config
+}
+
+// Market is what the generator knows about a crop: its current price and market factors.
+type Market struct {
+	Crop   models.Crop
+	Price  float64
+	Demand float64
+	Supply float64
+}
+
+type Engine struct {
+	config Config
+}
+
+func NewEngine(config Config) *Engine {
+	return &Engine{config: config}
+}
+
+func (e *Engine) Config() Config {
+	return e.config
+}
+
+// Round returns the round containing at; rounds count from the Unix epoch.
+func (e *Engine) Round(at time.Time) int64 {
+	return at.Unix() / int64(e.config.RoundHours*3600)
+}
+
+// RoundStart returns when round begins.
+func (e *Engine) RoundStart(round int64) time.Time {
+	return time.Unix(round*int64(e.config.RoundHours*3600), 0)
+}
+
+// Generate returns the open contracts of round. Crops are picked with a weight of their demand over
+// supply, between a quarter and four times normal, and the same ratio scales the quantity asked
+// for. Rewards pay the market value of the delivery plus the premium, more for higher minimum
+// quality. Crops without a price are skipped.
+func (e *Engine) Generate(round int64, markets []Market) []models.DeliveryContract {
+	markets = slices.DeleteFunc(slices.Clone(markets), func(m Market) bool { return m.Price <= 0 })
+	if len(markets) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(markets, func(a, b Market) int { return bytes.Compare(a.Crop.ID[:], b.Crop.ID[:]) })
+
+	weights := make([]float64, len(markets))
+	total := 0.0
+	for i, market := range markets {
+		weights[i] = demandRatio(market)
+		total += weights[i]
+	}
+
+	random := rand.New(rand.NewPCG(e.config.Seed, uint64(round)))
+	start := e.RoundStart(round)
+
+	contracts := make([]models.DeliveryContract, 0, e.config.PerRound)
+	for slot := 0; slot < e.config.PerRound; slot++ {
+		pick := random.Float64() * total
+
+		i := 0
+		for ; i < len(markets)-1 && pick >= weights[i]; i++ {
+			pick -= weights[i]
+		}
+
+		market := markets[i]
+
+		// 2 to 10 land units' worth of yield, rounded up to tens.
+		units := 2 + random.IntN(9)
+		quantity := int(math.Ceil(float64(max(1, market.Crop.YieldPerUnit)*units)*weights[i]/10)) * 10
+
+		minQuality := Qualities[random.IntN(len(Qualities))]
+		reward := roundCoins(market.Price * float64(quantity) * (1 + e.config.Premium + minQuality - 0.5))
+
+		contracts = append(contracts, models.DeliveryContract{
+			Round:          round,
+			Slot:           slot,
+			CropID:         market.Crop.ID,
+			Quantity:       quantity,
+			MinQuality:     minQuality,
+			Reward:         reward,
+			Penalty:        roundCoins(reward * e.config.PenaltyRate),
+			DeliveryHours:  DeliveryHours[random.IntN(len(DeliveryHours))],
+			Status:         utils.ContractOpen,
+			OfferExpiresAt: start.Add(time.Duration(e.config.RoundHours) * time.Hour),
+		})
+	}
+
+	return contracts
+}
+
+func demandRatio(market Market) float64 {
+	if market.Demand <= 0 || market.Supply <= 0 {
+		return 1
+	}
+
+	return math.Max(0.25, math.Min(4, market.Demand/market.Supply))
+}
+
+func roundCoins(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func readInt(key string, set func(int)) {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		set(value)
+	}
+}
+
+func readFloat(key string, set func(float64)) {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && value >= 0 {
+		set(value)
+	}
+}
@@ -0,0 +1,74 @@
+package contracts
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hrutik1235/farming-server/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testConfig(seed uint64) Config {
+	config := DefaultConfig()
+	config.Seed = seed
+
+	return config
+}
+
+func testMarkets() []Market {
+	return []Market{
+		{Crop: models.Crop{BaseModel: models.BaseModel{ID: primitive.NewObjectID()}, YieldPerUnit: 10}, Price: 4, Demand: 1, Supply: 1},
+		{Crop: models.Crop{BaseModel: models.BaseModel{ID: primitive.NewObjectID()}, YieldPerUnit: 20}, Price: 2.5, Demand: 2, Supply: 1},
+		{Crop: models.Crop{BaseModel: models.BaseModel{ID: primitive.NewObjectID()}, YieldPerUnit: 5}, Price: 12, Demand: 1, Supply: 3},
+	}
+}
+
+func TestGenerateReplaysSeedAndRound(t *testing.T) {
+	markets := testMarkets()
+
+	for round := int64(0); round < 50; round++ {
+		first := NewEngine(testConfig(42)).Generate(round, markets)
+
+		// The order markets are read in must not matter either.
+		reversed := []Market{markets[2], markets[1], markets[0]}
+		second := NewEngine(testConfig(42)).Generate(round, reversed)
+
+		if len(first) != testConfig(42).PerRound {
+			t.Fatalf("round %d posted %d contracts, want %d", round, len(first), testConfig(42).PerRound)
+		}
+
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("round %d: seed 42 gave %+v and then %+v", round, first, second)
+		}
+	}
+}
+
+func TestGenerateDependsOnSeedAndRound(t *testing.T) {
+	markets := testMarkets()
+	engine := NewEngine(testConfig(42))
+
+	if reflect.DeepEqual(engine.Generate(7, markets), NewEngine(testConfig(43)).Generate(7, markets)) {
+		t.Error("seeds 42 and 43 gave the same board")
+	}
+
+	first, second := engine.Generate(7, markets), engine.Generate(8, markets)
+	for i := range first {
+		first[i].Round, first[i].OfferExpiresAt = 0, second[i].OfferExpiresAt
+		second[i].Round = 0
+	}
+
+	if reflect.DeepEqual(first, second) {
+		t.Error("rounds 7 and 8 gave the same board")
+	}
+}
+
+func TestGenerateSkipsUnpricedCrops(t *testing.T) {
+	markets := testMarkets()
+	for i := range markets {
+		markets[i].Price = 0
+	}
+
+	if contracts := NewEngine(testConfig(42)).Generate(1, markets); len(contracts) != 0 {
+		t.Fatalf("posted %d contracts without any prices, want none", len(contracts))
+	}
+}
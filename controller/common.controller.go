@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/utils"
+)
+
+func respondWithError(c *gin.Context, err error) {
+	status := service.ErrorStatus(err)
+
+	c.JSON(status, utils.NewHttpError(c, err.Error(), status))
+}
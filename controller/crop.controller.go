@@ -6,7 +6,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/hrutik1235/farming-server/models"
 	"github.com/hrutik1235/farming-server/service"
 	"github.com/hrutik1235/farming-server/types"
 	"github.com/hrutik1235/farming-server/utils"
@@ -15,43 +14,28 @@ import (
 )
 
 type CropController struct {
-	service     *service.CropService
-	userService *service.UserService
-	dbClient    *mongo.Database
+	service         *service.CropService
+	userService     *service.UserService
+	careService     *service.CareService
+	outbreakService *service.OutbreakService
+	dbClient        *mongo.Database
 }
 
 func NewCropController(dbClient *mongo.Database) *CropController {
 	return &CropController{
-		service:     service.NewCropService(dbClient),
-		userService: service.NewUserService(dbClient),
-		dbClient:    dbClient,
+		service:         service.NewCropService(dbClient),
+		userService:     service.NewUserService(dbClient),
+		careService:     service.NewCareService(dbClient),
+		outbreakService: service.NewOutbreakService(dbClient),
+		dbClient:        dbClient,
 	}
 }
 
 func (cropController *CropController) CreateCrop(c *gin.Context) {
 	body := c.MustGet("body").(types.CreateCrop)
 
-	crop := models.Crop{
-		Name:            body.Name,
-		Description:     body.Description,
-		BasePrice:       body.BasePrice,
-		GrowthTimeHours: body.GrowthTimeHours,
-		YieldPerUnit:    body.YieldPerUnit,
-		CostPerUnit:     body.CostPerUnit,
-		IsActive:        true,
-	}
-
-	existingCrop, _ := cropController.service.GetCropByName(crop.Name)
-
-	if existingCrop.Name == crop.Name {
-		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "Crop already exists", http.StatusBadRequest))
-		return
-	}
-
-	_, err := cropController.dbClient.Collection(utils.CropsCollection).InsertOne(context.TODO(), crop)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
+	if _, err := cropController.service.CreateCrop(context.TODO(), body); err != nil {
+		respondWithError(c, err)
 		return
 	}
 
@@ -95,67 +79,83 @@ func (cropController *CropController) GetAllPlantedCrops(c *gin.Context) {
 
 func (cropController *CropController) PlantCrop(c *gin.Context) {
 	userId := c.GetHeader("user_id")
-	landUnits := c.MustGet("body").(types.PlantCrop)
-	cropId := c.Param("cropid")
+	selection := c.MustGet("body").(types.PlantCrop)
+	cropId := c.Param("id")
 
 	userObjectId, _ := primitive.ObjectIDFromHex(userId)
 	cropObjectId, _ := primitive.ObjectIDFromHex(cropId)
 
-	availableLand, err := cropController.service.GetAvailableLandUnits(context.TODO(), userObjectId)
+	plantedCrop, err := cropController.service.PlantCropOn(context.TODO(), userObjectId, cropObjectId, selection)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
+		respondWithError(c, err)
 		return
 	}
 
-	if len(availableLand) < landUnits.LandUnits {
-		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "Not enough land units", http.StatusBadRequest))
-		return
-	}
-
-	crop, err := cropController.service.GetCropById(cropObjectId)
+	c.JSON(http.StatusOK, gin.H{
+		"data": plantedCrop,
+	})
+}
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
-		return
+// CareForPlanting returns a handler that performs the care action on the planting in the path.
+func (cropController *CropController) CareForPlanting(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+		plantingObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid planting id", http.StatusBadRequest))
+			return
+		}
+
+		result, err := cropController.careService.PerformCare(c.Request.Context(), userObjectId, plantingObjectId, action)
+		if err != nil {
+			respondWithError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"data": result,
+		})
 	}
+}
 
-	wallet, err := cropController.userService.GetUserWallet(userObjectId)
+func (cropController *CropController) TreatPlanting(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
 
+	plantingObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, "Wallet Error", http.StatusInternalServerError))
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid planting id", http.StatusBadRequest))
 		return
 	}
 
-	totalCost := crop.CostPerUnit * float64(landUnits.LandUnits)
-
-	if wallet.Balance < totalCost {
-		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "Not enough balance", http.StatusBadRequest))
-		return
-	}
-
-	if err := cropController.userService.DeductPlantingCost(userObjectId, totalCost); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
+	result, err := cropController.outbreakService.Treat(c.Request.Context(), userObjectId, plantingObjectId)
+	if err != nil {
+		respondWithError(c, err)
 		return
 	}
 
-	landUnitIds := cropController.service.GetLandUnitIDs(availableLand[:landUnits.LandUnits])
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
 
-	fmt.Println("Land Unit IDs: ", landUnitIds)
+func (cropController *CropController) GetPlantingActivity(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
 
-	if err := cropController.service.MarkLandUnitsOccupied(context.TODO(), landUnitIds); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
+	plantingObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid planting id", http.StatusBadRequest))
 		return
 	}
 
-	plantedCrop, planterr := cropController.service.CreatePlantedCrop(context.TODO(), userObjectId, crop, landUnitIds, landUnits.LandUnits, totalCost)
-
-	if planterr != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
+	activities, err := cropController.careService.GetPlantingActivity(c.Request.Context(), userObjectId, plantingObjectId)
+	if err != nil {
+		respondWithError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": plantedCrop,
+		"data": activities,
 	})
 }
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type FederationController struct {
+	service *service.FederationService
+}
+
+func NewFederationController(dbClient *mongo.Database) *FederationController {
+	return &FederationController{
+		service: service.NewFederationService(dbClient),
+	}
+}
+
+func (fc *FederationController) ConnectPeer(c *gin.Context) {
+	body := c.MustGet("body").(types.ConnectPeer)
+
+	peer, err := fc.service.ConnectPeer(context.TODO(), body.Address)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": peer,
+	})
+}
+
+func (fc *FederationController) GetPeers(c *gin.Context) {
+	peers, err := fc.service.GetPeers(context.TODO())
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": peers,
+	})
+}
+
+func (fc *FederationController) SyncDirectory(c *gin.Context) {
+	entries, err := fc.service.SyncDirectory(context.TODO(), c.Param("peerid"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+	})
+}
+
+func (fc *FederationController) GetDirectory(c *gin.Context) {
+	entries, err := fc.service.GetDirectory(context.TODO(), c.Query("peer_id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+	})
+}
+
+func (fc *FederationController) InitiateTrade(c *gin.Context) {
+	userId := c.GetHeader("user_id")
+	userObjectId, _ := primitive.ObjectIDFromHex(userId)
+
+	body := c.MustGet("body").(types.InitiateFederatedTrade)
+
+	trade, err := fc.service.InitiateTrade(context.TODO(), userObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": trade,
+	})
+}
+
+func (fc *FederationController) GetTrades(c *gin.Context) {
+	userId := c.GetHeader("user_id")
+	userObjectId, _ := primitive.ObjectIDFromHex(userId)
+
+	trades, err := fc.service.GetTrades(context.TODO(), userObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": trades,
+	})
+}
+
+func (fc *FederationController) AcceptTrade(c *gin.Context) {
+	userId := c.GetHeader("user_id")
+	userObjectId, _ := primitive.ObjectIDFromHex(userId)
+
+	trade, err := fc.service.AcceptTrade(context.TODO(), userObjectId, c.Param("tradeid"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": trade,
+	})
+}
+
+func (fc *FederationController) DeclineTrade(c *gin.Context) {
+	userId := c.GetHeader("user_id")
+	userObjectId, _ := primitive.ObjectIDFromHex(userId)
+
+	trade, err := fc.service.DeclineTrade(context.TODO(), userObjectId, c.Param("tradeid"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": trade,
+	})
+}
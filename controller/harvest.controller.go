@@ -5,9 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/hrutik1235/farming-server/models"
 	"github.com/hrutik1235/farming-server/service"
-	"github.com/hrutik1235/farming-server/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -30,39 +28,10 @@ func (hc *HarvestController) HarvestCrop(c *gin.Context) {
 	plantingId := c.Param("plantid")
 	plantingObjectId, _ := primitive.ObjectIDFromHex(plantingId)
 
-	var harvestResult *models.HarvestResult
-
-	plantedCrop, err := hc.service.GetPlantedCrop(userObjectId, plantingObjectId)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
-		return
-	}
-
-	if err := hc.service.ValidateHarvest(plantedCrop); err != nil {
-		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, err.Error(), http.StatusBadRequest))
-		return
-	}
-
-	harvestResult, err = hc.service.CalculateHarvestResult(plantedCrop, 1.0)
+	harvestResult, err := hc.service.HarvestCrop(context.TODO(), userObjectId, plantingObjectId)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
-		return
-	}
-
-	if err := hc.service.MarkCropAsHarvested(plantingObjectId, harvestResult); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
-		return
-	}
-
-	if err := hc.service.FreeLandUnits(context.TODO(), plantedCrop.LandUnitIDs); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
-		return
-	}
-
-	if err := hc.service.AddToWarehouse(context.TODO(), userObjectId, harvestResult); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.NewHttpError(c, err.Error(), http.StatusInternalServerError))
+		respondWithError(c, err)
 		return
 	}
 
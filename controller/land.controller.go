@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type LandController struct {
+	service        *service.LandService
+	listingService *service.ListingService
+}
+
+func NewLandController(dbClient *mongo.Database) *LandController {
+	return &LandController{
+		service:        service.NewLandService(dbClient),
+		listingService: service.NewListingService(dbClient),
+	}
+}
+
+func (lc *LandController) GetLandMap(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	landMap, err := lc.service.GetLandMap(c.Request.Context(), userObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": landMap,
+	})
+}
+
+func (lc *LandController) BuildStructure(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.BuildStructure)
+
+	landUnitObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid land unit id", http.StatusBadRequest))
+		return
+	}
+
+	unit, err := lc.service.BuildStructure(c.Request.Context(), userObjectId, landUnitObjectId, body.Kind)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": unit,
+	})
+}
+
+func (lc *LandController) RemoveStructure(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	landUnitObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid land unit id", http.StatusBadRequest))
+		return
+	}
+
+	if err := lc.service.RemoveStructure(c.Request.Context(), userObjectId, landUnitObjectId); err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewHttpError(c, "Structure removed", http.StatusOK))
+}
+
+// QuoteExpansion prices buying the number of land units in the units query.
+func (lc *LandController) QuoteExpansion(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	units, err := strconv.Atoi(c.DefaultQuery("units", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid units", http.StatusBadRequest))
+		return
+	}
+
+	quote, err := lc.service.QuoteExpansion(c.Request.Context(), userObjectId, units)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": quote,
+	})
+}
+
+func (lc *LandController) ExpandLand(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.ExpandLand)
+
+	expansion, err := lc.service.ExpandLand(c.Request.Context(), userObjectId, body.Units)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": expansion,
+	})
+}
+
+// GetListings returns land listings with the status query, open ones by default.
+func (lc *LandController) GetListings(c *gin.Context) {
+	listings, err := lc.listingService.GetListings(c.Request.Context(), c.DefaultQuery("status", utils.ListingOpen))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": listings,
+	})
+}
+
+func (lc *LandController) CreateListing(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.ListLand)
+
+	listing, err := lc.listingService.CreateListing(c.Request.Context(), userObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": listing,
+	})
+}
+
+func (lc *LandController) CancelListing(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	listingObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid listing id", http.StatusBadRequest))
+		return
+	}
+
+	listing, err := lc.listingService.CancelListing(c.Request.Context(), userObjectId, listingObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": listing,
+	})
+}
+
+func (lc *LandController) BuyListing(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	listingObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid listing id", http.StatusBadRequest))
+		return
+	}
+
+	listing, err := lc.listingService.BuyListing(c.Request.Context(), userObjectId, listingObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": listing,
+	})
+}
+
+func (lc *LandController) GetTransfers(c *gin.Context) {
+	landUnitObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid land unit id", http.StatusBadRequest))
+		return
+	}
+
+	transfers, err := lc.listingService.GetTransfers(c.Request.Context(), landUnitObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": transfers,
+	})
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type LeaseController struct {
+	service        *service.LeaseService
+	auctionService *service.LeaseAuctionService
+}
+
+func NewLeaseController(dbClient *mongo.Database) *LeaseController {
+	return &LeaseController{
+		service:        service.NewLeaseService(dbClient),
+		auctionService: service.NewLeaseAuctionService(dbClient),
+	}
+}
+
+func (lc *LeaseController) GetLeases(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	leases, err := lc.service.GetLeases(c.Request.Context(), userObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": leases,
+	})
+}
+
+func (lc *LeaseController) RequestLease(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.RequestLease)
+
+	lease, err := lc.service.RequestLease(c.Request.Context(), userObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": lease,
+	})
+}
+
+func (lc *LeaseController) AcceptLease(c *gin.Context) {
+	lc.respondWithLease(c, lc.service.AcceptLease)
+}
+
+func (lc *LeaseController) RejectLease(c *gin.Context) {
+	lc.respondWithLease(c, lc.service.RejectLease)
+}
+
+func (lc *LeaseController) CancelLease(c *gin.Context) {
+	lc.respondWithLease(c, lc.service.CancelLease)
+}
+
+func (lc *LeaseController) GetActivity(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid page", http.StatusBadRequest))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid limit", http.StatusBadRequest))
+		return
+	}
+
+	activities, total, err := lc.service.GetActivity(c.Request.Context(), userObjectId, c.Param("id"), page, limit)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  activities,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	})
+}
+
+// GetAuctions returns lease auctions with the status query, open ones by default.
+func (lc *LeaseController) GetAuctions(c *gin.Context) {
+	auctions, err := lc.auctionService.GetAuctions(c.Request.Context(), c.DefaultQuery("status", utils.AuctionOpen))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": auctions,
+	})
+}
+
+func (lc *LeaseController) CreateAuction(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.CreateLeaseAuction)
+
+	auction, err := lc.auctionService.CreateAuction(c.Request.Context(), userObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": auction,
+	})
+}
+
+func (lc *LeaseController) CancelAuction(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	auctionObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid auction id", http.StatusBadRequest))
+		return
+	}
+
+	auction, err := lc.auctionService.CancelAuction(c.Request.Context(), userObjectId, auctionObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": auction,
+	})
+}
+
+func (lc *LeaseController) GetBids(c *gin.Context) {
+	auctionObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid auction id", http.StatusBadRequest))
+		return
+	}
+
+	bids, err := lc.auctionService.GetBids(c.Request.Context(), auctionObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": bids,
+	})
+}
+
+func (lc *LeaseController) PlaceBid(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.PlaceBid)
+
+	auctionObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid auction id", http.StatusBadRequest))
+		return
+	}
+
+	bid, err := lc.auctionService.PlaceBid(c.Request.Context(), userObjectId, auctionObjectId, body.Amount)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": bid,
+	})
+}
+
+// respondWithLease runs a lease action for the caller on the lease in the path.
+func (lc *LeaseController) respondWithLease(c *gin.Context, action func(ctx context.Context, userId primitive.ObjectID, leaseId string) (*models.Lease, error)) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	lease, err := action(c.Request.Context(), userObjectId, c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": lease,
+	})
+}
@@ -0,0 +1,449 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MarketController struct {
+	service          *service.MarketService
+	orderBookService *service.OrderBookService
+	storeService     *service.StorefrontService
+	contractService  *service.ContractService
+	auctionService   *service.StockAuctionService
+	forwardService   *service.ForwardService
+}
+
+func NewMarketController(dbClient *mongo.Database) *MarketController {
+	return &MarketController{
+		service:          service.NewMarketService(dbClient),
+		orderBookService: service.NewOrderBookService(dbClient),
+		storeService:     service.NewStorefrontService(dbClient),
+		contractService:  service.NewContractService(dbClient),
+		auctionService:   service.NewStockAuctionService(dbClient),
+		forwardService:   service.NewForwardService(dbClient),
+	}
+}
+
+func (mc *MarketController) SellCrop(c *gin.Context) {
+	userId := c.GetHeader("user_id")
+	userObjectId, _ := primitive.ObjectIDFromHex(userId)
+
+	cropObjectId, err := primitive.ObjectIDFromHex(c.Param("cropid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid crop id", http.StatusBadRequest))
+		return
+	}
+
+	body := c.MustGet("body").(types.SellCrop)
+
+	sale, err := mc.service.SellCrop(context.TODO(), userObjectId, cropObjectId, body.Quantity)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": sale,
+	})
+}
+
+func (mc *MarketController) PlaceOrder(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	cropObjectId, err := primitive.ObjectIDFromHex(c.Param("cropid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid crop id", http.StatusBadRequest))
+		return
+	}
+
+	body := c.MustGet("body").(types.PlaceOrder)
+
+	result, err := mc.orderBookService.PlaceOrder(c.Request.Context(), userObjectId, cropObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": result,
+	})
+}
+
+// GetOrders returns the caller's orders, optionally filtered by the status query.
+func (mc *MarketController) GetOrders(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	orders, err := mc.orderBookService.GetOrders(c.Request.Context(), userObjectId, c.Query("status"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": orders,
+	})
+}
+
+func (mc *MarketController) CancelOrder(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	orderObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid order id", http.StatusBadRequest))
+		return
+	}
+
+	order, err := mc.orderBookService.CancelOrder(c.Request.Context(), userObjectId, orderObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": order,
+	})
+}
+
+// GetOrderBook returns the depth of the crop's book for the grade query, A by default.
+func (mc *MarketController) GetOrderBook(c *gin.Context) {
+	cropObjectId, err := primitive.ObjectIDFromHex(c.Param("cropid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid crop id", http.StatusBadRequest))
+		return
+	}
+
+	depth, err := strconv.Atoi(c.DefaultQuery("depth", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid depth", http.StatusBadRequest))
+		return
+	}
+
+	book, err := mc.orderBookService.GetBook(c.Request.Context(), cropObjectId, c.DefaultQuery("grade", utils.GradeA), depth)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": book,
+	})
+}
+
+// GetMarketPrices returns the crop's current market price of every grade.
+func (mc *MarketController) GetMarketPrices(c *gin.Context) {
+	cropObjectId, err := primitive.ObjectIDFromHex(c.Param("cropid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid crop id", http.StatusBadRequest))
+		return
+	}
+
+	prices, err := mc.service.GetMarketPrices(c.Request.Context(), cropObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": prices,
+	})
+}
+
+// GetStoreListings returns a page of storefront listings matching the query.
+func (mc *MarketController) GetStoreListings(c *gin.Context) {
+	query := c.MustGet("query").(types.StoreQuery)
+
+	listings, total, err := mc.storeService.GetListings(c.Request.Context(), query)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  listings,
+		"total": total,
+	})
+}
+
+func (mc *MarketController) CreateStoreListing(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.ListInStore)
+
+	listing, err := mc.storeService.CreateListing(c.Request.Context(), userObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": listing,
+	})
+}
+
+func (mc *MarketController) CancelStoreListing(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	listingObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid listing id", http.StatusBadRequest))
+		return
+	}
+
+	listing, err := mc.storeService.CancelListing(c.Request.Context(), userObjectId, listingObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": listing,
+	})
+}
+
+func (mc *MarketController) BuyFromStore(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.BuyFromStore)
+
+	listingObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid listing id", http.StatusBadRequest))
+		return
+	}
+
+	trade, err := mc.storeService.Buy(c.Request.Context(), userObjectId, listingObjectId, body.Quantity)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": trade,
+	})
+}
+
+// GetAuctions returns stock auctions with the status query, open ones by default.
+func (mc *MarketController) GetAuctions(c *gin.Context) {
+	auctions, err := mc.auctionService.GetAuctions(c.Request.Context(), c.DefaultQuery("status", utils.AuctionOpen))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": auctions,
+	})
+}
+
+func (mc *MarketController) CreateAuction(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.CreateStockAuction)
+
+	auction, err := mc.auctionService.CreateAuction(c.Request.Context(), userObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": auction,
+	})
+}
+
+func (mc *MarketController) CancelAuction(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	auctionObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid auction id", http.StatusBadRequest))
+		return
+	}
+
+	auction, err := mc.auctionService.CancelAuction(c.Request.Context(), userObjectId, auctionObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": auction,
+	})
+}
+
+func (mc *MarketController) GetAuctionBids(c *gin.Context) {
+	auctionObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid auction id", http.StatusBadRequest))
+		return
+	}
+
+	bids, err := mc.auctionService.GetBids(c.Request.Context(), auctionObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": bids,
+	})
+}
+
+func (mc *MarketController) PlaceAuctionBid(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.PlaceBid)
+
+	auctionObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid auction id", http.StatusBadRequest))
+		return
+	}
+
+	bid, err := mc.auctionService.PlaceBid(c.Request.Context(), userObjectId, auctionObjectId, body.Amount)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": bid,
+	})
+}
+
+// GetForwards returns forward contracts with the status query, open offers by default.
+func (mc *MarketController) GetForwards(c *gin.Context) {
+	forwards, err := mc.forwardService.GetForwards(c.Request.Context(), c.DefaultQuery("status", utils.ForwardOpen))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": forwards,
+	})
+}
+
+func (mc *MarketController) GetUserForwards(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	forwards, err := mc.forwardService.GetUserForwards(c.Request.Context(), userObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": forwards,
+	})
+}
+
+func (mc *MarketController) OfferForward(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+	body := c.MustGet("body").(types.OfferForward)
+
+	forward, err := mc.forwardService.OfferForward(c.Request.Context(), userObjectId, body)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": forward,
+	})
+}
+
+func (mc *MarketController) AcceptForward(c *gin.Context) {
+	mc.handleForward(c, mc.forwardService.AcceptForward)
+}
+
+func (mc *MarketController) CancelForward(c *gin.Context) {
+	mc.handleForward(c, mc.forwardService.CancelForward)
+}
+
+// handleForward runs action on the forward contract in the path for the caller and responds with the result.
+func (mc *MarketController) handleForward(c *gin.Context, action func(context.Context, primitive.ObjectID, primitive.ObjectID) (*models.ForwardContract, error)) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	contractObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid contract id", http.StatusBadRequest))
+		return
+	}
+
+	forward, err := action(c.Request.Context(), userObjectId, contractObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": forward,
+	})
+}
+
+// GetContractBoard returns the NPC delivery contracts open for acceptance.
+func (mc *MarketController) GetContractBoard(c *gin.Context) {
+	contracts, err := mc.contractService.GetBoard(c.Request.Context())
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": contracts,
+	})
+}
+
+func (mc *MarketController) GetUserContracts(c *gin.Context) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	contracts, err := mc.contractService.GetUserContracts(c.Request.Context(), userObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": contracts,
+	})
+}
+
+func (mc *MarketController) AcceptContract(c *gin.Context) {
+	mc.handleContract(c, mc.contractService.Accept)
+}
+
+func (mc *MarketController) FulfilContract(c *gin.Context) {
+	mc.handleContract(c, mc.contractService.Fulfil)
+}
+
+func (mc *MarketController) AbandonContract(c *gin.Context) {
+	mc.handleContract(c, mc.contractService.Abandon)
+}
+
+// handleContract runs action on the contract in the path for the caller and responds with the result.
+func (mc *MarketController) handleContract(c *gin.Context, action func(context.Context, primitive.ObjectID, primitive.ObjectID) (*models.DeliveryContract, error)) {
+	userObjectId, _ := primitive.ObjectIDFromHex(c.GetHeader("user_id"))
+
+	contractObjectId, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid contract id", http.StatusBadRequest))
+		return
+	}
+
+	contract, err := action(c.Request.Context(), userObjectId, contractObjectId)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": contract,
+	})
+}
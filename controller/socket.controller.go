@@ -0,0 +1,363 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	socketSendBuffer     = 32
+	socketEventBuffer    = 64
+	socketMaxMessage     = 64 << 10
+	socketWriteTimeout   = 10 * time.Second
+	socketCommandTimeout = 15 * time.Second
+
+	// Each connection may send socketCommandBurst commands at once and socketCommandRate per second after that.
+	socketCommandRate  = 5
+	socketCommandBurst = 10
+)
+
+type SocketController struct {
+	userService    *service.UserService
+	cropService    *service.CropService
+	harvestService *service.HarvestService
+	marketService  *service.MarketService
+	eventService   *service.EventService
+	validate       *validator.Validate
+}
+
+func NewSocketController(dbClient *mongo.Database) *SocketController {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("name")
+	})
+
+	return &SocketController{
+		userService:    service.NewUserService(dbClient),
+		cropService:    service.NewCropService(dbClient),
+		harvestService: service.NewHarvestService(dbClient),
+		marketService:  service.NewMarketService(dbClient),
+		eventService:   service.NewEventService(dbClient),
+		validate:       validate,
+	}
+}
+
+// Connect upgrades to a WebSocket session for the user. Browsers cannot set headers on the
+// upgrade request, so the user id may also come from the user_id query parameter. The user is
+// online while at least one session is open.
+func (sc *SocketController) Connect(c *gin.Context) {
+	userId := c.GetHeader("user_id")
+	if userId == "" {
+		userId = c.Query("user_id")
+	}
+
+	userObjectId, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, utils.NewHttpError(c, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	if !originAllowed(c.Request) {
+		c.JSON(http.StatusForbidden, utils.NewHttpError(c, "origin not allowed", http.StatusForbidden))
+		return
+	}
+
+	if err := sc.userService.MarkOnline(c.Request.Context(), userObjectId); err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := sc.userService.MarkOffline(ctx, userObjectId); err != nil {
+			fmt.Printf("Error marking %s offline: %v\n", userId, err)
+		}
+	}()
+
+	server := websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			sc.serve(conn, userObjectId)
+		},
+	}
+
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// originAllowed stops other sites from opening sessions in a visitor's name: a browser upgrade
+// must come from the server's own origin or one listed in ALLOWED_ORIGINS. Clients that are not
+// browsers send no Origin and are let through.
+func originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	if strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+
+	for _, allowed := range utils.AllowedOrigins() {
+		if strings.EqualFold(allowed, strings.TrimSuffix(origin, "/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type socketSession struct {
+	controller *SocketController
+	conn       *websocket.Conn
+	userId     primitive.ObjectID
+
+	send    chan types.SocketMessage
+	done    chan struct{}
+	limiter *tokenBucket
+}
+
+func (sc *SocketController) serve(conn *websocket.Conn, userId primitive.ObjectID) {
+	conn.MaxPayloadBytes = socketMaxMessage
+
+	session := &socketSession{
+		controller: sc,
+		conn:       conn,
+		userId:     userId,
+		send:       make(chan types.SocketMessage, socketSendBuffer),
+		done:       make(chan struct{}),
+		limiter:    newTokenBucket(socketCommandRate, socketCommandBurst),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		session.writeLoop()
+	}()
+
+	session.readLoop()
+
+	close(session.done)
+	conn.Close()
+	wg.Wait()
+}
+
+// readLoop handles one command at a time and waits for room in the send buffer before reading the
+// next, so a client that does not read its replies stops being read from.
+func (s *socketSession) readLoop() {
+	for {
+		var command types.SocketCommand
+
+		err := websocket.JSON.Receive(s.conn, &command)
+		if err != nil {
+			if !isRecoverableReadError(err) {
+				return
+			}
+
+			if !s.reply(types.SocketMessage{Type: "error", Status: http.StatusBadRequest, Error: "invalid message"}) {
+				return
+			}
+			continue
+		}
+
+		var reply types.SocketMessage
+		if s.limiter.allow() {
+			reply = s.controller.handle(s.userId, command)
+		} else {
+			reply = types.SocketMessage{ID: command.ID, Type: "error", Status: http.StatusTooManyRequests, Error: "rate limit exceeded"}
+		}
+
+		if !s.reply(reply) {
+			return
+		}
+	}
+}
+
+func (s *socketSession) reply(message types.SocketMessage) bool {
+	select {
+	case s.send <- message:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+// writeLoop is the only writer on the connection. It relays command replies and the user's domain
+// events; when the broker drops the subscription for falling behind it resubscribes and tells the
+// client to refetch its state.
+func (s *socketSession) writeLoop() {
+	for {
+		subscription := s.controller.eventService.Subscribe(s.userId, socketEventBuffer)
+		lagged, err := s.relay(subscription.C)
+		subscription.Close()
+
+		if err != nil || !lagged {
+			// Unblock the reader if the write side failed first.
+			s.conn.Close()
+			return
+		}
+
+		if err := s.write(types.SocketMessage{Type: "resync"}); err != nil {
+			s.conn.Close()
+			return
+		}
+	}
+}
+
+func (s *socketSession) relay(events <-chan models.Event) (lagged bool, err error) {
+	for {
+		select {
+		case <-s.done:
+			return false, nil
+		case message := <-s.send:
+			if err := s.write(message); err != nil {
+				return false, err
+			}
+		case event, ok := <-events:
+			if !ok {
+				return true, nil
+			}
+
+			if err := s.write(types.SocketMessage{Type: "event", Data: event}); err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+func (s *socketSession) write(message types.SocketMessage) error {
+	s.conn.SetWriteDeadline(time.Now().Add(socketWriteTimeout))
+
+	return websocket.JSON.Send(s.conn, message)
+}
+
+// handle routes a command to the service its REST endpoint uses.
+func (sc *SocketController) handle(userId primitive.ObjectID, command types.SocketCommand) types.SocketMessage {
+	ctx, cancel := context.WithTimeout(context.Background(), socketCommandTimeout)
+	defer cancel()
+
+	var data any
+	var err error
+
+	switch command.Type {
+	case "plant":
+		var payload types.PlantCommand
+		if err = sc.decode(command.Payload, &payload); err == nil {
+			data, err = sc.cropService.PlantCropOn(ctx, userId, objectID(payload.CropID), payload.PlantCrop)
+		}
+	case "harvest":
+		var payload types.HarvestCommand
+		if err = sc.decode(command.Payload, &payload); err == nil {
+			data, err = sc.harvestService.HarvestCrop(ctx, userId, objectID(payload.PlantingID))
+		}
+	case "sell":
+		var payload types.SellCommand
+		if err = sc.decode(command.Payload, &payload); err == nil {
+			data, err = sc.marketService.SellCrop(ctx, userId, objectID(payload.CropID), payload.Quantity)
+		}
+	default:
+		err = service.NewServiceError(http.StatusBadRequest, "unknown command %q", command.Type)
+	}
+
+	if err != nil {
+		return types.SocketMessage{ID: command.ID, Type: "error", Status: service.ErrorStatus(err), Error: err.Error()}
+	}
+
+	return types.SocketMessage{ID: command.ID, Type: "result", Data: data}
+}
+
+// decode unpacks and validates a command payload with the same tags the REST ValidateRequest checks.
+func (sc *SocketController) decode(raw json.RawMessage, out any) error {
+	if err := json.Unmarshal(raw, out); err != nil {
+		return service.NewServiceError(http.StatusBadRequest, "invalid payload")
+	}
+
+	if err := sc.validate.Struct(out); err != nil {
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return service.NewServiceError(http.StatusBadRequest, "invalid payload")
+		}
+
+		fields := make([]string, len(errs))
+		for i, e := range errs {
+			if e.Tag() == "required" {
+				fields[i] = fmt.Sprintf("%s is required", e.Field())
+			} else {
+				fields[i] = fmt.Sprintf("%s is invalid", e.Field())
+			}
+		}
+
+		return service.NewServiceError(http.StatusBadRequest, "%s", strings.Join(fields, ", "))
+	}
+
+	return nil
+}
+
+func objectID(hex string) primitive.ObjectID {
+	id, _ := primitive.ObjectIDFromHex(hex)
+	return id
+}
+
+// isRecoverableReadError reports whether the connection survives a failed read: the message was
+// malformed or too large, rather than the connection being closed.
+func isRecoverableReadError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+		return false
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, websocket.ErrFrameTooLarge)
+}
+
+// tokenBucket is a per-connection rate limiter refilling rate tokens per second up to burst.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst float64) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+func (b *tokenBucket) allow() bool {
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/events"
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	streamBufferSize     = 64
+	streamReplayPageSize = 100
+	streamHeartbeat      = 15 * time.Second
+)
+
+// streamEventTypes are the events pushed to web clients; the rest stay on WatchFarm.
+var streamEventTypes = map[string]bool{
+	utils.EventGrowthUpdated:   true,
+	utils.EventGrowthMilestone: true,
+	utils.EventHarvestReady:    true,
+	utils.EventOutbreak:        true,
+	utils.EventPriceChanged:    true,
+	utils.EventTradeOffer:      true,
+	utils.EventLeaseRequest:    true,
+	utils.EventOutbid:          true,
+	utils.EventAuctionWon:      true,
+	utils.EventOrderFilled:     true,
+	utils.EventStoreSale:       true,
+	utils.EventContractFailed:  true,
+	utils.EventForwardSettled:  true,
+}
+
+type StreamController struct {
+	eventService     *service.EventService
+	warehouseService *service.WarehouseService
+	hub              *events.Hub
+}
+
+func NewStreamController(dbClient *mongo.Database) *StreamController {
+	return &StreamController{
+		eventService:     service.NewEventService(dbClient),
+		warehouseService: service.NewWarehouseService(dbClient),
+		hub:              events.DefaultHub,
+	}
+}
+
+// Stream serves the user's events as Server-Sent Events. Stored events carry their sequence as the SSE
+// id, so a reconnecting EventSource resumes through Last-Event-ID; price changes are only sent for
+// crops the user holds. EventSource cannot set headers, so the user id may also come from the
+// user_id query parameter.
+func (sc *StreamController) Stream(c *gin.Context) {
+	userId := c.GetHeader("user_id")
+	if userId == "" {
+		userId = c.Query("user_id")
+	}
+
+	userObjectId, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, utils.NewHttpError(c, "Unauthorized", http.StatusUnauthorized))
+		return
+	}
+
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.Query("last_event_id")
+	}
+	cursor, _ := strconv.ParseInt(lastEventId, 10, 64)
+	resume := cursor > 0
+
+	// A new connection starts at the latest event instead of replaying the whole history.
+	if !resume {
+		cursor, err = sc.eventService.LatestSequence(c.Request.Context())
+		if err != nil {
+			respondWithError(c, err)
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	stream := &eventStream{
+		controller: sc,
+		c:          c,
+		userId:     userObjectId,
+		cursor:     cursor,
+		replayed:   cursor,
+		resume:     resume,
+	}
+
+	ctx := c.Request.Context()
+
+	// A dropped subscription returns nil; resubscribe and replay what was missed.
+	for {
+		if err := stream.run(ctx); err != nil {
+			return
+		}
+		stream.resume = true
+	}
+}
+
+type eventStream struct {
+	controller *StreamController
+	c          *gin.Context
+	userId     primitive.ObjectID
+	listener   *events.Listener
+
+	cursor   int64 // highest stored sequence sent
+	replayed int64 // highest stored sequence read, sent or filtered out
+	resume   bool  // events after replayed may have been missed and must be read from the collection
+	pending  []models.Event
+	crops    map[string]bool
+}
+
+// run follows the feed from memory. The broker pushes stored events once they are committed, the
+// user's on the subscription and market-wide ones through the hub, and after each batch the hub
+// passes on a Committed marker. Events wait in pending until a marker covers them and then go out
+// in sequence order. The collection is only read when resuming, or when a marker shows the process
+// committed events this stream never saw.
+func (s *eventStream) run(ctx context.Context) error {
+	subscription := s.controller.eventService.Subscribe(s.userId, streamBufferSize)
+	defer subscription.Close()
+
+	crops, err := s.loadCrops(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.listener = s.controller.hub.Listen(streamBufferSize, crops)
+	defer s.listener.Close()
+
+	s.pending = nil
+	if s.resume {
+		if err := s.replay(ctx); err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-heartbeat.C:
+			if err := s.heartbeat(); err != nil {
+				return err
+			}
+		case event, ok := <-subscription.C:
+			if !ok {
+				return nil
+			}
+			if err := s.receive(event); err != nil {
+				return err
+			}
+		case event, ok := <-s.listener.C:
+			if !ok {
+				return nil
+			}
+			if event.Type != events.Committed {
+				s.hold(event)
+				continue
+			}
+
+			// The broker pushed the user's events up to the marker before the marker itself, so they
+			// are already waiting on the subscription.
+			open, err := s.drain(subscription)
+			if err != nil || !open {
+				return err
+			}
+			if err := s.commit(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// receive sends a transient event straight away and holds a stored one for its marker.
+func (s *eventStream) receive(event models.Event) error {
+	if event.Sequence == 0 {
+		return s.send(event)
+	}
+
+	s.hold(event)
+
+	return nil
+}
+
+func (s *eventStream) hold(event models.Event) {
+	if event.Sequence > s.replayed {
+		s.pending = append(s.pending, event)
+	}
+}
+
+// drain receives what is buffered on the subscription without waiting. It reports false once the
+// broker has dropped the subscription.
+func (s *eventStream) drain(subscription *events.Subscription) (bool, error) {
+	for {
+		select {
+		case event, ok := <-subscription.C:
+			if !ok {
+				return false, nil
+			}
+			if err := s.receive(event); err != nil {
+				return false, err
+			}
+		default:
+			return true, nil
+		}
+	}
+}
+
+// commit sends the pending events the marker covers in sequence order. A marker starting after the
+// last sequence read means the process committed events before this stream subscribed, so those are
+// read from the collection first.
+func (s *eventStream) commit(ctx context.Context, marker models.Event) error {
+	if after, _ := marker.Payload["after"].(int64); after > s.replayed {
+		if err := s.replay(ctx); err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(s.pending, func(i, j int) bool { return s.pending[i].Sequence < s.pending[j].Sequence })
+
+	rest := s.pending[:0]
+	for _, event := range s.pending {
+		if event.Sequence > marker.Sequence {
+			rest = append(rest, event)
+			continue
+		}
+
+		if event.Sequence <= s.replayed {
+			continue
+		}
+
+		if err := s.deliver(ctx, event); err != nil {
+			return err
+		}
+	}
+	s.pending = rest
+
+	s.replayed = max(s.replayed, marker.Sequence)
+
+	return nil
+}
+
+// replay sends the committed events after the last sequence it read.
+func (s *eventStream) replay(ctx context.Context) error {
+	for {
+		stored, committed, err := s.controller.eventService.GetFeedSince(ctx, s.userId, s.replayed, streamReplayPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range stored {
+			if err := s.deliver(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if len(stored) < streamReplayPageSize {
+			s.replayed = max(s.replayed, committed)
+			return nil
+		}
+	}
+}
+
+// deliver sends a stored event and moves past it. A warehouse change refreshes the crops whose
+// prices the stream follows.
+func (s *eventStream) deliver(ctx context.Context, event models.Event) error {
+	if event.Type == utils.EventWarehouseChanged {
+		crops, err := s.loadCrops(ctx)
+		if err != nil {
+			return err
+		}
+		s.listener.SetCrops(crops)
+	}
+
+	if err := s.send(event); err != nil {
+		return err
+	}
+
+	s.replayed = event.Sequence
+
+	return nil
+}
+
+func (s *eventStream) send(event models.Event) error {
+	if !streamEventTypes[event.Type] {
+		return nil
+	}
+
+	if event.Type == utils.EventPriceChanged {
+		cropId, _ := event.Payload["crop_id"].(string)
+		if !s.crops[cropId] {
+			return nil
+		}
+	}
+
+	message := sse.Event{
+		Event: event.Type,
+		Data:  event.Payload,
+	}
+
+	if event.Sequence != 0 {
+		message.Id = strconv.FormatInt(event.Sequence, 10)
+	}
+
+	if err := sse.Encode(s.c.Writer, message); err != nil {
+		return err
+	}
+
+	s.c.Writer.Flush()
+
+	if event.Sequence > s.cursor {
+		s.cursor = event.Sequence
+	}
+
+	return nil
+}
+
+// heartbeat writes an SSE comment so proxies keep the connection open and dead clients are noticed.
+func (s *eventStream) heartbeat() error {
+	if _, err := fmt.Fprint(s.c.Writer, ": ping\n\n"); err != nil {
+		return err
+	}
+
+	s.c.Writer.Flush()
+
+	return nil
+}
+
+func (s *eventStream) loadCrops(ctx context.Context) ([]string, error) {
+	crops, err := s.controller.warehouseService.GetHeldCropIDs(ctx, s.userId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.crops = make(map[string]bool, len(crops))
+	for _, crop := range crops {
+		s.crops[crop] = true
+	}
+
+	return crops, nil
+}
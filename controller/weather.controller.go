@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WeatherController struct {
+	service     *service.WeatherService
+	cropService *service.CropService
+}
+
+func NewWeatherController(dbClient *mongo.Database) *WeatherController {
+	return &WeatherController{
+		service:     service.NewWeatherService(dbClient),
+		cropService: service.NewCropService(dbClient),
+	}
+}
+
+// GetForecast returns today's weather and the next days'. With a crop_id query it also returns how
+// each day would change that crop's growth speed and quality.
+func (wc *WeatherController) GetForecast(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid days", http.StatusBadRequest))
+		return
+	}
+
+	var crop *models.Crop
+
+	if cropId := c.Query("crop_id"); cropId != "" {
+		cropObjectId, err := primitive.ObjectIDFromHex(cropId)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.NewHttpError(c, "invalid crop id", http.StatusBadRequest))
+			return
+		}
+
+		crop, err = wc.cropService.GetCropById(cropObjectId)
+		if err != nil {
+			respondWithError(c, err)
+			return
+		}
+	}
+
+	forecast, err := wc.service.GetForecast(c.Request.Context(), days, crop)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"current":  forecast[0],
+			"forecast": forecast[1:],
+		},
+	})
+}
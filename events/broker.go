@@ -0,0 +1,127 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/hrutik1235/farming-server/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Broker fans domain events out to the live subscribers of each user within this process.
+// Stored events can always be replayed from the events collection, so a subscriber that
+// falls behind is dropped instead of blocking publishers.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[primitive.ObjectID]map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	C <-chan models.Event
+
+	ch     chan models.Event
+	userID primitive.ObjectID
+	broker *Broker
+	once   sync.Once
+	lagged bool
+}
+
+// Committed is the type of the marker Commit sends after each batch. Its sequence is the highest
+// committed one and its "after" payload the committed sequence the batch started from.
+const Committed = "COMMITTED"
+
+// Default is the broker the services publish to.
+var Default = NewBroker()
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: map[primitive.ObjectID]map[*Subscription]struct{}{},
+	}
+}
+
+// Subscribe registers a subscriber for the user's events with room for buffer pending events.
+func (b *Broker) Subscribe(userID primitive.ObjectID, buffer int) *Subscription {
+	ch := make(chan models.Event, buffer)
+	sub := &Subscription{C: ch, ch: ch, userID: userID, broker: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = map[*Subscription]struct{}{}
+	}
+	b.subscribers[userID][sub] = struct{}{}
+
+	return sub
+}
+
+// Publish delivers the event to every subscriber of its user without blocking. Subscribers whose
+// buffer is full are closed and flagged as lagged.
+func (b *Broker) Publish(event models.Event) {
+	b.mu.RLock()
+	var lagging []*Subscription
+	for sub := range b.subscribers[event.UserID] {
+		select {
+		case sub.ch <- event:
+		default:
+			lagging = append(lagging, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range lagging {
+		sub.close(true)
+	}
+}
+
+// Commit publishes stored events that are committed from after up to through, in sequence order,
+// and then hands the hub a Committed marker for the range. A stream merging its own events with the
+// hub's knows from the marker that nothing up to through is still on its way.
+func (b *Broker) Commit(batch []models.Event, after int64, through int64) {
+	for _, event := range batch {
+		b.Publish(event)
+	}
+
+	b.Publish(models.Event{
+		UserID:   Everyone,
+		Type:     Committed,
+		Sequence: through,
+		Payload:  bson.M{"after": after},
+	})
+}
+
+// Subscribers returns how many live subscriptions the user has.
+func (b *Broker) Subscribers(userID primitive.ObjectID) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers[userID])
+}
+
+// Close unregisters the subscription and closes C.
+func (s *Subscription) Close() {
+	s.close(false)
+}
+
+// Lagged reports whether the broker dropped the subscription for falling behind. Once C is
+// closed a lagged subscriber should resubscribe and replay from its last stored sequence.
+func (s *Subscription) Lagged() bool {
+	s.broker.mu.RLock()
+	defer s.broker.mu.RUnlock()
+
+	return s.lagged
+}
+
+func (s *Subscription) close(lagged bool) {
+	s.once.Do(func() {
+		s.broker.mu.Lock()
+		defer s.broker.mu.Unlock()
+
+		s.lagged = lagged
+		delete(s.broker.subscribers[s.userID], s)
+		if len(s.broker.subscribers[s.userID]) == 0 {
+			delete(s.broker.subscribers, s.userID)
+		}
+		close(s.ch)
+	})
+}
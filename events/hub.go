@@ -0,0 +1,179 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/hrutik1235/farming-server/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Everyone is the user id market-wide events are published under.
+var Everyone = primitive.NilObjectID
+
+// Hub hands market-wide events to the open streams interested in them. It holds a single broker
+// subscription for Everyone and indexes listeners by crop, so a price change costs one send per
+// stream holding that crop rather than one per open stream.
+type Hub struct {
+	broker *Broker
+
+	mu        sync.RWMutex
+	byCrop    map[string]map[*Listener]struct{}
+	listeners map[*Listener]struct{}
+	started   bool
+}
+
+type Listener struct {
+	C <-chan models.Event
+
+	ch     chan models.Event
+	hub    *Hub
+	crops  []string
+	once   sync.Once
+	lagged bool
+}
+
+// DefaultHub routes the market-wide events of the Default broker.
+var DefaultHub = NewHub(Default)
+
+// hubBuffer is the hub's own backlog; it only has to absorb bursts while it dispatches.
+const hubBuffer = 1024
+
+func NewHub(broker *Broker) *Hub {
+	return &Hub{
+		broker:    broker,
+		byCrop:    map[string]map[*Listener]struct{}{},
+		listeners: map[*Listener]struct{}{},
+	}
+}
+
+// Listen registers a listener for market-wide events about the given crops.
+func (h *Hub) Listen(buffer int, crops []string) *Listener {
+	ch := make(chan models.Event, buffer)
+	listener := &Listener{C: ch, ch: ch, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.started {
+		h.started = true
+		go h.run()
+	}
+
+	h.listeners[listener] = struct{}{}
+	h.index(listener, crops)
+
+	return listener
+}
+
+// SetCrops replaces the crops the listener receives events for.
+func (l *Listener) SetCrops(crops []string) {
+	l.hub.mu.Lock()
+	defer l.hub.mu.Unlock()
+
+	if _, ok := l.hub.listeners[l]; !ok {
+		return
+	}
+
+	l.hub.unindex(l)
+	l.hub.index(l, crops)
+}
+
+// Close unregisters the listener and closes C.
+func (l *Listener) Close() {
+	l.close(false)
+}
+
+// Lagged reports whether the hub dropped the listener for falling behind.
+func (l *Listener) Lagged() bool {
+	l.hub.mu.RLock()
+	defer l.hub.mu.RUnlock()
+
+	return l.lagged
+}
+
+func (h *Hub) run() {
+	for {
+		subscription := h.broker.Subscribe(Everyone, hubBuffer)
+
+		for event := range subscription.C {
+			h.dispatch(event)
+		}
+
+		// The hub itself fell behind, so every listener may have missed events; let them replay.
+		h.dropAll()
+	}
+}
+
+func (h *Hub) dispatch(event models.Event) {
+	cropId, _ := event.Payload["crop_id"].(string)
+
+	h.mu.RLock()
+
+	// Every listener gets the Committed markers, whatever crops it follows.
+	targets := h.byCrop[cropId]
+	if event.Type == Committed {
+		targets = h.listeners
+	}
+
+	var lagging []*Listener
+	for listener := range targets {
+		select {
+		case listener.ch <- event:
+		default:
+			lagging = append(lagging, listener)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, listener := range lagging {
+		listener.close(true)
+	}
+}
+
+func (h *Hub) dropAll() {
+	h.mu.RLock()
+	listeners := make([]*Listener, 0, len(h.listeners))
+	for listener := range h.listeners {
+		listeners = append(listeners, listener)
+	}
+	h.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener.close(true)
+	}
+}
+
+// index and unindex must be called with h.mu held.
+func (h *Hub) index(listener *Listener, crops []string) {
+	listener.crops = crops
+
+	for _, crop := range crops {
+		if h.byCrop[crop] == nil {
+			h.byCrop[crop] = map[*Listener]struct{}{}
+		}
+		h.byCrop[crop][listener] = struct{}{}
+	}
+}
+
+func (h *Hub) unindex(listener *Listener) {
+	for _, crop := range listener.crops {
+		delete(h.byCrop[crop], listener)
+		if len(h.byCrop[crop]) == 0 {
+			delete(h.byCrop, crop)
+		}
+	}
+
+	listener.crops = nil
+}
+
+func (l *Listener) close(lagged bool) {
+	l.once.Do(func() {
+		l.hub.mu.Lock()
+		defer l.hub.mu.Unlock()
+
+		l.lagged = lagged
+		l.hub.unindex(l)
+		delete(l.hub.listeners, l)
+		close(l.ch)
+	})
+}
@@ -0,0 +1,140 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/tlsconn"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type userIDKey struct{}
+
+type peerIDKey struct{}
+
+// federationPrefix marks server-to-server methods, which carry the calling server's server_id
+// instead of a user_id.
+var federationPrefix = "/" + farmingv1.FederationService_ServiceDesc.ServiceName + "/"
+
+// publicMethods mirror the REST routes registered before GateValidateUser.
+var publicMethods = map[string]bool{
+	farmingv1.UserService_RegisterUser_FullMethodName: true,
+}
+
+// UnaryAuthInterceptor is the gRPC counterpart of middleware.GateValidateUser: every call outside
+// publicMethods must carry a user_id metadata entry.
+func UnaryAuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		authCtx, err := authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(authCtx, req)
+	}
+}
+
+func StreamAuthInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if publicMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		authCtx, err := authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: authCtx})
+	}
+}
+
+// UserIDFromContext returns the caller set by the auth interceptors.
+func UserIDFromContext(ctx context.Context) (primitive.ObjectID, error) {
+	userId, _ := ctx.Value(userIDKey{}).(string)
+
+	userObjectId, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return primitive.NilObjectID, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	return userObjectId, nil
+}
+
+// PeerIDFromContext returns the calling peer server set by the auth interceptors.
+func PeerIDFromContext(ctx context.Context) (string, error) {
+	peerId, _ := ctx.Value(peerIDKey{}).(string)
+	if peerId == "" {
+		return "", status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+
+	return peerId, nil
+}
+
+func authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if strings.HasPrefix(method, federationPrefix) {
+		return authenticatePeer(ctx, md)
+	}
+
+	values := md.Get("user_id")
+	if len(values) == 0 || values[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+
+	return context.WithValue(ctx, userIDKey{}, values[0]), nil
+}
+
+// authenticatePeer identifies the calling server. Over mutual TLS the client certificate decides and
+// a server_id that disagrees with it is rejected. Without TLS the server_id metadata is only
+// trusted from callers presenting the shared FEDERATION_SECRET.
+func authenticatePeer(ctx context.Context, md metadata.MD) (context.Context, error) {
+	serverId := firstValue(md, "server_id")
+
+	if identity, ok := tlsconn.PeerIdentity(ctx); ok {
+		if serverId != "" && serverId != identity {
+			return nil, status.Error(codes.PermissionDenied, "server_id does not match the client certificate")
+		}
+		serverId = identity
+	} else {
+		secret := utils.FederationSecret()
+		if secret == "" || subtle.ConstantTimeCompare([]byte(firstValue(md, "federation_secret")), []byte(secret)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "peer calls need mutual TLS or the federation secret")
+		}
+	}
+
+	if serverId == "" {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+
+	return context.WithValue(ctx, peerIDKey{}, serverId), nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
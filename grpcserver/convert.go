@@ -0,0 +1,295 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func toHex(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+
+	return id.Hex()
+}
+
+func toPbUser(user *models.User) *farmingv1.User {
+	return &farmingv1.User{
+		Id:            toHex(user.ID),
+		Username:      user.Username,
+		Email:         user.Email,
+		DisplayName:   user.DisplayName,
+		ServerAddress: user.ServerAddress,
+		LastLogin:     toTimestamp(user.LastLogin),
+		IsOnline:      user.IsOnline,
+	}
+}
+
+func toPbWallet(wallet *models.Wallet) *farmingv1.Wallet {
+	return &farmingv1.Wallet{
+		Id:            toHex(wallet.ID),
+		UserId:        toHex(wallet.UserID),
+		Balance:       wallet.Balance,
+		TotalEarnings: wallet.TotalEarnings,
+		TotalSpent:    wallet.TotalSpent,
+		LastUpdated:   toTimestamp(wallet.LastUpdated),
+	}
+}
+
+func toPbLandUnit(unit models.LandUnit) *farmingv1.LandUnit {
+	pbUnit := &farmingv1.LandUnit{
+		Id:          toHex(unit.ID),
+		Land:        unit.Land,
+		OwnerId:     toHex(unit.OwnerID),
+		LesseeId:    toHex(unit.LesseeID),
+		SizeUnits:   int32(unit.SizeUnits),
+		IsLeased:    unit.IsLeased,
+		IsAvailable: unit.IsAvailable,
+		Position:    int32(unit.Position),
+	}
+
+	if unit.Structure != nil {
+		pbUnit.Structure = unit.Structure.Kind
+	}
+
+	if unit.Soil != nil {
+		pbUnit.Soil = &farmingv1.Soil{
+			Nitrogen:       unit.Soil.Nitrogen,
+			Phosphorus:     unit.Soil.Phosphorus,
+			Potassium:      unit.Soil.Potassium,
+			LastCropId:     toHex(unit.Soil.LastCropID),
+			RotationStreak: int32(unit.Soil.RotationStreak),
+		}
+	}
+
+	return pbUnit
+}
+
+func toPbCrop(crop *models.Crop) *farmingv1.Crop {
+	return &farmingv1.Crop{
+		Id:              toHex(crop.ID),
+		Name:            crop.Name,
+		BasePrice:       crop.BasePrice,
+		GrowthTimeHours: int32(crop.GrowthTimeHours),
+		YieldPerUnit:    int32(crop.YieldPerUnit),
+		CostPerUnit:     crop.CostPerUnit,
+		Description:     crop.Description,
+		IsActive:        crop.IsActive,
+	}
+}
+
+func toPbPlantedCrop(planting *models.PlantedCrop) *farmingv1.PlantedCrop {
+	partials := make([]*farmingv1.PartialHarvest, len(planting.PartialHarvests))
+	for i, partial := range planting.PartialHarvests {
+		partials[i] = &farmingv1.PartialHarvest{
+			HarvestId:   toHex(partial.HarvestID),
+			Percentage:  partial.Percentage,
+			Quantity:    int32(partial.Quantity),
+			HarvestedAt: toTimestamp(partial.HarvestedAt),
+			Quality:     partial.Quality,
+		}
+	}
+
+	return &farmingv1.PlantedCrop{
+		Id:                toHex(planting.ID),
+		UserId:            toHex(planting.UserID),
+		CropId:            toHex(planting.CropID),
+		LandUnitIds:       planting.LandUnitIDs,
+		QuantityPlanted:   int32(planting.QuantityPlanted),
+		PlantedAt:         toTimestamp(planting.PlantedAt),
+		ExpectedHarvestAt: toTimestamp(planting.ExpectedHarvestAt),
+		GrowthPercentage:  planting.GrowthPercentage,
+		IsHarvested:       planting.IsHarvested,
+		HarvestedAt:       toTimestamp(planting.HarvestedAt),
+		QualityFactor:     planting.QualityFactor,
+		TotalCost:         planting.TotalCost,
+		ExpectedYield:     int32(planting.ExpectedYield),
+		IsReady:           planting.IsReady,
+		GrowthMilestone:   int32(planting.GrowthMilestone),
+		PartialHarvests:   partials,
+	}
+}
+
+func toPbHarvestResult(result *models.HarvestResult) *farmingv1.HarvestResult {
+	pbResult := &farmingv1.HarvestResult{
+		Id:                toHex(result.ID),
+		PlantingId:        toHex(result.PlantingID),
+		UserId:            toHex(result.UserID),
+		CropId:            toHex(result.CropID),
+		HarvestType:       result.HarvestType,
+		HarvestPercentage: result.HarvestPercentage,
+		Quantity:          int32(result.Quantity),
+		QualityFactor:     result.QualityFactor,
+		BasePrice:         result.BasePrice,
+		ActualPrice:       result.ActualPrice,
+		TotalValue:        result.TotalValue,
+		HarvestedAt:       toTimestamp(result.HarvestedAt),
+		IsPartial:         result.IsPartial,
+	}
+
+	if result.Adjacency != nil {
+		pbResult.AdjacencyQualityChange = result.Adjacency.QualityChange
+		pbResult.AdjacencyYieldBonus = result.Adjacency.YieldBonus
+
+		for _, effect := range result.Adjacency.Effects {
+			pbResult.AdjacencyEffects = append(pbResult.AdjacencyEffects, &farmingv1.AdjacencyEffect{
+				Kind:          effect.Kind,
+				LandUnitId:    toHex(effect.LandUnitID),
+				PlantingId:    toHex(effect.PlantingID),
+				CropId:        toHex(effect.CropID),
+				QualityChange: effect.QualityChange,
+				YieldChange:   effect.YieldChange,
+				Description:   effect.Description,
+			})
+		}
+	}
+
+	return pbResult
+}
+
+func toPbWarehouse(warehouse *models.Warehouse) *farmingv1.Warehouse {
+	items := make([]*farmingv1.WarehouseItem, len(warehouse.Items))
+	for i, item := range warehouse.Items {
+		items[i] = &farmingv1.WarehouseItem{
+			Id:            toHex(item.ID),
+			CropId:        toHex(item.CropID),
+			Quantity:      int32(item.Quantity),
+			BasePrice:     item.BasePrice,
+			CurrentPrice:  item.CurrentPrice,
+			StoredAt:      toTimestamp(item.StoredAt),
+			ExpiresAt:     toTimestamp(item.ExpiresAt),
+			QualityFactor: item.QualityFactor,
+			IsExpired:     item.IsExpired,
+			Source:        item.Source,
+		}
+	}
+
+	return &farmingv1.Warehouse{
+		Id:            toHex(warehouse.ID),
+		UserId:        toHex(warehouse.UserID),
+		TotalCapacity: int32(warehouse.TotalCapacity),
+		UsedCapacity:  int32(warehouse.UsedCapacity),
+		Items:         items,
+	}
+}
+
+var farmEventTypes = map[string]farmingv1.FarmEventType{
+	utils.EventGrowthUpdated:    farmingv1.FarmEventType_FARM_EVENT_TYPE_GROWTH_UPDATED,
+	utils.EventHarvestReady:     farmingv1.FarmEventType_FARM_EVENT_TYPE_HARVEST_READY,
+	utils.EventWarehouseChanged: farmingv1.FarmEventType_FARM_EVENT_TYPE_WAREHOUSE_CHANGED,
+	utils.EventWalletChanged:    farmingv1.FarmEventType_FARM_EVENT_TYPE_WALLET_CHANGED,
+	utils.EventGrowthMilestone:  farmingv1.FarmEventType_FARM_EVENT_TYPE_GROWTH_MILESTONE,
+	utils.EventOutbreak:         farmingv1.FarmEventType_FARM_EVENT_TYPE_OUTBREAK,
+}
+
+// toPbFarmEvent converts a domain event, returning nil for event types the farm feed does not carry.
+func toPbFarmEvent(event models.Event) (*farmingv1.FarmEvent, error) {
+	eventType, ok := farmEventTypes[event.Type]
+	if !ok {
+		return nil, nil
+	}
+
+	pbEvent := &farmingv1.FarmEvent{
+		Cursor:     event.Sequence,
+		Type:       eventType,
+		OccurredAt: toTimestamp(event.CreatedAt),
+	}
+
+	switch event.Type {
+	case utils.EventGrowthUpdated:
+		var payload types.GrowthUpdatedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		pbEvent.Payload = &farmingv1.FarmEvent_Growth{Growth: &farmingv1.GrowthUpdate{
+			PlantingId:        payload.PlantingID,
+			CropId:            payload.CropID,
+			GrowthPercentage:  payload.GrowthPercentage,
+			ExpectedHarvestAt: toTimestamp(payload.ExpectedHarvestAt),
+		}}
+	case utils.EventHarvestReady:
+		var payload types.HarvestReadyPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		pbEvent.Payload = &farmingv1.FarmEvent_HarvestReady{HarvestReady: &farmingv1.HarvestReady{
+			PlantingId:    payload.PlantingID,
+			CropId:        payload.CropID,
+			ExpectedYield: int32(payload.ExpectedYield),
+		}}
+	case utils.EventGrowthMilestone:
+		var payload types.GrowthMilestonePayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		pbEvent.Payload = &farmingv1.FarmEvent_GrowthMilestone{GrowthMilestone: &farmingv1.GrowthMilestone{
+			PlantingId: payload.PlantingID,
+			CropId:     payload.CropID,
+			Milestone:  int32(payload.Milestone),
+		}}
+	case utils.EventOutbreak:
+		var payload types.OutbreakPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		pbEvent.Payload = &farmingv1.FarmEvent_Outbreak{Outbreak: &farmingv1.Outbreak{
+			PlantingId:         payload.PlantingID,
+			CropId:             payload.CropID,
+			Kind:               payload.Kind,
+			InfectedNeighbours: int32(payload.InfectedNeighbours),
+		}}
+	case utils.EventWarehouseChanged:
+		var payload types.WarehouseChangedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		pbEvent.Payload = &farmingv1.FarmEvent_Warehouse{Warehouse: &farmingv1.WarehouseChange{
+			CropId:        payload.CropID,
+			QuantityDelta: int32(payload.QuantityDelta),
+			UsedCapacity:  int32(payload.UsedCapacity),
+			TotalCapacity: int32(payload.TotalCapacity),
+			Source:        payload.Source,
+		}}
+	case utils.EventWalletChanged:
+		var payload types.WalletChangedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return nil, err
+		}
+		pbEvent.Payload = &farmingv1.FarmEvent_Wallet{Wallet: &farmingv1.WalletChange{
+			Balance: payload.Balance,
+			Delta:   payload.Delta,
+			Reason:  payload.Reason,
+		}}
+	}
+
+	return pbEvent, nil
+}
+
+var tradeStatuses = map[string]farmingv1.TradeStatus{
+	utils.TradePreparing: farmingv1.TradeStatus_TRADE_STATUS_PREPARING,
+	utils.TradePrepared:  farmingv1.TradeStatus_TRADE_STATUS_PREPARED,
+	utils.TradeCommitted: farmingv1.TradeStatus_TRADE_STATUS_COMMITTED,
+	utils.TradeAborted:   farmingv1.TradeStatus_TRADE_STATUS_ABORTED,
+}
+
+func toPbTradeStatus(tradeStatus string) farmingv1.TradeStatus {
+	if pb, ok := tradeStatuses[tradeStatus]; ok {
+		return pb
+	}
+
+	return farmingv1.TradeStatus_TRADE_STATUS_UNKNOWN
+}
@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type cropHandler struct {
+	farmingv1.UnimplementedCropServiceServer
+
+	service *service.CropService
+}
+
+func newCropHandler(dbClient *mongo.Database) *cropHandler {
+	return &cropHandler{
+		service: service.NewCropService(dbClient),
+	}
+}
+
+func (h *cropHandler) CreateCrop(ctx context.Context, req *farmingv1.CreateCropRequest) (*farmingv1.CreateCropResponse, error) {
+	body := types.CreateCrop{
+		Name:            req.GetName(),
+		BasePrice:       req.GetBasePrice(),
+		GrowthTimeHours: int(req.GetGrowthTimeHours()),
+		YieldPerUnit:    int(req.GetYieldPerUnit()),
+		CostPerUnit:     req.GetCostPerUnit(),
+		Description:     req.GetDescription(),
+	}
+
+	if err := validateRequest(body); err != nil {
+		return nil, err
+	}
+
+	crop, err := h.service.CreateCrop(ctx, body)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.CreateCropResponse{Crop: toPbCrop(crop)}, nil
+}
+
+func (h *cropHandler) ListCrops(ctx context.Context, req *farmingv1.ListCropsRequest) (*farmingv1.ListCropsResponse, error) {
+	crops, err := h.service.GetAllCrops(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbCrops := make([]*farmingv1.Crop, len(crops))
+	for i := range crops {
+		pbCrops[i] = toPbCrop(&crops[i])
+	}
+
+	return &farmingv1.ListCropsResponse{Crops: pbCrops}, nil
+}
@@ -0,0 +1,30 @@
+package grpcserver
+
+import (
+	"net/http"
+
+	"github.com/hrutik1235/farming-server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var httpToCode = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusBadGateway:          codes.Unavailable,
+}
+
+// toStatus converts a service error into the gRPC status matching the HTTP status the REST API would return.
+func toStatus(err error) error {
+	code, ok := httpToCode[service.ErrorStatus(err)]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	return status.Error(code, err.Error())
+}
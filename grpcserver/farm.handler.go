@@ -0,0 +1,135 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/events"
+	"github.com/hrutik1235/farming-server/models"
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+const (
+	watchReplayPageSize = 100
+	watchBufferSize     = 64
+
+	watchCatchUpInterval = 15 * time.Second
+)
+
+type farmHandler struct {
+	farmingv1.UnimplementedFarmServiceServer
+
+	eventService *service.EventService
+}
+
+func newFarmHandler(dbClient *mongo.Database) *farmHandler {
+	return &farmHandler{
+		eventService: service.NewEventService(dbClient),
+	}
+}
+
+// WatchFarm replays the caller's stored events after last_event_cursor and then relays live ones.
+// The live subscription is opened before replaying so nothing published in between is missed;
+// if the broker drops the stream for falling behind, it resubscribes and replays the gap.
+func (h *farmHandler) WatchFarm(req *farmingv1.WatchFarmRequest, stream grpc.ServerStreamingServer[farmingv1.FarmEvent]) error {
+	ctx := stream.Context()
+
+	userId, err := UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	cursor := req.GetLastEventCursor()
+
+	for {
+		subscription := h.eventService.Subscribe(userId, watchBufferSize)
+
+		cursor, err = h.replay(ctx, userId, cursor, stream)
+		if err == nil {
+			cursor, err = h.relay(ctx, userId, subscription, cursor, stream)
+		}
+
+		subscription.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (h *farmHandler) replay(ctx context.Context, userId primitive.ObjectID, cursor int64, stream grpc.ServerStreamingServer[farmingv1.FarmEvent]) (int64, error) {
+	for {
+		stored, err := h.eventService.GetEventsSince(ctx, userId, cursor, watchReplayPageSize)
+		if err != nil {
+			return cursor, toStatus(err)
+		}
+
+		for _, event := range stored {
+			if err := sendFarmEvent(stream, event); err != nil {
+				return cursor, err
+			}
+			cursor = event.Sequence
+		}
+
+		if len(stored) < watchReplayPageSize {
+			return cursor, nil
+		}
+	}
+}
+
+// relay forwards live events until the client goes away (error) or the subscription is dropped
+// (nil). Transient events are sent as they come. A stored event only wakes a replay, so stored
+// events always go out in sequence order even when they were published out of order; the ticker
+// picks up events held back by a gap that has since timed out.
+func (h *farmHandler) relay(ctx context.Context, userId primitive.ObjectID, subscription *events.Subscription, cursor int64, stream grpc.ServerStreamingServer[farmingv1.FarmEvent]) (int64, error) {
+	catchUp := time.NewTicker(watchCatchUpInterval)
+	defer catchUp.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return cursor, ctx.Err()
+		case <-catchUp.C:
+		case event, ok := <-subscription.C:
+			if !ok {
+				return cursor, nil
+			}
+
+			if event.Sequence == 0 {
+				if err := sendFarmEvent(stream, event); err != nil {
+					return cursor, err
+				}
+				continue
+			}
+
+			// Already sent during replay.
+			if event.Sequence <= cursor {
+				continue
+			}
+		}
+
+		var err error
+		if cursor, err = h.replay(ctx, userId, cursor, stream); err != nil {
+			return cursor, err
+		}
+	}
+}
+
+func sendFarmEvent(stream grpc.ServerStreamingServer[farmingv1.FarmEvent], event models.Event) error {
+	pbEvent, err := toPbFarmEvent(event)
+	if err != nil {
+		fmt.Printf("Skipping malformed %s event %d: %v\n", event.Type, event.Sequence, err)
+		return nil
+	}
+
+	if pbEvent == nil {
+		return nil
+	}
+
+	return stream.Send(pbEvent)
+}
@@ -0,0 +1,161 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/hrutik1235/farming-server/models"
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type federationHandler struct {
+	farmingv1.UnimplementedFederationServiceServer
+
+	federationService *service.FederationService
+}
+
+func newFederationHandler(dbClient *mongo.Database) *federationHandler {
+	return &federationHandler{
+		federationService: service.NewFederationService(dbClient),
+	}
+}
+
+func (h *federationHandler) RegisterPeer(ctx context.Context, req *farmingv1.RegisterPeerRequest) (*farmingv1.RegisterPeerResponse, error) {
+	peerId, err := PeerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetPeer().GetServerId() != peerId {
+		return nil, status.Error(codes.PermissionDenied, "server_id does not match the registering peer")
+	}
+
+	if _, err := h.federationService.RegisterPeer(ctx, peerId, req.GetPeer().GetName(), req.GetPeer().GetAddress()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.RegisterPeerResponse{
+		Peer: &farmingv1.PeerInfo{
+			ServerId: utils.ServerID(),
+			Name:     utils.ServerID(),
+			Address:  utils.ServerAddress(),
+		},
+	}, nil
+}
+
+func (h *federationHandler) ExchangeDirectory(ctx context.Context, req *farmingv1.ExchangeDirectoryRequest) (*farmingv1.ExchangeDirectoryResponse, error) {
+	peerId, err := PeerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.federationService.GetPeer(ctx, peerId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	entries := make([]models.DirectoryEntry, len(req.GetEntries()))
+	for i, entry := range req.GetEntries() {
+		entries[i] = models.DirectoryEntry{
+			PeerID:        peerId,
+			RemoteUserID:  entry.GetUserId(),
+			Username:      entry.GetUsername(),
+			DisplayName:   entry.GetDisplayName(),
+			ServerAddress: entry.GetServerAddress(),
+		}
+	}
+
+	if err := h.federationService.SaveDirectory(ctx, peerId, entries); err != nil {
+		return nil, toStatus(err)
+	}
+
+	users, err := h.federationService.GetLocalDirectory(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	local := make([]*farmingv1.DirectoryEntry, len(users))
+	for i, user := range users {
+		local[i] = &farmingv1.DirectoryEntry{
+			UserId:        user.ID.Hex(),
+			Username:      user.Username,
+			DisplayName:   user.DisplayName,
+			ServerAddress: user.ServerAddress,
+		}
+	}
+
+	return &farmingv1.ExchangeDirectoryResponse{Entries: local}, nil
+}
+
+func (h *federationHandler) PrepareTrade(ctx context.Context, req *farmingv1.PrepareTradeRequest) (*farmingv1.PrepareTradeResponse, error) {
+	peerId, err := PeerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := req.GetTerms()
+	if terms.GetTradeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "trade_id is required")
+	}
+
+	prepared, reason, quality, err := h.federationService.PrepareTrade(ctx, peerId, types.FederatedTradeTerms{
+		TradeID:        terms.GetTradeId(),
+		SellerID:       terms.GetSellerId(),
+		SellerServerID: terms.GetSellerServerId(),
+		BuyerID:        terms.GetBuyerId(),
+		BuyerServerID:  terms.GetBuyerServerId(),
+		CropName:       terms.GetCropName(),
+		Quantity:       int(terms.GetQuantity()),
+		PricePerUnit:   terms.GetPricePerUnit(),
+		QualityFactor:  terms.GetQualityFactor(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.PrepareTradeResponse{Prepared: prepared, Reason: reason, QualityFactor: quality}, nil
+}
+
+func (h *federationHandler) CommitTrade(ctx context.Context, req *farmingv1.TradeDecisionRequest) (*farmingv1.TradeDecisionResponse, error) {
+	return h.decide(ctx, req.GetTradeId(), utils.TradeCommitted)
+}
+
+func (h *federationHandler) AbortTrade(ctx context.Context, req *farmingv1.TradeDecisionRequest) (*farmingv1.TradeDecisionResponse, error) {
+	return h.decide(ctx, req.GetTradeId(), utils.TradeAborted)
+}
+
+func (h *federationHandler) GetTradeStatus(ctx context.Context, req *farmingv1.GetTradeStatusRequest) (*farmingv1.GetTradeStatusResponse, error) {
+	peerId, err := PeerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeStatus, err := h.federationService.GetCoordinatedTradeStatus(ctx, peerId, req.GetTradeId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.GetTradeStatusResponse{Status: toPbTradeStatus(tradeStatus)}, nil
+}
+
+func (h *federationHandler) decide(ctx context.Context, tradeId string, decision string) (*farmingv1.TradeDecisionResponse, error) {
+	peerId, err := PeerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tradeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "trade_id is required")
+	}
+
+	tradeStatus, err := h.federationService.DecideTrade(ctx, peerId, tradeId, decision)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.TradeDecisionResponse{Status: toPbTradeStatus(tradeStatus)}, nil
+}
@@ -0,0 +1,43 @@
+package grpcserver
+
+import (
+	"context"
+
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type harvestHandler struct {
+	farmingv1.UnimplementedHarvestServiceServer
+
+	service *service.HarvestService
+}
+
+func newHarvestHandler(dbClient *mongo.Database) *harvestHandler {
+	return &harvestHandler{
+		service: service.NewHarvestService(dbClient),
+	}
+}
+
+func (h *harvestHandler) HarvestCrop(ctx context.Context, req *farmingv1.HarvestCropRequest) (*farmingv1.HarvestCropResponse, error) {
+	userId, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plantingId, err := primitive.ObjectIDFromHex(req.GetPlantingId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid planting_id")
+	}
+
+	result, err := h.service.HarvestCrop(ctx, userId, plantingId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.HarvestCropResponse{Result: toPbHarvestResult(result)}, nil
+}
@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"context"
+
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type plantingHandler struct {
+	farmingv1.UnimplementedPlantingServiceServer
+
+	cropService *service.CropService
+}
+
+func newPlantingHandler(dbClient *mongo.Database) *plantingHandler {
+	return &plantingHandler{
+		cropService: service.NewCropService(dbClient),
+	}
+}
+
+func (h *plantingHandler) PlantCrop(ctx context.Context, req *farmingv1.PlantCropRequest) (*farmingv1.PlantCropResponse, error) {
+	userId, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cropId, err := primitive.ObjectIDFromHex(req.GetCropId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid crop_id")
+	}
+
+	selection := types.PlantCrop{
+		LandUnits:   int(req.GetLandUnits()),
+		LandUnitIDs: req.GetLandUnitIds(),
+	}
+
+	if region := req.GetRegion(); region != nil {
+		selection.Region = &types.LandRegion{
+			X:      int(region.GetX()),
+			Y:      int(region.GetY()),
+			Width:  int(region.GetWidth()),
+			Height: int(region.GetHeight()),
+		}
+	}
+
+	planting, err := h.cropService.PlantCropOn(ctx, userId, cropId, selection)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.PlantCropResponse{Planting: toPbPlantedCrop(planting)}, nil
+}
+
+func (h *plantingHandler) ListPlantings(ctx context.Context, req *farmingv1.ListPlantingsRequest) (*farmingv1.ListPlantingsResponse, error) {
+	userId, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plantings, err := h.cropService.GetUserPlantedCrops(ctx, userId, true)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbPlantings := make([]*farmingv1.PlantedCrop, len(plantings))
+	for i := range plantings {
+		pbPlantings[i] = toPbPlantedCrop(&plantings[i])
+	}
+
+	return &farmingv1.ListPlantingsResponse{Plantings: pbPlantings}, nil
+}
@@ -0,0 +1,78 @@
+package grpcserver
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer builds the gRPC server exposing the same services the REST controllers use.
+func NewServer(dbClient *mongo.Database, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor()),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor()),
+	)
+
+	server := grpc.NewServer(opts...)
+
+	farmingv1.RegisterUserServiceServer(server, newUserHandler(dbClient))
+	farmingv1.RegisterWalletServiceServer(server, newWalletHandler(dbClient))
+	farmingv1.RegisterCropServiceServer(server, newCropHandler(dbClient))
+	farmingv1.RegisterPlantingServiceServer(server, newPlantingHandler(dbClient))
+	farmingv1.RegisterHarvestServiceServer(server, newHarvestHandler(dbClient))
+	farmingv1.RegisterWarehouseServiceServer(server, newWarehouseHandler(dbClient))
+	farmingv1.RegisterFarmServiceServer(server, newFarmHandler(dbClient))
+	farmingv1.RegisterFederationServiceServer(server, newFederationHandler(dbClient))
+
+	return server
+}
+
+// Start serves the gRPC API on port until the listener fails.
+func Start(port string, dbClient *mongo.Database, opts ...grpc.ServerOption) error {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port %s: %w", port, err)
+	}
+
+	return NewServer(dbClient, opts...).Serve(listener)
+}
+
+var validate = newValidator()
+
+// newValidator reports fields by their `name` tag, like the REST error messages do.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("name")
+	})
+
+	return v
+}
+
+// validateRequest applies the same struct tags the REST ValidateRequest middleware checks.
+func validateRequest(body any) error {
+	if err := validate.Struct(body); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			fields := make([]string, len(errs))
+			for i, e := range errs {
+				if e.Tag() == "required" {
+					fields[i] = fmt.Sprintf("%s is required", e.Field())
+				} else {
+					fields[i] = fmt.Sprintf("%s is invalid", e.Field())
+				}
+			}
+			return status.Error(codes.InvalidArgument, strings.Join(fields, ", "))
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type userHandler struct {
+	farmingv1.UnimplementedUserServiceServer
+
+	service *service.UserService
+}
+
+func newUserHandler(dbClient *mongo.Database) *userHandler {
+	return &userHandler{
+		service: service.NewUserService(dbClient),
+	}
+}
+
+func (h *userHandler) RegisterUser(ctx context.Context, req *farmingv1.RegisterUserRequest) (*farmingv1.RegisterUserResponse, error) {
+	body := types.RegisterUser{
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		Username: req.GetUsername(),
+	}
+
+	if err := validateRequest(body); err != nil {
+		return nil, err
+	}
+
+	user, existed, err := h.service.RegisterUser(ctx, body)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	message := fmt.Sprintf("User registered with name %s", body.Name)
+	if existed {
+		message = "User already exists"
+	}
+
+	return &farmingv1.RegisterUserResponse{
+		UserId:        user.ID.Hex(),
+		AlreadyExists: existed,
+		Message:       message,
+	}, nil
+}
+
+func (h *userHandler) GetUser(ctx context.Context, req *farmingv1.GetUserRequest) (*farmingv1.GetUserResponse, error) {
+	userId, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.service.GetUserById(userId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	wallet, err := h.service.GetUserWallet(userId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	landUnits, err := h.service.GetUserLandUnits(userId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	land := make([]*farmingv1.LandUnit, len(landUnits))
+	for i, unit := range landUnits {
+		land[i] = toPbLandUnit(unit)
+	}
+
+	return &farmingv1.GetUserResponse{
+		User:   toPbUser(user),
+		Wallet: toPbWallet(wallet),
+		Land:   land,
+	}, nil
+}
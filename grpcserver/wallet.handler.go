@@ -0,0 +1,35 @@
+package grpcserver
+
+import (
+	"context"
+
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type walletHandler struct {
+	farmingv1.UnimplementedWalletServiceServer
+
+	userService *service.UserService
+}
+
+func newWalletHandler(dbClient *mongo.Database) *walletHandler {
+	return &walletHandler{
+		userService: service.NewUserService(dbClient),
+	}
+}
+
+func (h *walletHandler) GetWallet(ctx context.Context, req *farmingv1.GetWalletRequest) (*farmingv1.GetWalletResponse, error) {
+	userId, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := h.userService.GetUserWallet(userId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.GetWalletResponse{Wallet: toPbWallet(wallet)}, nil
+}
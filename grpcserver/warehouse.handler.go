@@ -0,0 +1,35 @@
+package grpcserver
+
+import (
+	"context"
+
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type warehouseHandler struct {
+	farmingv1.UnimplementedWarehouseServiceServer
+
+	service *service.WarehouseService
+}
+
+func newWarehouseHandler(dbClient *mongo.Database) *warehouseHandler {
+	return &warehouseHandler{
+		service: service.NewWarehouseService(dbClient),
+	}
+}
+
+func (h *warehouseHandler) GetWarehouse(ctx context.Context, req *farmingv1.GetWarehouseRequest) (*farmingv1.GetWarehouseResponse, error) {
+	userId, err := UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouse, err := h.service.GetUserWarehouse(ctx, userId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &farmingv1.GetWarehouseResponse{Warehouse: toPbWarehouse(warehouse)}, nil
+}
@@ -1,18 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/grpcserver"
+	"github.com/hrutik1235/farming-server/kafkaconn"
 	"github.com/hrutik1235/farming-server/router"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/tlsconn"
 	"github.com/hrutik1235/farming-server/utils"
+	"github.com/hrutik1235/farming-server/workers"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
-func initializeApp(rg *gin.RouterGroup, conn *grpc.ClientConn) {
+func initializeApp(rg *gin.RouterGroup, conn *grpc.ClientConn) *mongo.Database {
 	client, dbErr := utils.ConnectToDB("mongodb://localhost:27017/")
 
 	if dbErr != nil {
@@ -21,9 +27,40 @@ func initializeApp(rg *gin.RouterGroup, conn *grpc.ClientConn) {
 
 	db := client.Database("gfarming")
 
+	utils.Backfill(context.Background(), db)
+	utils.EnsureIndexes(context.Background(), db)
+
+	go func() {
+		onboarding := workers.NewOnboardingWorker(kafkaconn.NewKafka(utils.KafkaBrokers()), db)
+
+		if err := onboarding.Start(context.Background()); err != nil {
+			fmt.Println("Onboarding worker stopped", err.Error())
+		}
+	}()
+
+	go workers.NewGrowthWorker(db).Start(context.Background())
+	go workers.NewFederationWorker(db).Start(context.Background())
+	go workers.NewLandWorker(db).Start(context.Background())
+	go workers.NewLeaseWorker(db).Start(context.Background())
+	go workers.NewAuctionWorker(db).Start(context.Background())
+	go workers.NewStoreWorker(db).Start(context.Background())
+	go workers.NewMarketWorker(db).Start(context.Background())
+	go workers.NewContractWorker(db).Start(context.Background())
+	go workers.NewForwardWorker(db).Start(context.Background())
+	go workers.NewWalletWorker(db).Start(context.Background())
+
 	router.NewUserRoutes(rg, conn, db)
 	router.NewCropRoutes(rg, conn, db)
 	router.NewHarvestRoutes(rg, conn, db)
+	router.NewMarketRoutes(rg, conn, db)
+	router.NewFederationRoutes(rg, conn, db)
+	router.NewStreamRoutes(rg, conn, db)
+	router.NewSocketRoutes(rg, conn, db)
+	router.NewWeatherRoutes(rg, conn, db)
+	router.NewLandRoutes(rg, conn, db)
+	router.NewLeaseRoutes(rg, conn, db)
+
+	return db
 }
 
 func main() {
@@ -34,7 +71,14 @@ func main() {
 
 	serverGRPC := os.Getenv("SERVER_GRPC")
 
-	conn, err := grpc.NewClient(serverGRPC, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	transport, err := tlsconn.FromFiles(utils.TLSFiles())
+	if err != nil {
+		panic("Failed to load gRPC TLS files: " + err.Error())
+	}
+
+	service.PeerDialOptions = []grpc.DialOption{transport.DialOption()}
+
+	conn, err := grpc.NewClient(serverGRPC, transport.DialOption())
 
 	if err != nil {
 		panic("Failed to connect to gRPC server: " + err.Error())
@@ -50,7 +94,18 @@ func main() {
 		ctx.JSON(200, gin.H{"status": "OK"})
 	})
 
-	initializeApp(group, conn)
+	db := initializeApp(group, conn)
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9000"
+	}
+
+	go func() {
+		if err := grpcserver.Start(grpcPort, db, transport.ServerOptions()...); err != nil {
+			fmt.Println("gRPC server stopped", err.Error())
+		}
+	}()
 
 	router.Run(":" + port)
 }
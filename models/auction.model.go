@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LeaseAuction offers a lease on land units to the highest bidder. Its units are reserved until it
+// closes, and the winner gets a FLAT lease whose price is their bid.
+type LeaseAuction struct {
+	BaseModel       `bson:",inline"`
+	OwnerID         primitive.ObjectID   `bson:"owner_id" json:"owner_id"`
+	LandUnitIDs     []primitive.ObjectID `bson:"land_unit_ids" json:"land_unit_ids"`
+	DurationDays    int                  `bson:"duration_days" json:"duration_days"` // Of the lease the winner gets
+	ReservePrice    float64              `bson:"reserve_price" json:"reserve_price"`
+	Status          string               `bson:"status" json:"status"` // OPEN, SETTLING, AWARDED, UNSOLD, CANCELLED
+	EndsAt          time.Time            `bson:"ends_at" json:"ends_at"`
+	Extensions      int                  `bson:"extensions" json:"extensions"` // Times a late bid pushed EndsAt back
+	Bids            int                  `bson:"bids" json:"bids"`
+	HighestBid      float64              `bson:"highest_bid" json:"highest_bid"`
+	HighestBidderID primitive.ObjectID   `bson:"highest_bidder_id,omitempty" json:"highest_bidder_id,omitempty"`
+	HighestBidID    primitive.ObjectID   `bson:"highest_bid_id,omitempty" json:"highest_bid_id,omitempty"`
+	HighestHoldID   primitive.ObjectID   `bson:"highest_hold_id,omitempty" json:"-"` // Highest bidder's reserved funds
+	LeaseID         string               `bson:"lease_id,omitempty" json:"lease_id,omitempty"`
+	ClosedAt        time.Time            `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+}
+
+// LeaseBid is a bid on a lease auction. Its amount stays held in the bidder's wallet until it is
+// outbid or wins.
+type LeaseBid struct {
+	BaseModel `bson:",inline"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	BidderID  primitive.ObjectID `bson:"bidder_id" json:"bidder_id"`
+	Amount    float64            `bson:"amount" json:"amount"`
+	HoldID    primitive.ObjectID `bson:"hold_id" json:"-"`
+	Status    string             `bson:"status" json:"status"` // ACTIVE, OUTBID or WON
+	PlacedAt  time.Time          `bson:"placed_at" json:"placed_at"`
+}
+
+// StockAuction sells a lot of warehouse stock to the highest bidder. The lot is reserved from the
+// seller's warehouse until the auction closes, and bids are for the whole lot.
+type StockAuction struct {
+	BaseModel       `bson:",inline"`
+	SellerID        primitive.ObjectID `bson:"seller_id" json:"seller_id"`
+	CropID          primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Grade           string             `bson:"grade" json:"grade"`
+	QualityFactor   float64            `bson:"quality_factor" json:"quality_factor"` // Of the reserved stock
+	Quantity        int                `bson:"quantity" json:"quantity"`
+	StartPrice      float64            `bson:"start_price" json:"start_price"`
+	ReservePrice    float64            `bson:"reserve_price" json:"-"` // Kept from bidders, who only see ReserveMet
+	ReserveMet      bool               `bson:"reserve_met" json:"reserve_met"`
+	Status          string             `bson:"status" json:"status"` // OPEN, SETTLING, AWARDED, UNSOLD, CANCELLED
+	EndsAt          time.Time          `bson:"ends_at" json:"ends_at"`
+	Extensions      int                `bson:"extensions" json:"extensions"` // Times a late bid pushed EndsAt back
+	Bids            int                `bson:"bids" json:"bids"`
+	HighestBid      float64            `bson:"highest_bid" json:"highest_bid"`
+	HighestBidderID primitive.ObjectID `bson:"highest_bidder_id,omitempty" json:"highest_bidder_id,omitempty"`
+	HighestBidID    primitive.ObjectID `bson:"highest_bid_id,omitempty" json:"highest_bid_id,omitempty"`
+	HighestHoldID   primitive.ObjectID `bson:"highest_hold_id,omitempty" json:"-"` // Highest bidder's reserved funds
+	ReservationID   primitive.ObjectID `bson:"reservation_id" json:"-"`
+	TradeID         string             `bson:"trade_id,omitempty" json:"trade_id,omitempty"`
+	ClosedAt        time.Time          `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+}
+
+// StockBid is a bid on a stock auction. Its amount stays held in the bidder's wallet until it is
+// outbid or wins.
+type StockBid struct {
+	BaseModel `bson:",inline"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	BidderID  primitive.ObjectID `bson:"bidder_id" json:"bidder_id"`
+	Amount    float64            `bson:"amount" json:"amount"`
+	HoldID    primitive.ObjectID `bson:"hold_id" json:"-"`
+	Status    string             `bson:"status" json:"status"` // ACTIVE, OUTBID or WON
+	PlacedAt  time.Time          `bson:"placed_at" json:"placed_at"`
+}
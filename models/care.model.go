@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CareSchedule describes how often a crop wants one care action and what doing or skipping it is worth.
+type CareSchedule struct {
+	Action           string  `bson:"action" json:"action"`                         // WATER, FERTILIZE or WEED
+	IntervalHours    float64 `bson:"interval_hours" json:"interval_hours"`         // Length of each care window
+	CooldownHours    float64 `bson:"cooldown_hours" json:"cooldown_hours"`         // Minimum gap between two actions
+	CostPerUnit      float64 `bson:"cost_per_unit" json:"cost_per_unit"`           // Charged per planted land unit
+	QualityBonus     float64 `bson:"quality_bonus" json:"quality_bonus"`           // Added for each action taken
+	QualityPenalty   float64 `bson:"quality_penalty" json:"quality_penalty"`       // Taken for each missed window
+	GrowthBoostHours float64 `bson:"growth_boost_hours" json:"growth_boost_hours"` // Taken off the expected harvest time
+}
+
+type CareStatus struct {
+	LastPerformedAt time.Time `bson:"last_performed_at,omitempty" json:"last_performed_at,omitempty"`
+	NextDueAt       time.Time `bson:"next_due_at" json:"next_due_at"`
+}
+
+// Infection is a pest or disease outbreak on a planting, which destroys a growing share of its
+// yield until treated.
+type Infection struct {
+	Kind               string    `bson:"kind" json:"kind"` // PEST or DISEASE
+	InfectedAt         time.Time `bson:"infected_at" json:"infected_at"`
+	InfectedNeighbours int       `bson:"infected_neighbours" json:"infected_neighbours"`
+	DamagePerHour      float64   `bson:"damage_per_hour" json:"damage_per_hour"`
+	MaxDamage          float64   `bson:"max_damage" json:"max_damage"`
+	TreatedAt          time.Time `bson:"treated_at,omitempty" json:"treated_at,omitempty"`
+	Damage             float64   `bson:"damage,omitempty" json:"damage,omitempty"`             // Fixed when treated
+	PriorDamage        float64   `bson:"prior_damage,omitempty" json:"prior_damage,omitempty"` // Left by earlier treated infections
+}
+
+type PlantingActivity struct {
+	BaseModel     `bson:",inline"`
+	PlantingID    primitive.ObjectID `bson:"planting_id" json:"planting_id"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	CropID        primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	ActivityType  string             `bson:"activity_type" json:"activity_type"` // WATER, FERTILIZE, WEED, MISSED_WATER, WEATHER, PEST, TREAT, etc.
+	Description   string             `bson:"description" json:"description"`
+	Cost          float64            `bson:"cost" json:"cost"`
+	QualityChange float64            `bson:"quality_change" json:"quality_change"`
+	QualityFactor float64            `bson:"quality_factor" json:"quality_factor"` // Planting quality after the activity
+	Timestamp     time.Time          `bson:"timestamp" json:"timestamp"`
+}
+
+type CareResult struct {
+	Planting *PlantedCrop     `json:"planting"`
+	Activity PlantingActivity `json:"activity"`
+}
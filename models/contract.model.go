@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeliveryContract is an NPC order on the contract board: deliver Quantity of the crop at
+// MinQuality or better within DeliveryHours of accepting it, for Reward. Accepting holds Penalty
+// from the player's wallet, which is returned on delivery and lost if the contract fails.
+type DeliveryContract struct {
+	BaseModel      `bson:",inline"`
+	Round          int64              `bson:"round" json:"round"`
+	Slot           int                `bson:"slot" json:"slot"` // Position in the round, which with Round identifies a generated contract
+	CropID         primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Quantity       int                `bson:"quantity" json:"quantity"`
+	MinQuality     float64            `bson:"min_quality" json:"min_quality"`
+	Reward         float64            `bson:"reward" json:"reward"`
+	Penalty        float64            `bson:"penalty" json:"penalty"`
+	DeliveryHours  int                `bson:"delivery_hours" json:"delivery_hours"`
+	Status         string             `bson:"status" json:"status"` // OPEN, ACCEPTED, DELIVERING, FULFILLED, FAILED or EXPIRED
+	OfferExpiresAt time.Time          `bson:"offer_expires_at" json:"offer_expires_at"`
+	AcceptedBy     primitive.ObjectID `bson:"accepted_by,omitempty" json:"accepted_by,omitempty"`
+	AcceptedAt     time.Time          `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	DueAt          time.Time          `bson:"due_at,omitempty" json:"due_at,omitempty"`
+	HoldID         primitive.ObjectID `bson:"hold_id,omitempty" json:"-"`
+	ReservationID  primitive.ObjectID `bson:"reservation_id,omitempty" json:"-"`                        // Stock being delivered
+	QualityFactor  float64            `bson:"quality_factor,omitempty" json:"quality_factor,omitempty"` // Of the delivered stock
+	ClosedAt       time.Time          `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+	Reason         string             `bson:"reason,omitempty" json:"reason,omitempty"` // Why a FAILED contract failed: ABANDONED or OVERDUE
+	Forfeited      bool               `bson:"forfeited" json:"-"`                       // The collateral of a FAILED contract has been collected
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Event struct {
+	BaseModel `bson:",inline"`
+	Sequence  int64              `bson:"sequence" json:"sequence"` // 0 for transient events that are not stored
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`   // Zero for market-wide events such as PRICE_CHANGED
+	Type      string             `bson:"type" json:"type"`         // GROWTH_UPDATED, HARVEST_READY, PRICE_CHANGED, ...
+	Payload   bson.M             `bson:"payload" json:"payload"`
+}
+
+// DecodePayload unpacks the event payload into one of the types.*Payload structs.
+func (e *Event) DecodePayload(out any) error {
+	raw, err := bson.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+
+	return bson.Unmarshal(raw, out)
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PeerConnection struct {
+	BaseModel    `bson:",inline"`
+	PeerID       string    `bson:"peer_id" json:"peer_id"` // SERVER_ID of the remote farming server
+	Name         string    `bson:"name" json:"name"`
+	Address      string    `bson:"address" json:"address"` // gRPC IP:Port
+	Status       string    `bson:"status" json:"status"`   // ACTIVE, UNREACHABLE
+	LastSeenAt   time.Time `bson:"last_seen_at" json:"last_seen_at"`
+	LastSyncedAt time.Time `bson:"last_synced_at,omitempty" json:"last_synced_at,omitempty"`
+}
+
+type DirectoryEntry struct {
+	BaseModel     `bson:",inline"`
+	PeerID        string    `bson:"peer_id" json:"peer_id"`
+	RemoteUserID  string    `bson:"remote_user_id" json:"remote_user_id"`
+	Username      string    `bson:"username" json:"username"`
+	DisplayName   string    `bson:"display_name" json:"display_name"`
+	ServerAddress string    `bson:"server_address" json:"server_address"`
+	SyncedAt      time.Time `bson:"synced_at" json:"synced_at"`
+}
+
+type FederatedTrade struct {
+	BaseModel     `bson:",inline"`
+	TradeID       string             `bson:"trade_id" json:"trade_id"` // <initiator server id>:<object id>, same on both servers
+	Role          string             `bson:"role" json:"role"`         // COORDINATOR or PARTICIPANT
+	PeerID        string             `bson:"peer_id" json:"peer_id"`
+	LocalUserID   primitive.ObjectID `bson:"local_user_id" json:"local_user_id"`
+	RemoteUserID  string             `bson:"remote_user_id" json:"remote_user_id"`
+	LocalSide     string             `bson:"local_side" json:"local_side"` // SELLER or BUYER
+	CropName      string             `bson:"crop_name" json:"crop_name"`   // Crop ids differ between servers
+	Quantity      int                `bson:"quantity" json:"quantity"`
+	PricePerUnit  float64            `bson:"price_per_unit" json:"price_per_unit"`
+	TotalAmount   float64            `bson:"total_amount" json:"total_amount"`
+	QualityFactor float64            `bson:"quality_factor" json:"quality_factor"`
+	Status        string             `bson:"status" json:"status"` // OFFERED, PREPARING, PREPARED, COMMITTED, ABORTED
+
+	// Escrow backing the local side: a stock reservation for sellers, a wallet hold for buyers.
+	ReservationID primitive.ObjectID `bson:"reservation_id,omitempty" json:"reservation_id,omitempty"`
+	HoldID        primitive.ObjectID `bson:"hold_id,omitempty" json:"hold_id,omitempty"`
+
+	LocalSettled bool      `bson:"local_settled" json:"local_settled"`
+	PeerNotified bool      `bson:"peer_notified" json:"peer_notified"` // Coordinator only: peer acknowledged the decision
+	Reason       string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	DecidedAt    time.Time `bson:"decided_at,omitempty" json:"decided_at,omitempty"`
+}
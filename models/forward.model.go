@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ForwardContract pre-sells part of a growing planting's harvest at a locked price. The seller's
+// collateral is held from the offer on, and the buyer's payment from acceptance until the harvest
+// delivers. A shortfall costs the seller the market price of the missing units, up to the collateral.
+type ForwardContract struct {
+	BaseModel    `bson:",inline"`
+	SellerID     primitive.ObjectID `bson:"seller_id" json:"seller_id"`
+	BuyerID      primitive.ObjectID `bson:"buyer_id,omitempty" json:"buyer_id,omitempty"`
+	PlantingID   primitive.ObjectID `bson:"planting_id" json:"planting_id"`
+	CropID       primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Quantity     int                `bson:"quantity" json:"quantity"`
+	PricePerUnit float64            `bson:"price_per_unit" json:"price_per_unit"`
+	TotalAmount  float64            `bson:"total_amount" json:"total_amount"`
+	Collateral   float64            `bson:"collateral" json:"collateral"`
+	DeliverAfter time.Time          `bson:"deliver_after" json:"deliver_after"` // The planting's expected harvest when offered
+	Status       string             `bson:"status" json:"status"`               // OPEN, ACTIVE, DELIVERED, SHORTFALL, CANCELLED or EXPIRED
+	SellerHoldID primitive.ObjectID `bson:"seller_hold_id" json:"-"`
+	BuyerHoldID  primitive.ObjectID `bson:"buyer_hold_id,omitempty" json:"-"`
+	AcceptedAt   time.Time          `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+
+	// Filled in on settlement
+	Delivered   int       `bson:"delivered" json:"delivered"`
+	Shortfall   int       `bson:"shortfall" json:"shortfall"`
+	MarketPrice float64   `bson:"market_price,omitempty" json:"market_price,omitempty"` // Per unit, when settled
+	Penalty     float64   `bson:"penalty" json:"penalty"`                               // Paid to the buyer out of the collateral
+	SettledAt   time.Time `bson:"settled_at,omitempty" json:"settled_at,omitempty"`
+	PaidOut     bool      `bson:"paid_out" json:"-"` // Once the settlement's payments have all gone through
+}
+
+// ForwardDelivery is the part of a harvest delivered to a forward contract's buyer.
+type ForwardDelivery struct {
+	ContractID primitive.ObjectID `bson:"contract_id" json:"contract_id"`
+	BuyerID    primitive.ObjectID `bson:"buyer_id" json:"buyer_id"`
+	Quantity   int                `bson:"quantity" json:"quantity"`
+	Shortfall  int                `bson:"shortfall" json:"shortfall"`
+	Penalty    float64            `bson:"penalty" json:"penalty"`
+}
@@ -15,9 +15,37 @@ type HarvestResult struct {
 	HarvestPercentage float64            `bson:"harvest_percentage" json:"harvest_percentage"`
 	Quantity          int                `bson:"quantity" json:"quantity"`
 	QualityFactor     float64            `bson:"quality_factor" json:"quality_factor"`
-	BasePrice         float64            `bson:"base_price" json:"base_price"`
-	ActualPrice       float64            `bson:"actual_price" json:"actual_price"`
+	Grade             string             `bson:"grade" json:"grade"`
+	BasePrice         float64            `bson:"base_price" json:"base_price"`     // Standard grade market price
+	ActualPrice       float64            `bson:"actual_price" json:"actual_price"` // Market price of Grade
 	TotalValue        float64            `bson:"total_value" json:"total_value"`
 	HarvestedAt       time.Time          `bson:"harvested_at" json:"harvested_at"`
 	IsPartial         bool               `bson:"is_partial" json:"is_partial"`
+	Adjacency         *AdjacencyReport   `bson:"adjacency,omitempty" json:"adjacency,omitempty"`
+	Shares            []HarvestShare     `bson:"shares,omitempty" json:"shares,omitempty"`     // Given to landowners; Quantity is what the tenant keeps
+	Forwards          []ForwardDelivery  `bson:"forwards,omitempty" json:"forwards,omitempty"` // Delivered to forward buyers out of the tenant's part
+}
+
+// HarvestShare is the part of a harvest a crop-share lease gives the landowner.
+type HarvestShare struct {
+	LeaseID  string             `bson:"lease_id" json:"lease_id"`
+	OwnerID  primitive.ObjectID `bson:"owner_id" json:"owner_id"`
+	Quantity int                `bson:"quantity" json:"quantity"`
+}
+
+// AdjacencyReport explains how neighbouring plantings and structures changed a harvest.
+type AdjacencyReport struct {
+	QualityChange float64           `bson:"quality_change" json:"quality_change"` // Fraction the quality factor was scaled by
+	YieldBonus    float64           `bson:"yield_bonus" json:"yield_bonus"`       // Fraction added to the yield
+	Effects       []AdjacencyEffect `bson:"effects" json:"effects"`
+}
+
+type AdjacencyEffect struct {
+	Kind          string             `bson:"kind" json:"kind"` // COMPANION, ANTAGONIST or POLLINATOR
+	LandUnitID    primitive.ObjectID `bson:"land_unit_id" json:"land_unit_id"`
+	PlantingID    primitive.ObjectID `bson:"planting_id,omitempty" json:"planting_id,omitempty"`
+	CropID        primitive.ObjectID `bson:"crop_id,omitempty" json:"crop_id,omitempty"`
+	QualityChange float64            `bson:"quality_change,omitempty" json:"quality_change,omitempty"`
+	YieldChange   float64            `bson:"yield_change,omitempty" json:"yield_change,omitempty"`
+	Description   string             `bson:"description" json:"description"`
 }
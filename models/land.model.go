@@ -15,7 +15,67 @@ type LandUnit struct {
 	IsLeased    bool               `bson:"is_leased" json:"is_leased"`
 	IsAvailable bool               `bson:"is_available" json:"is_available"`
 	Position    int                `bson:"position" json:"position"`
-	Location    string             `bson:"location,omitempty" json:"location,omitempty"` // Optional: for future expansion
+	Location    string             `bson:"location,omitempty" json:"location,omitempty"`     // Optional: for future expansion
+	Soil        *Soil              `bson:"soil,omitempty" json:"soil,omitempty"`             // Unset until first planted on
+	Structure   *Structure         `bson:"structure,omitempty" json:"structure,omitempty"`   // Occupies the unit until removed
+	ListingID   primitive.ObjectID `bson:"listing_id,omitempty" json:"listing_id,omitempty"` // Set while listed for sale
+	AuctionID   primitive.ObjectID `bson:"auction_id,omitempty" json:"auction_id,omitempty"` // Set while a lease on it is auctioned
+}
+
+type Structure struct {
+	Kind    string    `bson:"kind" json:"kind"` // BEEHIVE
+	BuiltAt time.Time `bson:"built_at" json:"built_at"`
+}
+
+// Soil is the nutrient state of a land unit, each nutrient from 0 to 100.
+type Soil struct {
+	Nitrogen       float64            `bson:"nitrogen" json:"nitrogen"`
+	Phosphorus     float64            `bson:"phosphorus" json:"phosphorus"`
+	Potassium      float64            `bson:"potassium" json:"potassium"`
+	LastCropID     primitive.ObjectID `bson:"last_crop_id,omitempty" json:"last_crop_id,omitempty"`
+	RotationStreak int                `bson:"rotation_streak" json:"rotation_streak"` // Plantings of LastCropID in a row
+	FallowSince    time.Time          `bson:"fallow_since,omitempty" json:"fallow_since,omitempty"`
+}
+
+// LandMap is a user's land laid out on the grid, one cell per land unit.
+type LandMap struct {
+	Width  int        `json:"width"`
+	Height int        `json:"height"`
+	Cells  []LandCell `json:"cells"`
+}
+
+type LandCell struct {
+	X                int                `json:"x"`
+	Y                int                `json:"y"`
+	Position         int                `json:"position"`
+	LandUnitID       primitive.ObjectID `json:"land_unit_id"`
+	IsAvailable      bool               `json:"is_available"`
+	IsLeased         bool               `json:"is_leased"`
+	LesseeID         primitive.ObjectID `json:"lessee_id,omitempty"`
+	Soil             Soil               `json:"soil"`
+	Structure        string             `json:"structure,omitempty"`
+	ListingID        primitive.ObjectID `json:"listing_id,omitempty"`
+	AuctionID        primitive.ObjectID `json:"auction_id,omitempty"`
+	PlantingID       primitive.ObjectID `json:"planting_id,omitempty"`
+	CropID           primitive.ObjectID `json:"crop_id,omitempty"`
+	CropName         string             `json:"crop_name,omitempty"`
+	GrowthPercentage float64            `json:"growth_percentage,omitempty"`
+}
+
+// LandQuote prices buying more land for a user.
+type LandQuote struct {
+	Units      int     `json:"units"`
+	Price      float64 `json:"price"`
+	Owned      int     `json:"owned"`
+	MaxUnits   int     `json:"max_units"`
+	SupplyLeft int     `json:"supply_left"`
+}
+
+// LandExpansion is land a user just bought.
+type LandExpansion struct {
+	LandUnits []LandUnit `json:"land_units"`
+	Price     float64    `json:"price"`
+	Owned     int        `json:"owned"`
 }
 
 type Land struct {
@@ -33,11 +93,27 @@ type PlantedCrop struct {
 	PlantedAt         time.Time          `bson:"planted_at" json:"planted_at"`
 	ExpectedHarvestAt time.Time          `bson:"expected_harvest_at" json:"expected_harvest_at"`
 	GrowthPercentage  float64            `bson:"growth_percentage" json:"growth_percentage"` // 0.0 to 1.0
+	GrowthBase        float64            `bson:"growth_base,omitempty" json:"-"`             // Growth when the growth speed last changed
+	GrowthBaseAt      time.Time          `bson:"growth_base_at,omitempty" json:"-"`
+	WeatherDay        int                `bson:"weather_day,omitempty" json:"weather_day,omitempty"` // Last world day whose weather was applied
+	HarvestReadyAt    time.Time          `bson:"harvest_ready_at,omitempty" json:"harvest_ready_at,omitempty"`
+	IsReady           bool               `bson:"is_ready" json:"is_ready"`
+	GrowthMilestone   int                `bson:"growth_milestone" json:"growth_milestone"` // Highest of 25/50/75/100 reached
 	IsHarvested       bool               `bson:"is_harvested" json:"is_harvested"`
 	HarvestedAt       time.Time          `bson:"harvested_at,omitempty" json:"harvested_at,omitempty"`
-	QualityFactor     float64            `bson:"quality_factor" json:"quality_factor"` // 0.0 to 1.0
+	QualityFactor     float64            `bson:"quality_factor" json:"quality_factor"` // Care quality while growing, harvest quality after
 	TotalCost         float64            `bson:"total_cost" json:"total_cost"`
 	ExpectedYield     int                `bson:"expected_yield" json:"expected_yield"`
+	SoilFactor        float64            `bson:"soil_factor,omitempty" json:"soil_factor,omitempty"` // Soil and rotation multiplier applied to ExpectedYield
+
+	// Care windows keyed by action
+	Care map[string]CareStatus `bson:"care,omitempty" json:"care,omitempty"`
+
+	Infection *Infection `bson:"infection,omitempty" json:"infection,omitempty"`
+
+	// The full harvest claimed and being stored, kept until it is done so an interrupted one can be
+	// finished with the same result
+	Harvest *HarvestResult `bson:"harvest,omitempty" json:"-"`
 
 	// For partial harvest tracking
 	PartialHarvests []PartialHarvest `bson:"partial_harvests,omitempty" json:"partial_harvests,omitempty"`
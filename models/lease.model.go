@@ -4,19 +4,42 @@ import "time"
 
 type Lease struct {
 	BaseModel      `bson:",inline"`
-	LeaseID        string    `bson:"lease_id" json:"lease_id"`
-	LandownerID    string    `bson:"landowner_id" json:"landowner_id"`
-	TenantID       string    `bson:"tenant_id" json:"tenant_id"`
-	LandUnitIDs    []string  `bson:"land_unit_ids" json:"land_unit_ids"`
-	TotalLandUnits int       `bson:"total_land_units" json:"total_land_units"`
-	Status         string    `bson:"status" json:"status"` // PENDING, ACTIVE, REJECTED, EXPIRED, CANCELLED
-	DurationDays   int       `bson:"duration_days" json:"duration_days"`
-	LeasePrice     float64   `bson:"lease_price" json:"lease_price"`
-	RequestedAt    time.Time `bson:"requested_at" json:"requested_at"`
-	AcceptedAt     time.Time `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
-	StartTime      time.Time `bson:"start_time,omitempty" json:"start_time,omitempty"`
-	EndTime        time.Time `bson:"end_time,omitempty" json:"end_time,omitempty"`
-	Reason         string    `bson:"reason,omitempty" json:"reason,omitempty"` // For rejection/cancellation
+	LeaseID        string   `bson:"lease_id" json:"lease_id"`
+	LandownerID    string   `bson:"landowner_id" json:"landowner_id"`
+	TenantID       string   `bson:"tenant_id" json:"tenant_id"`
+	LandUnitIDs    []string `bson:"land_unit_ids" json:"land_unit_ids"`
+	TotalLandUnits int      `bson:"total_land_units" json:"total_land_units"`
+	Status         string   `bson:"status" json:"status"` // PENDING, ACTIVE, REJECTED, EXPIRED, CANCELLED, TERMINATED
+	DurationDays   int      `bson:"duration_days" json:"duration_days"`
+	Terms          string   `bson:"terms" json:"terms"`             // FLAT, CROP_SHARE or RENT
+	LeasePrice     float64  `bson:"lease_price" json:"lease_price"` // Paid up front on FLAT terms
+
+	// CROP_SHARE: the landowner's percentage of every harvest from the units
+	OwnerSharePercent float64 `bson:"owner_share_percent,omitempty" json:"owner_share_percent,omitempty"`
+
+	// RENT: charged each day; a day that can't be paid ends the lease
+	DailyRent    float64   `bson:"daily_rent,omitempty" json:"daily_rent,omitempty"`
+	RentPaidDays int       `bson:"rent_paid_days,omitempty" json:"rent_paid_days,omitempty"`
+	NextRentAt   time.Time `bson:"next_rent_at,omitempty" json:"next_rent_at,omitempty"`
+
+	RequestedAt time.Time `bson:"requested_at" json:"requested_at"`
+	AcceptedAt  time.Time `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	StartTime   time.Time `bson:"start_time,omitempty" json:"start_time,omitempty"`
+	EndTime     time.Time `bson:"end_time,omitempty" json:"end_time,omitempty"`
+	Reason      string    `bson:"reason,omitempty" json:"reason,omitempty"`         // For rejection/cancellation
+	AuctionID   string    `bson:"auction_id,omitempty" json:"auction_id,omitempty"` // Set when won at auction
+
+	// Landowner payments that failed, retried by the lease worker
+	PendingPayouts []LeasePayout `bson:"pending_payouts,omitempty" json:"pending_payouts,omitempty"`
+}
+
+// LeasePayout is a payment to the landowner still to be made: coins, or a crop share's stock.
+type LeasePayout struct {
+	Key         string         `bson:"key" json:"key"` // Makes the payment once however often it is retried
+	OwnerID     string         `bson:"owner_id" json:"owner_id"`
+	Amount      float64        `bson:"amount,omitempty" json:"amount,omitempty"`
+	Item        *WarehouseItem `bson:"item,omitempty" json:"item,omitempty"`
+	Description string         `bson:"description" json:"description"`
 }
 
 type LeaseActivity struct {
@@ -24,6 +47,7 @@ type LeaseActivity struct {
 	LeaseID      string    `bson:"lease_id" json:"lease_id"`
 	ActivityType string    `bson:"activity_type" json:"activity_type"` // PLANT, HARVEST, WATER, etc.
 	TenantID     string    `bson:"tenant_id" json:"tenant_id"`
+	PlantingID   string    `bson:"planting_id,omitempty" json:"planting_id,omitempty"`
 	CropID       string    `bson:"crop_id,omitempty" json:"crop_id,omitempty"`
 	Description  string    `bson:"description" json:"description"`
 	Timestamp    time.Time `bson:"timestamp" json:"timestamp"`
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LandListing offers land units for sale at a fixed price for all of them.
+type LandListing struct {
+	BaseModel   `bson:",inline"`
+	SellerID    primitive.ObjectID   `bson:"seller_id" json:"seller_id"`
+	LandUnitIDs []primitive.ObjectID `bson:"land_unit_ids" json:"land_unit_ids"`
+	Price       float64              `bson:"price" json:"price"`
+	Status      string               `bson:"status" json:"status"` // OPEN, SETTLING, SOLD, CANCELLED
+	BuyerID     primitive.ObjectID   `bson:"buyer_id,omitempty" json:"buyer_id,omitempty"`
+	HoldID      primitive.ObjectID   `bson:"hold_id,omitempty" json:"-"`   // Buyer's escrowed payment
+	Positions   []int                `bson:"positions,omitempty" json:"-"` // Buyer's positions for the units, in LandUnitIDs order
+	SoldAt      time.Time            `bson:"sold_at,omitempty" json:"sold_at,omitempty"`
+}
+
+// LandTransfer records a land unit changing owner.
+type LandTransfer struct {
+	BaseModel     `bson:",inline"`
+	LandUnitID    primitive.ObjectID `bson:"land_unit_id" json:"land_unit_id"`
+	FromID        primitive.ObjectID `bson:"from_id" json:"from_id"`
+	ToID          primitive.ObjectID `bson:"to_id" json:"to_id"`
+	ListingID     primitive.ObjectID `bson:"listing_id" json:"listing_id"`
+	Price         float64            `bson:"price" json:"price"` // The unit's share of the listing price
+	FromPosition  int                `bson:"from_position" json:"from_position"`
+	ToPosition    int                `bson:"to_position" json:"to_position"`
+	TransferredAt time.Time          `bson:"transferred_at" json:"transferred_at"`
+}
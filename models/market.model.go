@@ -12,6 +12,13 @@ type Trade struct {
 	SellerID     string    `bson:"seller_id" json:"seller_id"`
 	BuyerID      string    `bson:"buyer_id" json:"buyer_id"`
 	CropID       string    `bson:"crop_id" json:"crop_id"`
+	Grade        string    `bson:"grade,omitempty" json:"grade,omitempty"`
+	BuyOrderID   string    `bson:"buy_order_id,omitempty" json:"buy_order_id,omitempty"`
+	SellOrderID  string    `bson:"sell_order_id,omitempty" json:"sell_order_id,omitempty"`
+	MakerOrderID string    `bson:"maker_order_id,omitempty" json:"maker_order_id,omitempty"`
+	ListingID    string    `bson:"listing_id,omitempty" json:"listing_id,omitempty"` // Storefront listing bought from
+	AuctionID    string    `bson:"auction_id,omitempty" json:"auction_id,omitempty"` // Stock auction won
+	Commission   float64   `bson:"commission,omitempty" json:"commission,omitempty"` // Kept by the marketplace out of TotalAmount
 	Quantity     int       `bson:"quantity" json:"quantity"`
 	PricePerUnit float64   `bson:"price_per_unit" json:"price_per_unit"`
 	TotalAmount  float64   `bson:"total_amount" json:"total_amount"`
@@ -25,6 +32,7 @@ type Trade struct {
 type MarketPrice struct {
 	BaseModel     `bson:",inline"`
 	CropID        primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Grade         string             `bson:"grade" json:"grade"` // Each grade of a crop is priced separately
 	CurrentPrice  float64            `bson:"current_price" json:"current_price"`
 	BasePrice     float64            `bson:"base_price" json:"base_price"`
 	DemandFactor  float64            `bson:"demand_factor" json:"demand_factor"`
@@ -37,7 +45,27 @@ type MarketPrice struct {
 type PriceHistory struct {
 	BaseModel `bson:",inline"`
 	CropID    string    `bson:"crop_id" json:"crop_id"`
+	Grade     string    `bson:"grade,omitempty" json:"grade,omitempty"`
 	Price     float64   `bson:"price" json:"price"`
 	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
 	Reason    string    `bson:"reason" json:"reason"` // TRADE, MARKET_UPDATE, etc.
 }
+
+type SaleResult struct {
+	CropID        primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Quantity      int                `bson:"quantity" json:"quantity"`
+	MarketPrice   float64            `bson:"market_price" json:"market_price"`
+	QualityFactor float64            `bson:"quality_factor" json:"quality_factor"`
+	TotalAmount   float64            `bson:"total_amount" json:"total_amount"`
+	Balance       float64            `bson:"balance" json:"balance"`
+	SoldAt        time.Time          `bson:"sold_at" json:"sold_at"`
+	Grades        []GradeSale        `bson:"grades" json:"grades"` // What each grade of the stock sold for
+}
+
+// GradeSale is the part of a market sale of one grade, paid at that grade's price.
+type GradeSale struct {
+	Grade        string  `bson:"grade" json:"grade"`
+	Quantity     int     `bson:"quantity" json:"quantity"`
+	PricePerUnit float64 `bson:"price_per_unit" json:"price_per_unit"`
+	TotalAmount  float64 `bson:"total_amount" json:"total_amount"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Onboarding struct {
+	BaseModel     `bson:",inline"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Username      string             `bson:"username" json:"username"`
+	Status        string             `bson:"status" json:"status"` // PENDING, COMPLETED, FAILED
+	WalletCreated bool               `bson:"wallet_created" json:"wallet_created"`
+	LandAllocated bool               `bson:"land_allocated" json:"land_allocated"`
+	SeedsGranted  bool               `bson:"seeds_granted" json:"seeds_granted"`
+	WelcomeSent   bool               `bson:"welcome_sent" json:"welcome_sent"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	LastError     string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	Event         []byte             `bson:"event" json:"-"` // Raw UserRegistered payload, replayed on retry
+	CompletedAt   time.Time          `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MarketOrder is a buy or sell order on the order book of one crop and quality grade. Buy orders
+// hold their cost in the buyer's wallet and sell orders reserve their stock, until they fill or
+// are cancelled.
+type MarketOrder struct {
+	BaseModel     `bson:",inline"`
+	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
+	CropID        primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Grade         string             `bson:"grade" json:"grade"`
+	Side          string             `bson:"side" json:"side"`                       // BUY or SELL
+	Type          string             `bson:"type" json:"type"`                       // LIMIT or MARKET
+	Price         float64            `bson:"price,omitempty" json:"price,omitempty"` // Limit price per unit
+	Quantity      int                `bson:"quantity" json:"quantity"`
+	Remaining     int                `bson:"remaining" json:"remaining"`
+	Filled        int                `bson:"filled" json:"filled"`
+	FilledValue   float64            `bson:"filled_value" json:"filled_value"` // Paid or received for the filled units
+	Status        string             `bson:"status" json:"status"`             // OPEN, PARTIAL, FILLED or CANCELLED
+	HoldID        primitive.ObjectID `bson:"hold_id,omitempty" json:"-"`
+	ReservationID primitive.ObjectID `bson:"reservation_id,omitempty" json:"-"`
+	SpaceReserved bool               `bson:"space_reserved,omitempty" json:"-"` // Buy orders: warehouse space set aside for the fills
+	PlacedAt      time.Time          `bson:"placed_at" json:"placed_at"`
+}
+
+// OrderResult is an order as it stands after matching, with the trades it made.
+type OrderResult struct {
+	Order  MarketOrder `json:"order"`
+	Trades []Trade     `json:"trades"`
+}
+
+// OrderBook is a depth snapshot of one crop and grade: resting orders summed per price level,
+// best prices first.
+type OrderBook struct {
+	CropID    primitive.ObjectID `json:"crop_id"`
+	Grade     string             `json:"grade"`
+	Bids      []BookLevel        `json:"bids"`
+	Asks      []BookLevel        `json:"asks"`
+	LastPrice float64            `json:"last_price,omitempty"` // Of the latest trade
+	At        time.Time          `json:"at"`
+}
+
+type BookLevel struct {
+	Price    float64 `bson:"price" json:"price"`
+	Quantity int     `bson:"quantity" json:"quantity"`
+	Orders   int     `bson:"orders" json:"orders"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type SeedItem struct {
+	BaseModel `bson:",inline"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	CropID    primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Quantity  int                `bson:"quantity" json:"quantity"`
+	Source    string             `bson:"source" json:"source"` // STARTER, etc.
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StoreListing offers warehouse stock at a fixed price per unit. The stock is reserved from the
+// seller's warehouse while listed and goes back when the listing is cancelled or expires.
+type StoreListing struct {
+	BaseModel     `bson:",inline"`
+	SellerID      primitive.ObjectID `bson:"seller_id" json:"seller_id"`
+	CropID        primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Grade         string             `bson:"grade" json:"grade"`
+	QualityFactor float64            `bson:"quality_factor" json:"quality_factor"` // Of the reserved stock
+	Quantity      int                `bson:"quantity" json:"quantity"`
+	Remaining     int                `bson:"remaining" json:"remaining"`
+	PricePerUnit  float64            `bson:"price_per_unit" json:"price_per_unit"`
+	ListingFee    float64            `bson:"listing_fee" json:"listing_fee"`
+	Status        string             `bson:"status" json:"status"` // ACTIVE, SOLD_OUT, CANCELLED or EXPIRED
+	ReservationID primitive.ObjectID `bson:"reservation_id" json:"-"`
+	ExpiresAt     time.Time          `bson:"expires_at" json:"expires_at"`
+}
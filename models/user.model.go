@@ -25,6 +25,7 @@ type User struct {
 	ServerAddress string             `bson:"server_address" json:"server_address"` // IP:Port for manual connections
 	LastLogin     time.Time          `bson:"last_login" json:"last_login"`
 	IsOnline      bool               `bson:"is_online" json:"is_online"`
+	Connections   int                `bson:"connections" json:"-"` // Open realtime sessions across all instances
 }
 
 func (user *User) Save(userCol *mongo.Collection) (*mongo.InsertOneResult, error) {
@@ -46,4 +47,24 @@ type Crop struct {
 	CostPerUnit     float64 `bson:"cost_per_unit" json:"cost_per_unit"`
 	Description     string  `bson:"description,omitempty" json:"description,omitempty"`
 	IsActive        bool    `bson:"is_active" json:"is_active"`
+
+	// Empty means the default schedule scaled to GrowthTimeHours
+	CareSchedule []CareSchedule `bson:"care_schedule,omitempty" json:"care_schedule,omitempty"`
+
+	// Seasons the crop grows faster in, and how strongly each weather condition affects it (1 is normal)
+	PreferredSeasons   []string           `bson:"preferred_seasons,omitempty" json:"preferred_seasons,omitempty"`
+	WeatherSensitivity map[string]float64 `bson:"weather_sensitivity,omitempty" json:"weather_sensitivity,omitempty"`
+
+	// How prone the crop is to PEST and DISEASE outbreaks (1 is normal)
+	OutbreakSusceptibility map[string]float64 `bson:"outbreak_susceptibility,omitempty" json:"outbreak_susceptibility,omitempty"`
+
+	// Change to each soil nutrient per planting; negative depletes. Empty depletes each by 10.
+	SoilImpact map[string]float64 `bson:"soil_impact,omitempty" json:"soil_impact,omitempty"`
+
+	// Crops that do better or worse on adjacent land; a pair counts when either crop lists the other
+	Companions  []primitive.ObjectID `bson:"companions,omitempty" json:"companions,omitempty"`
+	Antagonists []primitive.ObjectID `bson:"antagonists,omitempty" json:"antagonists,omitempty"`
+
+	// Lowest quality factor of grades A, B and C; empty means utils.DefaultGradeThresholds
+	GradeThresholds map[string]float64 `bson:"grade_thresholds,omitempty" json:"grade_thresholds,omitempty"`
 }
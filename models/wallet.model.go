@@ -10,9 +10,11 @@ type Wallet struct {
 	BaseModel     `bson:",inline"`
 	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
 	Balance       float64            `bson:"balance" json:"balance"`
+	HeldBalance   float64            `bson:"held_balance" json:"held_balance"` // Reserved by open trades, bids and orders
 	TotalEarnings float64            `bson:"total_earnings" json:"total_earnings"`
 	TotalSpent    float64            `bson:"total_spent" json:"total_spent"`
 	LastUpdated   time.Time          `bson:"last_updated" json:"last_updated"`
+	AppliedKeys   []string           `bson:"applied_keys,omitempty" json:"-"` // Latest keyed changes, so a retried change applies once
 }
 
 type Transaction struct {
@@ -27,3 +29,21 @@ type Transaction struct {
 	Timestamp     time.Time `bson:"timestamp" json:"timestamp"`
 	BalanceAfter  float64   `bson:"balance_after" json:"balance_after"`
 }
+
+type WalletHold struct {
+	BaseModel   `bson:",inline"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Amount      float64            `bson:"amount" json:"amount"`
+	Status      string             `bson:"status" json:"status"` // PENDING, HELD, RELEASED, CAPTURED
+	Reason      string             `bson:"reason" json:"reason"` // FEDERATED_TRADE, etc.
+	ReferenceID string             `bson:"reference_id" json:"reference_id"`
+	Settled     bool               `bson:"settled" json:"settled"`                 // Wallet moved for the final status
+	Abandoned   bool               `bson:"abandoned,omitempty" json:"-"`           // Released while PENDING, so the money may never have moved
+	Parts       []HoldPart         `bson:"parts,omitempty" json:"parts,omitempty"` // Amounts captured piecewise
+}
+
+type HoldPart struct {
+	Key     string  `bson:"key" json:"key"`
+	Amount  float64 `bson:"amount" json:"amount"`
+	Applied bool    `bson:"applied" json:"applied"` // Moved out of the wallet's held balance
+}
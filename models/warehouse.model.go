@@ -16,6 +16,7 @@ type WarehouseItem struct {
 	StoredAt      time.Time          `bson:"stored_at" json:"stored_at"`
 	ExpiresAt     time.Time          `bson:"expires_at" json:"expires_at"`
 	QualityFactor float64            `bson:"quality_factor" json:"quality_factor"` // 0.0 to 1.0
+	Grade         string             `bson:"grade,omitempty" json:"grade"`         // Of QualityFactor under the crop's thresholds when stored
 	IsExpired     bool               `bson:"is_expired" json:"is_expired"`
 	Source        string             `bson:"source" json:"source"` // HARVEST, TRADE, etc.
 }
@@ -26,6 +27,29 @@ type Warehouse struct {
 	TotalCapacity int                `bson:"total_capacity" json:"total_capacity"`
 	UsedCapacity  int                `bson:"used_capacity" json:"used_capacity"`
 
+	// Space set aside for stock bought but not delivered yet, by reference
+	ReservedCapacity     int            `bson:"reserved_capacity" json:"reserved_capacity"`
+	CapacityReservations map[string]int `bson:"capacity_reservations,omitempty" json:"-"`
+	AppliedKeys          []string       `bson:"applied_keys,omitempty" json:"-"` // Latest keyed deliveries, so a retried one lands once
+
 	// Embedded items (or could be separate collection)
 	Items []WarehouseItem `bson:"items,omitempty" json:"items,omitempty"`
 }
+
+type StockReservation struct {
+	BaseModel   `bson:",inline"`
+	UserID      primitive.ObjectID `bson:"user_id" json:"user_id"`
+	CropID      primitive.ObjectID `bson:"crop_id" json:"crop_id"`
+	Quantity    int                `bson:"quantity" json:"quantity"`
+	Lots        []WarehouseItem    `bson:"lots" json:"lots"`     // Stacks taken out of the warehouse, returned as-is on release
+	Status      string             `bson:"status" json:"status"` // HELD, RELEASED, CONSUMED
+	Reason      string             `bson:"reason" json:"reason"`
+	ReferenceID string             `bson:"reference_id" json:"reference_id"`
+	Parts       []ReservationPart  `bson:"parts,omitempty" json:"-"` // Taken out piecewise, by key
+}
+
+// ReservationPart is stock taken out of a reservation under a key, so taking it again returns the same lots.
+type ReservationPart struct {
+	Key  string          `bson:"key"`
+	Lots []WarehouseItem `bson:"lots"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type WeatherDay struct {
+	BaseModel    `bson:",inline"`
+	WorldID      string    `bson:"world_id" json:"world_id"`
+	Day          int       `bson:"day" json:"day"` // Days since the world epoch
+	Date         time.Time `bson:"date" json:"date"`
+	Season       string    `bson:"season" json:"season"`       // SPRING, SUMMER, AUTUMN or WINTER
+	Condition    string    `bson:"condition" json:"condition"` // SUNNY, CLOUDY, RAIN, STORM, DROUGHT or FROST
+	TemperatureC float64   `bson:"temperature_c" json:"temperature_c"`
+	RainfallMM   float64   `bson:"rainfall_mm" json:"rainfall_mm"`
+}
+
+// WeatherEffect is how a day changes a planting: Growth multiplies its growth speed and Quality is
+// added to its quality factor.
+type WeatherEffect struct {
+	Growth  float64 `json:"growth"`
+	Quality float64 `json:"quality"`
+}
+
+type WeatherForecast struct {
+	WeatherDay
+	Effect *WeatherEffect `json:"effect,omitempty"` // For the crop asked about
+}
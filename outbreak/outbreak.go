@@ -0,0 +1,173 @@
+// Package outbreak decides when pests and disease break out on plantings. Every roll is derived
+// from the seed, the planting and the hour alone, so a seed replays the same outbreaks regardless
+// of the order or how often plantings are checked.
+package outbreak
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+)
+
+const (
+	Pest    = "PEST"
+	Disease = "DISEASE"
+)
+
+var Kinds = []string{Pest, Disease}
+
+type Config struct {
+	Seed uint64
+
+	// Chance per hour of each kind breaking out on a planting with average care and no infected neighbours.
+	Chance map[string]float64
+
+	// Each infected neighbour adds this multiple of the base chance.
+	NeighbourFactor float64
+
+	// Share of the yield lost per hour an infection goes untreated, up to MaxDamage.
+	DamagePerHour float64
+	MaxDamage     float64
+
+	// Treatment cost per planted land unit, as a multiple of the crop's cost per unit.
+	TreatmentCostFactor float64
+}
+
+// DefaultConfig is used for anything the OUTBREAK_* env variables leave unset.
+func DefaultConfig() Config {
+	return Config{
+		Seed:                utils.WorldSeed(),
+		Chance:              map[string]float64{Pest: 0.01, Disease: 0.005},
+		NeighbourFactor:     1.0,
+		DamagePerHour:       0.02,
+		MaxDamage:           0.8,
+		TreatmentCostFactor: 0.25,
+	}
+}
+
+// ConfigFromEnv reads OUTBREAK_SEED, OUTBREAK_PEST_CHANCE, OUTBREAK_DISEASE_CHANCE,
+// OUTBREAK_NEIGHBOUR_FACTOR, OUTBREAK_DAMAGE_PER_HOUR, OUTBREAK_MAX_DAMAGE and
+// OUTBREAK_TREATMENT_COST_FACTOR over the defaults.
+func ConfigFromEnv() Config {
+	config := DefaultConfig()
+
+	if seed, err := strconv.ParseUint(os.Getenv("OUTBREAK_SEED"), 10, 64); err == nil {
+		config.Seed = seed
+	}
+
+	readFloat("OUTBREAK_PEST_CHANCE", func(v float64) { config.Chance[Pest] = v })
+	readFloat("OUTBREAK_DISEASE_CHANCE", func(v float64) { config.Chance[Disease] = v })
+	readFloat("OUTBREAK_NEIGHBOUR_FACTOR", func(v float64) { config.NeighbourFactor = v })
+	readFloat("OUTBREAK_DAMAGE_PER_HOUR", func(v float64) { config.DamagePerHour = v })
+	readFloat("OUTBREAK_MAX_DAMAGE", func(v float64) { config.MaxDamage = math.Min(1, v) })
+	readFloat("OUTBREAK_TREATMENT_COST_FACTOR", func(v float64) { config.TreatmentCostFactor = v })
+
+	return config
+}
+
+type Engine struct {
+	config Config
+}
+
+func NewEngine(config Config) *Engine {
+	return &Engine{config: config}
+}
+
+func (e *Engine) Config() Config {
+	return e.config
+}
+
+// Chance returns the hourly chance of kind breaking out on the planting. Care quality below 1.0
+// raises it and above lowers it, within half and double; each infected neighbour adds the
+// neighbour factor. A crop's susceptibility defaults to 1.
+func (e *Engine) Chance(kind string, crop *models.Crop, planting models.PlantedCrop, infectedNeighbours int) float64 {
+	susceptibility := 1.0
+	if value, ok := crop.OutbreakSusceptibility[kind]; ok {
+		susceptibility = value
+	}
+
+	quality := planting.QualityFactor
+	if quality <= 0 {
+		quality = 1
+	}
+
+	care := math.Max(0.5, math.Min(2, 2-quality))
+	spread := 1 + e.config.NeighbourFactor*float64(infectedNeighbours)
+
+	return math.Min(1, e.config.Chance[kind]*susceptibility*care*spread)
+}
+
+// Roll returns the kind breaking out on the planting in the hour containing at, or "" if none does.
+func (e *Engine) Roll(crop *models.Crop, planting models.PlantedCrop, infectedNeighbours int, at time.Time) string {
+	hash := fnv.New64a()
+	hash.Write(planting.ID[:])
+
+	random := rand.New(rand.NewPCG(e.config.Seed^hash.Sum64(), uint64(at.Unix()/3600)))
+
+	for _, kind := range Kinds {
+		if random.Float64() < e.Chance(kind, crop, planting, infectedNeighbours) {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+// NewInfection starts an infection, fixing its damage rate at the current config.
+func (e *Engine) NewInfection(kind string, at time.Time, infectedNeighbours int) models.Infection {
+	return models.Infection{
+		Kind:               kind,
+		InfectedAt:         at,
+		InfectedNeighbours: infectedNeighbours,
+		DamagePerHour:      e.config.DamagePerHour,
+		MaxDamage:          e.config.MaxDamage,
+	}
+}
+
+// TreatmentCost is the price of treating an infected planting of crop.
+func (e *Engine) TreatmentCost(crop *models.Crop, planting models.PlantedCrop) float64 {
+	return e.config.TreatmentCostFactor * crop.CostPerUnit * float64(planting.QuantityPlanted)
+}
+
+// Damage returns the share of yield an infection has destroyed by at, including what earlier
+// treated infections of the planting destroyed; it stops growing once treated.
+func Damage(infection *models.Infection, at time.Time) float64 {
+	if infection == nil {
+		return 0
+	}
+
+	if !infection.TreatedAt.IsZero() {
+		return infection.Damage
+	}
+
+	hours := math.Max(0, at.Sub(infection.InfectedAt).Hours())
+
+	return math.Max(infection.PriorDamage, math.Min(infection.MaxDamage, infection.PriorDamage+hours*infection.DamagePerHour))
+}
+
+// PlantingDamage returns the share of the planting's yield destroyed by at. A fully grown crop
+// takes no further damage, so the infection is only counted up to the time it was ready.
+func PlantingDamage(planting models.PlantedCrop, at time.Time) float64 {
+	grownAt := planting.HarvestReadyAt
+	if grownAt.IsZero() {
+		grownAt = planting.ExpectedHarvestAt
+	}
+
+	if !grownAt.IsZero() && grownAt.Before(at) {
+		at = grownAt
+	}
+
+	return Damage(planting.Infection, at)
+}
+
+func readFloat(key string, set func(float64)) {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && value >= 0 {
+		set(value)
+	}
+}
@@ -0,0 +1,176 @@
+package outbreak
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testConfig(seed uint64) Config {
+	config := DefaultConfig()
+	config.Seed = seed
+	config.Chance = map[string]float64{Pest: 0.1, Disease: 0.05}
+
+	return config
+}
+
+func testPlanting() models.PlantedCrop {
+	return models.PlantedCrop{
+		BaseModel:     models.BaseModel{ID: primitive.NewObjectID()},
+		QualityFactor: 1,
+	}
+}
+
+// rolls returns the outcome of every hourly roll on the planting over a month.
+func rolls(engine *Engine, crop *models.Crop, planting models.PlantedCrop) []string {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	outcomes := make([]string, 0, 30*24)
+	for hour := 0; hour < 30*24; hour++ {
+		outcomes = append(outcomes, engine.Roll(crop, planting, 0, start.Add(time.Duration(hour)*time.Hour)))
+	}
+
+	return outcomes
+}
+
+func TestSeedReplaysSameOutbreaks(t *testing.T) {
+	crop := &models.Crop{}
+	planting := testPlanting()
+
+	first := rolls(NewEngine(testConfig(7)), crop, planting)
+	second := rolls(NewEngine(testConfig(7)), crop, planting)
+
+	outbreaks := 0
+	for hour := range first {
+		if first[hour] != second[hour] {
+			t.Fatalf("hour %d rolled %q then %q with the same seed", hour, first[hour], second[hour])
+		}
+		if first[hour] != "" {
+			outbreaks++
+		}
+	}
+
+	if outbreaks == 0 {
+		t.Fatal("no outbreaks rolled in a month at a 15% hourly chance")
+	}
+}
+
+func TestRollWithinHourIsStable(t *testing.T) {
+	engine := NewEngine(testConfig(7))
+	crop := &models.Crop{}
+	planting := testPlanting()
+	hour := time.Date(2026, 1, 1, 5, 0, 0, 0, time.UTC)
+
+	want := engine.Roll(crop, planting, 0, hour)
+	for minute := 1; minute < 60; minute += 7 {
+		if got := engine.Roll(crop, planting, 0, hour.Add(time.Duration(minute)*time.Minute)); got != want {
+			t.Fatalf("roll at minute %d = %q, want %q from the start of the hour", minute, got, want)
+		}
+	}
+}
+
+func TestSeedChangesOutbreaks(t *testing.T) {
+	crop := &models.Crop{}
+	planting := testPlanting()
+
+	first := rolls(NewEngine(testConfig(7)), crop, planting)
+	second := rolls(NewEngine(testConfig(8)), crop, planting)
+
+	for hour := range first {
+		if first[hour] != second[hour] {
+			return
+		}
+	}
+
+	t.Fatal("different seeds rolled the same month of outbreaks")
+}
+
+func TestChanceFollowsCareAndNeighbours(t *testing.T) {
+	engine := NewEngine(testConfig(7))
+	crop := &models.Crop{OutbreakSusceptibility: map[string]float64{Disease: 2}}
+
+	planting := testPlanting()
+	base := engine.Chance(Pest, crop, planting, 0)
+
+	if got := engine.Chance(Pest, crop, planting, 2); math.Abs(got-3*base) > 1e-9 {
+		t.Errorf("chance with two infected neighbours = %v, want %v", got, 3*base)
+	}
+
+	if got := engine.Chance(Disease, crop, planting, 0); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("disease chance with susceptibility 2 = %v, want 0.1", got)
+	}
+
+	planting.QualityFactor = 1.5
+	if got := engine.Chance(Pest, crop, planting, 0); math.Abs(got-base/2) > 1e-9 {
+		t.Errorf("chance with good care = %v, want %v", got, base/2)
+	}
+}
+
+func TestDamageGrowsUntilTreated(t *testing.T) {
+	engine := NewEngine(testConfig(7))
+	infectedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	infection := engine.NewInfection(Pest, infectedAt, 0)
+
+	if got := Damage(&infection, infectedAt.Add(10*time.Hour)); math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("damage after 10 hours = %v, want 0.2", got)
+	}
+
+	if got := Damage(&infection, infectedAt.Add(1000*time.Hour)); got != infection.MaxDamage {
+		t.Errorf("damage after 1000 hours = %v, want the maximum %v", got, infection.MaxDamage)
+	}
+
+	treatedAt := infectedAt.Add(5 * time.Hour)
+	infection.Damage = Damage(&infection, treatedAt)
+	infection.TreatedAt = treatedAt
+
+	if got := Damage(&infection, infectedAt.Add(100*time.Hour)); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("damage after treatment = %v, want 0.1 fixed at treatment", got)
+	}
+}
+
+func TestReinfectionKeepsEarlierDamage(t *testing.T) {
+	engine := NewEngine(testConfig(7))
+	infectedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	infection := engine.NewInfection(Disease, infectedAt, 0)
+	infection.PriorDamage = 0.3
+
+	if got := Damage(&infection, infectedAt); math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("damage at reinfection = %v, want the earlier 0.3", got)
+	}
+
+	if got := Damage(&infection, infectedAt.Add(10*time.Hour)); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("damage 10 hours after reinfection = %v, want 0.5", got)
+	}
+
+	if got := Damage(&infection, infectedAt.Add(1000*time.Hour)); got != infection.MaxDamage {
+		t.Errorf("damage long after reinfection = %v, want the maximum %v", got, infection.MaxDamage)
+	}
+}
+
+func TestPlantingDamageStopsWhenGrown(t *testing.T) {
+	engine := NewEngine(testConfig(7))
+	infectedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	infection := engine.NewInfection(Pest, infectedAt, 0)
+
+	planting := testPlanting()
+	planting.Infection = &infection
+	planting.ExpectedHarvestAt = infectedAt.Add(10 * time.Hour)
+
+	if got := PlantingDamage(planting, infectedAt.Add(30*time.Hour)); math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("damage 20 hours after the crop was grown = %v, want 0.2", got)
+	}
+
+	planting.HarvestReadyAt = infectedAt.Add(5 * time.Hour)
+	if got := PlantingDamage(planting, infectedAt.Add(30*time.Hour)); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("damage after the recorded ready time = %v, want 0.1", got)
+	}
+
+	planting.Infection = nil
+	if got := PlantingDamage(planting, infectedAt.Add(30*time.Hour)); got != 0 {
+		t.Errorf("damage without an infection = %v, want 0", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/hrutik1235/farming-server/controller"
 	middleware "github.com/hrutik1235/farming-server/midlleware"
 	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
 	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
 )
@@ -16,6 +17,11 @@ func NewCropRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Da
 	group.Use(middleware.GateValidateUser())
 	group.POST("", middleware.ValidateRequest[types.CreateCrop, any, any](), cropController.CreateCrop)
 	group.GET("", cropController.GetAllCrops)
-	group.POST("/plant/:cropid", middleware.ValidateRequest[types.PlantCrop, any, any](), cropController.PlantCrop)
+	group.POST("/plant/:id", middleware.ValidateRequest[types.PlantCrop, any, any](), cropController.PlantCrop)
 	group.GET("/plant", cropController.GetAllPlantedCrops)
+	group.POST("/plant/:id/water", cropController.CareForPlanting(utils.CareWater))
+	group.POST("/plant/:id/fertilize", cropController.CareForPlanting(utils.CareFertilize))
+	group.POST("/plant/:id/weed", cropController.CareForPlanting(utils.CareWeed))
+	group.POST("/plant/:id/treat", cropController.TreatPlanting)
+	group.GET("/plant/:id/activity", cropController.GetPlantingActivity)
 }
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/controller"
+	middleware "github.com/hrutik1235/farming-server/midlleware"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+func NewFederationRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Database) {
+	federationController := controller.NewFederationController(dbClient)
+	group := r.Group("/federation")
+
+	group.Use(middleware.GateValidateUser())
+	group.POST("/peers", middleware.ValidateRequest[types.ConnectPeer, any, any](), federationController.ConnectPeer)
+	group.GET("/peers", federationController.GetPeers)
+	group.POST("/peers/:peerid/sync", middleware.ValidateRequest[any, any, any](), federationController.SyncDirectory)
+	group.GET("/directory", federationController.GetDirectory)
+	group.POST("/trades", middleware.ValidateRequest[types.InitiateFederatedTrade, any, any](), federationController.InitiateTrade)
+	group.GET("/trades", federationController.GetTrades)
+	group.POST("/trades/:tradeid/accept", middleware.ValidateRequest[any, any, any](), federationController.AcceptTrade)
+	group.POST("/trades/:tradeid/decline", middleware.ValidateRequest[any, any, any](), federationController.DeclineTrade)
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/controller"
+	middleware "github.com/hrutik1235/farming-server/midlleware"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+func NewLandRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Database) {
+	landController := controller.NewLandController(dbClient)
+	group := r.Group("/land")
+
+	group.Use(middleware.GateValidateUser())
+	group.GET("/map", landController.GetLandMap)
+	group.GET("/expand", landController.QuoteExpansion)
+	group.POST("/expand", middleware.ValidateRequest[types.ExpandLand, any, any](), landController.ExpandLand)
+	group.POST("/units/:id/structure", middleware.ValidateRequest[types.BuildStructure, any, any](), landController.BuildStructure)
+	group.DELETE("/units/:id/structure", landController.RemoveStructure)
+	group.GET("/units/:id/transfers", landController.GetTransfers)
+	group.GET("/listings", landController.GetListings)
+	group.POST("/listings", middleware.ValidateRequest[types.ListLand, any, any](), landController.CreateListing)
+	group.DELETE("/listings/:id", landController.CancelListing)
+	group.POST("/listings/:id/buy", landController.BuyListing)
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/controller"
+	middleware "github.com/hrutik1235/farming-server/midlleware"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+func NewLeaseRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Database) {
+	leaseController := controller.NewLeaseController(dbClient)
+	group := r.Group("/lease")
+
+	group.Use(middleware.GateValidateUser())
+	group.GET("", leaseController.GetLeases)
+	group.GET("/:id/activity", leaseController.GetActivity)
+	group.POST("", middleware.ValidateRequest[types.RequestLease, any, any](), leaseController.RequestLease)
+	group.POST("/:id/accept", leaseController.AcceptLease)
+	group.POST("/:id/reject", leaseController.RejectLease)
+	group.DELETE("/:id", leaseController.CancelLease)
+
+	group.GET("/auctions", leaseController.GetAuctions)
+	group.POST("/auctions", middleware.ValidateRequest[types.CreateLeaseAuction, any, any](), leaseController.CreateAuction)
+	group.DELETE("/auctions/:id", leaseController.CancelAuction)
+	group.GET("/auctions/:id/bids", leaseController.GetBids)
+	group.POST("/auctions/:id/bids", middleware.ValidateRequest[types.PlaceBid, any, any](), leaseController.PlaceBid)
+}
@@ -0,0 +1,47 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/controller"
+	middleware "github.com/hrutik1235/farming-server/midlleware"
+	"github.com/hrutik1235/farming-server/types"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+func NewMarketRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Database) {
+	marketController := controller.NewMarketController(dbClient)
+	group := r.Group("/market")
+
+	group.Use(middleware.GateValidateUser())
+	group.POST("/sell/:cropid", middleware.ValidateRequest[types.SellCrop, any, any](), marketController.SellCrop)
+
+	group.GET("/orders", marketController.GetOrders)
+	group.DELETE("/orders/:id", marketController.CancelOrder)
+	group.GET("/:cropid/prices", marketController.GetMarketPrices)
+	group.GET("/:cropid/book", marketController.GetOrderBook)
+	group.POST("/:cropid/orders", middleware.ValidateRequest[types.PlaceOrder, any, any](), marketController.PlaceOrder)
+
+	group.GET("/store", middleware.ValidateRequest[any, types.StoreQuery, any](), marketController.GetStoreListings)
+	group.POST("/store", middleware.ValidateRequest[types.ListInStore, any, any](), marketController.CreateStoreListing)
+	group.DELETE("/store/:id", marketController.CancelStoreListing)
+	group.POST("/store/:id/buy", middleware.ValidateRequest[types.BuyFromStore, any, any](), marketController.BuyFromStore)
+
+	group.GET("/auctions", marketController.GetAuctions)
+	group.POST("/auctions", middleware.ValidateRequest[types.CreateStockAuction, any, any](), marketController.CreateAuction)
+	group.DELETE("/auctions/:id", marketController.CancelAuction)
+	group.GET("/auctions/:id/bids", marketController.GetAuctionBids)
+	group.POST("/auctions/:id/bids", middleware.ValidateRequest[types.PlaceBid, any, any](), marketController.PlaceAuctionBid)
+
+	group.GET("/forwards", marketController.GetForwards)
+	group.GET("/forwards/mine", marketController.GetUserForwards)
+	group.POST("/forwards", middleware.ValidateRequest[types.OfferForward, any, any](), marketController.OfferForward)
+	group.POST("/forwards/:id/accept", marketController.AcceptForward)
+	group.DELETE("/forwards/:id", marketController.CancelForward)
+
+	group.GET("/contracts", marketController.GetContractBoard)
+	group.GET("/contracts/mine", marketController.GetUserContracts)
+	group.POST("/contracts/:id/accept", marketController.AcceptContract)
+	group.POST("/contracts/:id/deliver", marketController.FulfilContract)
+	group.DELETE("/contracts/:id", marketController.AbandonContract)
+}
@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/controller"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+func NewSocketRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Database) {
+	socketController := controller.NewSocketController(dbClient)
+
+	// The controller authenticates the upgrade itself since browsers cannot send the user_id header.
+	r.GET("/ws", socketController.Connect)
+}
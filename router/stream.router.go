@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/controller"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+func NewStreamRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Database) {
+	streamController := controller.NewStreamController(dbClient)
+
+	// The controller authenticates the stream itself since EventSource cannot send the user_id header.
+	r.GET("/stream", streamController.Stream)
+}
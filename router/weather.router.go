@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hrutik1235/farming-server/controller"
+	middleware "github.com/hrutik1235/farming-server/midlleware"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+)
+
+func NewWeatherRoutes(r *gin.RouterGroup, conn *grpc.ClientConn, dbClient *mongo.Database) {
+	weatherController := controller.NewWeatherController(dbClient)
+	group := r.Group("/weather")
+
+	group.Use(middleware.GateValidateUser())
+	group.GET("", weatherController.GetForecast)
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	AdjacencyCompanion  = "COMPANION"
+	AdjacencyAntagonist = "ANTAGONIST"
+	AdjacencyPollinator = "POLLINATOR"
+
+	maxPollinatorYieldBonus = 0.3
+)
+
+type structureSpec struct {
+	Cost       float64
+	Radius     int     // Grid cells in any direction, diagonals included
+	YieldBonus float64 // Fraction added to the yield of each planting in range
+}
+
+var structureSpecs = map[string]structureSpec{
+	utils.StructureBeehive: {Cost: 250, Radius: 2, YieldBonus: 0.1},
+}
+
+type AdjacencyService struct {
+	Client *mongo.Database
+
+	cropService *CropService
+}
+
+func NewAdjacencyService(client *mongo.Database) *AdjacencyService {
+	return &AdjacencyService{
+		Client:      client,
+		cropService: NewCropService(client),
+	}
+}
+
+// Evaluate works out how the land around a planting changes its harvest. Each companion planting
+// sharing an edge with it adds 5% quality and each antagonist takes 5% off, up to 15% either way.
+// Each pollinator structure in range adds its yield bonus, up to 30%. Neighbours count if they
+// grew at the same time as the planting, even when they were harvested first.
+func (as *AdjacencyService) Evaluate(ctx context.Context, planting *models.PlantedCrop) (*models.AdjacencyReport, error) {
+	report := &models.AdjacencyReport{Effects: []models.AdjacencyEffect{}}
+
+	crop, err := as.cropService.GetCropById(planting.CropID)
+	if err != nil {
+		return nil, err
+	}
+
+	own, err := as.landUnitsById(ctx, planting.LandUnitIDs)
+	if err != nil || len(own) == 0 {
+		return report, err
+	}
+
+	nearby, err := as.nearbyLandUnits(ctx, own)
+	if err != nil {
+		return nil, err
+	}
+
+	adjacent := make(map[string]models.LandUnit)
+	for _, unit := range nearby {
+		if unit.Structure != nil {
+			as.addPollinator(report, own, unit)
+			continue
+		}
+
+		if isAdjacent(own, unit) {
+			adjacent[unit.ID.Hex()] = unit
+		}
+	}
+
+	neighbours, err := as.neighbourPlantings(ctx, planting, adjacent)
+	if err != nil {
+		return nil, err
+	}
+
+	crops := map[primitive.ObjectID]*models.Crop{crop.ID: crop}
+
+	for _, neighbour := range neighbours {
+		other, ok := crops[neighbour.CropID]
+		if !ok {
+			if other, err = as.cropService.GetCropById(neighbour.CropID); err != nil {
+				fmt.Printf("Error loading crop %s: %v\n", neighbour.CropID.Hex(), err)
+				continue
+			}
+			crops[neighbour.CropID] = other
+		}
+
+		effect := models.AdjacencyEffect{
+			LandUnitID: adjacentUnitOf(neighbour, adjacent),
+			PlantingID: neighbour.ID,
+			CropID:     other.ID,
+		}
+
+		switch {
+		case isPair(crop, other, func(c *models.Crop) []primitive.ObjectID { return c.Antagonists }):
+			effect.Kind = AdjacencyAntagonist
+			effect.QualityChange = -utils.AntagonistQualityPenalty
+			effect.Description = fmt.Sprintf("%s next to %s lowers quality", other.Name, crop.Name)
+		case isPair(crop, other, func(c *models.Crop) []primitive.ObjectID { return c.Companions }):
+			effect.Kind = AdjacencyCompanion
+			effect.QualityChange = utils.CompanionQualityBonus
+			effect.Description = fmt.Sprintf("%s next to %s raises quality", other.Name, crop.Name)
+		default:
+			continue
+		}
+
+		report.QualityChange += effect.QualityChange
+		report.Effects = append(report.Effects, effect)
+	}
+
+	report.QualityChange = math.Max(-utils.MaxAdjacencyQualityChange, math.Min(utils.MaxAdjacencyQualityChange, report.QualityChange))
+	report.YieldBonus = math.Min(maxPollinatorYieldBonus, report.YieldBonus)
+
+	return report, nil
+}
+
+func (as *AdjacencyService) addPollinator(report *models.AdjacencyReport, own []models.LandUnit, unit models.LandUnit) {
+	spec, ok := structureSpecs[unit.Structure.Kind]
+	if !ok || spec.YieldBonus == 0 || !withinRadius(own, unit, spec.Radius) {
+		return
+	}
+
+	report.YieldBonus += spec.YieldBonus
+	report.Effects = append(report.Effects, models.AdjacencyEffect{
+		Kind:        AdjacencyPollinator,
+		LandUnitID:  unit.ID,
+		YieldChange: spec.YieldBonus,
+		Description: fmt.Sprintf("A %s at position %d pollinates the crop", strings.ToLower(unit.Structure.Kind), unit.Position),
+	})
+}
+
+func (as *AdjacencyService) landUnitsById(ctx context.Context, landUnitIds []string) ([]models.LandUnit, error) {
+	objectIds, err := utils.ConvertObjectIdsFromStringIds(landUnitIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.cropService.findLandUnits(ctx, bson.M{"_id": bson.M{"$in": objectIds}})
+}
+
+// nearbyLandUnits loads the other land units within reach of the largest structure radius, on the
+// grids of the units' owners.
+func (as *AdjacencyService) nearbyLandUnits(ctx context.Context, own []models.LandUnit) ([]models.LandUnit, error) {
+	reach := 1
+	for _, spec := range structureSpecs {
+		reach = max(reach, spec.Radius)
+	}
+
+	positions := make(map[primitive.ObjectID][]int)
+	for _, unit := range own {
+		x, y := GridCell(unit.Position)
+
+		for dy := -reach; dy <= reach; dy++ {
+			for dx := -reach; dx <= reach; dx++ {
+				if x+dx < 0 || x+dx >= utils.LandGridWidth || y+dy < 0 || (dx == 0 && dy == 0) {
+					continue
+				}
+				positions[unit.OwnerID] = append(positions[unit.OwnerID], GridPosition(x+dx, y+dy))
+			}
+		}
+	}
+
+	filters := make([]bson.M, 0, len(positions))
+	for ownerId, ownerPositions := range positions {
+		filters = append(filters, bson.M{"owner_id": ownerId, "position": bson.M{"$in": ownerPositions}})
+	}
+
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	units, err := as.cropService.findLandUnits(ctx, bson.M{"$or": filters})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(units, func(unit models.LandUnit) bool {
+		return slices.ContainsFunc(own, func(o models.LandUnit) bool { return o.ID == unit.ID })
+	}), nil
+}
+
+// neighbourPlantings returns the other plantings on the adjacent units that grew alongside planting.
+func (as *AdjacencyService) neighbourPlantings(ctx context.Context, planting *models.PlantedCrop, adjacent map[string]models.LandUnit) ([]models.PlantedCrop, error) {
+	if len(adjacent) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(adjacent))
+	for id := range adjacent {
+		ids = append(ids, id)
+	}
+
+	cursor, err := as.Client.Collection(utils.PlantedCropsCollection).Find(ctx, bson.M{
+		"_id":           bson.M{"$ne": planting.ID},
+		"land_unit_ids": bson.M{"$in": ids},
+		"is_active":     true,
+		"$or": []bson.M{
+			{"is_harvested": false},
+			{"harvested_at": bson.M{"$gt": planting.PlantedAt}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var plantings []models.PlantedCrop
+	err = cursor.All(ctx, &plantings)
+
+	return plantings, err
+}
+
+// isPair reports whether either crop lists the other in the given list.
+func isPair(crop *models.Crop, other *models.Crop, list func(*models.Crop) []primitive.ObjectID) bool {
+	return slices.Contains(list(crop), other.ID) || slices.Contains(list(other), crop.ID)
+}
+
+// isAdjacent reports whether unit shares an edge with any of own on the same owner's grid.
+func isAdjacent(own []models.LandUnit, unit models.LandUnit) bool {
+	for _, o := range own {
+		if o.OwnerID == unit.OwnerID && slices.Contains(neighbourPositions(o.Position), unit.Position) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withinRadius(own []models.LandUnit, unit models.LandUnit, radius int) bool {
+	x, y := GridCell(unit.Position)
+
+	for _, o := range own {
+		ox, oy := GridCell(o.Position)
+		if o.OwnerID == unit.OwnerID && max(abs(x-ox), abs(y-oy)) <= radius {
+			return true
+		}
+	}
+
+	return false
+}
+
+func adjacentUnitOf(planting models.PlantedCrop, adjacent map[string]models.LandUnit) primitive.ObjectID {
+	for _, id := range planting.LandUnitIDs {
+		if unit, ok := adjacent[id]; ok {
+			return unit.ID
+		}
+	}
+
+	return primitive.NilObjectID
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// toCropPairs parses a crop's companion and antagonist crop ids.
+func toCropPairs(companions []string, antagonists []string) ([]primitive.ObjectID, []primitive.ObjectID, error) {
+	companionIds, err := utils.ConvertObjectIdsFromStringIds(companions)
+	if err != nil {
+		return nil, nil, NewServiceError(http.StatusBadRequest, "invalid companion crop id")
+	}
+
+	antagonistIds, err := utils.ConvertObjectIdsFromStringIds(antagonists)
+	if err != nil {
+		return nil, nil, NewServiceError(http.StatusBadRequest, "invalid antagonist crop id")
+	}
+
+	for _, id := range companionIds {
+		if slices.Contains(antagonistIds, id) {
+			return nil, nil, NewServiceError(http.StatusBadRequest, "crop %s cannot be both a companion and an antagonist", id.Hex())
+		}
+	}
+
+	return companionIds, antagonistIds, nil
+}
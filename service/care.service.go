@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const activityPageSize = 100
+
+type CareService struct {
+	Client *mongo.Database
+
+	cropService   *CropService
+	walletService *WalletService
+}
+
+func NewCareService(client *mongo.Database) *CareService {
+	return &CareService{
+		Client:        client,
+		cropService:   NewCropService(client),
+		walletService: NewWalletService(client),
+	}
+}
+
+// PerformCare waters, fertilizes or weeds a growing planting. The cost is held while the planting
+// is updated and only spent once the action is recorded, so a lost race costs nothing.
+func (cs *CareService) PerformCare(ctx context.Context, userId primitive.ObjectID, plantingId primitive.ObjectID, action string) (*models.CareResult, error) {
+	planting, err := cs.getPlanting(ctx, userId, plantingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if planting.IsHarvested {
+		return nil, NewServiceError(http.StatusBadRequest, "Planting is already harvested")
+	}
+
+	if planting.IsReady || cs.cropService.CalculateCurrentGrowth(*planting) >= 1 {
+		return nil, NewServiceError(http.StatusBadRequest, "Planting is ready for harvest")
+	}
+
+	crop, err := cs.cropService.GetCropById(planting.CropID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := findCareSchedule(crop, action)
+	if schedule == nil {
+		return nil, NewServiceError(http.StatusBadRequest, "%s does not need %s", crop.Name, strings.ToLower(action))
+	}
+
+	now := time.Now()
+	status := planting.Care[action]
+
+	if readyAt := status.LastPerformedAt.Add(hours(schedule.CooldownHours)); !status.LastPerformedAt.IsZero() && now.Before(readyAt) {
+		return nil, NewServiceError(http.StatusTooManyRequests, "%s is on cooldown until %s", strings.ToLower(action), readyAt.Format(time.RFC3339))
+	}
+
+	activity := models.PlantingActivity{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		PlantingID:   planting.ID,
+		UserID:       userId,
+		CropID:       planting.CropID,
+		ActivityType: action,
+		Description:  fmt.Sprintf("%s %s", careVerb(action), crop.Name),
+		Cost:         schedule.CostPerUnit * float64(planting.QuantityPlanted),
+		Timestamp:    now,
+	}
+
+	var hold *models.WalletHold
+	if activity.Cost > 0 {
+		hold, err = cs.walletService.Hold(ctx, userId, activity.Cost, "CROP_CARE", activity.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := cs.applyCare(ctx, planting, action, status, schedule, now)
+	if err != nil {
+		if hold != nil {
+			if releaseErr := cs.walletService.ReleaseHold(ctx, hold.ID); releaseErr != nil {
+				fmt.Printf("Error releasing care hold %s: %v\n", hold.ID.Hex(), releaseErr)
+			}
+		}
+		return nil, err
+	}
+
+	if hold != nil {
+		if err := cs.walletService.CaptureHold(ctx, hold.ID, "CROP_CARE", activity.Description); err != nil {
+			// A capture that got as far as claiming the hold is finished by the wallet worker, so
+			// the care stands; otherwise nothing was paid and the care is taken back.
+			if current, getErr := cs.walletService.GetHold(ctx, hold.ID); getErr != nil || current.Status != utils.EscrowCaptured {
+				cs.revertCare(ctx, planting, action, status, now)
+
+				if releaseErr := cs.walletService.ReleaseHold(ctx, hold.ID); releaseErr != nil {
+					fmt.Printf("Error releasing care hold %s: %v\n", hold.ID.Hex(), releaseErr)
+				}
+				return nil, err
+			}
+		}
+	}
+
+	activity.QualityFactor = updated.QualityFactor
+	activity.QualityChange = updated.QualityFactor - careQualityOf(planting)
+
+	if updated.ExpectedHarvestAt.Before(planting.ExpectedHarvestAt) {
+		activity.Description += fmt.Sprintf(", harvest moved up to %s", updated.ExpectedHarvestAt.Format(time.RFC3339))
+	}
+
+	cs.recordActivity(ctx, planting, &activity)
+
+	return &models.CareResult{Planting: updated, Activity: activity}, nil
+}
+
+// applyCare raises the planting's care quality, starts a new care window and pulls the harvest
+// forward. It only applies if nobody performed the action since status was read.
+func (cs *CareService) applyCare(ctx context.Context, planting *models.PlantedCrop, action string, status models.CareStatus, schedule *models.CareSchedule, now time.Time) (*models.PlantedCrop, error) {
+	filter := bson.M{
+		"_id":          planting.ID,
+		"is_harvested": false,
+		"is_ready":     bson.M{"$ne": true},
+	}
+
+	if status.LastPerformedAt.IsZero() {
+		filter["care."+action+".last_performed_at"] = bson.M{"$exists": false}
+	} else {
+		filter["care."+action+".last_performed_at"] = status.LastPerformedAt
+	}
+
+	set := bson.M{
+		"quality_factor": careQualityExpr(schedule.QualityBonus),
+		"care." + action: bson.M{
+			"last_performed_at": now,
+			"next_due_at":       now.Add(hours(schedule.IntervalHours)),
+		},
+		"updated_at": now,
+	}
+
+	// Never pulled forward past the present, so the planting is at most ready now.
+	if schedule.GrowthBoostHours > 0 {
+		set["expected_harvest_at"] = bson.M{"$max": bson.A{
+			now,
+			bson.M{"$subtract": bson.A{"$expected_harvest_at", hours(schedule.GrowthBoostHours).Milliseconds()}},
+		}}
+	}
+
+	var updated models.PlantedCrop
+
+	err := cs.Client.Collection(utils.PlantedCropsCollection).FindOneAndUpdate(
+		ctx,
+		filter,
+		mongo.Pipeline{{{Key: "$set", Value: set}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusConflict, "Planting changed, try again")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// revertCare undoes an applyCare made at now that could not be paid for, unless the planting
+// changed since.
+func (cs *CareService) revertCare(ctx context.Context, planting *models.PlantedCrop, action string, status models.CareStatus, now time.Time) {
+	set := bson.M{
+		"quality_factor":      planting.QualityFactor,
+		"expected_harvest_at": planting.ExpectedHarvestAt,
+		"updated_at":          time.Now(),
+	}
+	update := bson.M{"$set": set}
+
+	if _, ok := planting.Care[action]; ok {
+		set["care."+action] = status
+	} else {
+		update["$unset"] = bson.M{"care." + action: ""}
+	}
+
+	_, err := cs.Client.Collection(utils.PlantedCropsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": planting.ID, "care." + action + ".last_performed_at": now},
+		update,
+	)
+	if err != nil {
+		fmt.Printf("Error reverting care of planting %s: %v\n", planting.ID.Hex(), err)
+	}
+}
+
+// SettleMissedCare lowers the quality of a growing planting for every care window that closed
+// without the action being taken, and opens the next window.
+func (cs *CareService) SettleMissedCare(ctx context.Context, planting models.PlantedCrop, crop *models.Crop) error {
+	now := time.Now()
+
+	for _, schedule := range careScheduleFor(crop) {
+		status, ok := planting.Care[schedule.Action]
+		interval := hours(schedule.IntervalHours)
+
+		if !ok || interval <= 0 || !now.After(status.NextDueAt) {
+			continue
+		}
+
+		missed := int(now.Sub(status.NextDueAt)/interval) + 1
+		penalty := schedule.QualityPenalty * float64(missed)
+
+		var updated models.PlantedCrop
+
+		err := cs.Client.Collection(utils.PlantedCropsCollection).FindOneAndUpdate(
+			ctx,
+			bson.M{
+				"_id":          planting.ID,
+				"is_harvested": false,
+				"care." + schedule.Action + ".next_due_at": status.NextDueAt,
+			},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"quality_factor": careQualityExpr(-penalty),
+				"care." + schedule.Action + ".next_due_at": status.NextDueAt.Add(time.Duration(missed) * interval),
+				"updated_at": now,
+			}}}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&updated)
+
+		// Someone else settled or performed it first.
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		cs.recordActivity(ctx, &planting, &models.PlantingActivity{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			},
+			PlantingID:    planting.ID,
+			UserID:        planting.UserID,
+			CropID:        planting.CropID,
+			ActivityType:  "MISSED_" + schedule.Action,
+			Description:   fmt.Sprintf("Missed %d %s window(s) for %s", missed, strings.ToLower(schedule.Action), crop.Name),
+			QualityChange: updated.QualityFactor - careQualityOf(&planting),
+			QualityFactor: updated.QualityFactor,
+			Timestamp:     now,
+		})
+
+		planting.QualityFactor = updated.QualityFactor
+	}
+
+	return nil
+}
+
+// GetPlantingActivity returns the planting's care log, newest first.
+func (cs *CareService) GetPlantingActivity(ctx context.Context, userId primitive.ObjectID, plantingId primitive.ObjectID) ([]models.PlantingActivity, error) {
+	if _, err := cs.getPlanting(ctx, userId, plantingId); err != nil {
+		return nil, err
+	}
+
+	cursor, err := cs.Client.Collection(utils.ActivitiesCollection).Find(
+		ctx,
+		bson.M{"planting_id": plantingId},
+		options.Find().SetSort(bson.M{"timestamp": -1}).SetLimit(activityPageSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	activities := []models.PlantingActivity{}
+	err = cursor.All(ctx, &activities)
+
+	return activities, err
+}
+
+func (cs *CareService) getPlanting(ctx context.Context, userId primitive.ObjectID, plantingId primitive.ObjectID) (*models.PlantedCrop, error) {
+	var planting models.PlantedCrop
+
+	err := cs.Client.Collection(utils.PlantedCropsCollection).FindOne(ctx, bson.M{
+		"_id":       plantingId,
+		"user_id":   userId,
+		"is_active": true,
+	}).Decode(&planting)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Planting not found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &planting, nil
+}
+
+// recordActivity adds an entry to the planting's activity log, and to the lease feed when the
+// planting is on leased land.
+func (cs *CareService) recordActivity(ctx context.Context, planting *models.PlantedCrop, activity *models.PlantingActivity) {
+	if _, err := cs.Client.Collection(utils.ActivitiesCollection).InsertOne(ctx, activity); err != nil {
+		fmt.Printf("Error recording %s activity for planting %s: %v\n", activity.ActivityType, activity.PlantingID.Hex(), err)
+	}
+
+	recordLeaseActivity(ctx, cs.Client, planting, activity.ActivityType, activity.Description)
+}
+
+// careScheduleFor returns the crop's care schedule, or one scaled to its growth time if it has none.
+func careScheduleFor(crop *models.Crop) []models.CareSchedule {
+	if len(crop.CareSchedule) > 0 {
+		return crop.CareSchedule
+	}
+
+	growth := math.Max(1, float64(crop.GrowthTimeHours))
+
+	return []models.CareSchedule{
+		{
+			Action:         utils.CareWater,
+			IntervalHours:  growth / 6,
+			CooldownHours:  growth / 12,
+			CostPerUnit:    crop.CostPerUnit * 0.02,
+			QualityBonus:   0.02,
+			QualityPenalty: 0.05,
+		},
+		{
+			Action:         utils.CareWeed,
+			IntervalHours:  growth / 4,
+			CooldownHours:  growth / 8,
+			CostPerUnit:    crop.CostPerUnit * 0.05,
+			QualityBonus:   0.03,
+			QualityPenalty: 0.04,
+		},
+		{
+			Action:           utils.CareFertilize,
+			IntervalHours:    growth / 2,
+			CooldownHours:    growth / 4,
+			CostPerUnit:      crop.CostPerUnit * 0.1,
+			QualityBonus:     0.05,
+			QualityPenalty:   0.02,
+			GrowthBoostHours: growth / 20,
+		},
+	}
+}
+
+func findCareSchedule(crop *models.Crop, action string) *models.CareSchedule {
+	for _, schedule := range careScheduleFor(crop) {
+		if schedule.Action == action {
+			return &schedule
+		}
+	}
+
+	return nil
+}
+
+// newCareStatuses opens the first window of every care action at planting time.
+func newCareStatuses(crop *models.Crop, plantedAt time.Time) map[string]models.CareStatus {
+	statuses := make(map[string]models.CareStatus)
+
+	for _, schedule := range careScheduleFor(crop) {
+		statuses[schedule.Action] = models.CareStatus{NextDueAt: plantedAt.Add(hours(schedule.IntervalHours))}
+	}
+
+	return statuses
+}
+
+// toCareSchedule validates a crop's requested care schedule.
+func toCareSchedule(schedule []types.CareSchedule) ([]models.CareSchedule, error) {
+	seen := make(map[string]bool)
+	result := make([]models.CareSchedule, 0, len(schedule))
+
+	for _, entry := range schedule {
+		action := strings.ToUpper(entry.Action)
+
+		switch action {
+		case utils.CareWater, utils.CareFertilize, utils.CareWeed:
+		default:
+			return nil, NewServiceError(http.StatusBadRequest, "unknown care action %q", entry.Action)
+		}
+
+		if seen[action] {
+			return nil, NewServiceError(http.StatusBadRequest, "care action %s is scheduled twice", action)
+		}
+		seen[action] = true
+
+		if entry.IntervalHours <= 0 {
+			return nil, NewServiceError(http.StatusBadRequest, "interval_hours of %s must be positive", action)
+		}
+
+		if entry.CooldownHours < 0 || entry.CostPerUnit < 0 || entry.QualityBonus < 0 || entry.QualityPenalty < 0 || entry.GrowthBoostHours < 0 {
+			return nil, NewServiceError(http.StatusBadRequest, "care schedule of %s cannot be negative", action)
+		}
+
+		result = append(result, models.CareSchedule{
+			Action:           action,
+			IntervalHours:    entry.IntervalHours,
+			CooldownHours:    entry.CooldownHours,
+			CostPerUnit:      entry.CostPerUnit,
+			QualityBonus:     entry.QualityBonus,
+			QualityPenalty:   entry.QualityPenalty,
+			GrowthBoostHours: entry.GrowthBoostHours,
+		})
+	}
+
+	return result, nil
+}
+
+// careQualityExpr adds delta to the stored care quality, treating plantings from before care as
+// 1.0, and keeps the result within MinCareQuality and MaxCareQuality.
+func careQualityExpr(delta float64) bson.M {
+	current := bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$quality_factor", 0}}, "$quality_factor", 1.0}}
+
+	return bson.M{"$min": bson.A{
+		utils.MaxCareQuality,
+		bson.M{"$max": bson.A{utils.MinCareQuality, bson.M{"$add": bson.A{current, delta}}}},
+	}}
+}
+
+// careQualityOf returns the care quality of a growing planting, never above utils.MaxCareQuality.
+func careQualityOf(planting *models.PlantedCrop) float64 {
+	if planting.QualityFactor <= 0 {
+		return 1.0
+	}
+
+	return math.Min(utils.MaxCareQuality, planting.QualityFactor)
+}
+
+func careVerb(action string) string {
+	switch action {
+	case utils.CareWater:
+		return "Watered"
+	case utils.CareFertilize:
+		return "Fertilized"
+	case utils.CareWeed:
+		return "Weeded"
+	}
+
+	return action
+}
+
+func hours(h float64) time.Duration {
+	return time.Duration(h * float64(time.Hour))
+}
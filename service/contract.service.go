@@ -0,0 +1,464 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/contracts"
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ContractService struct {
+	Client *mongo.Database
+
+	engine           *contracts.Engine
+	cropService      *CropService
+	walletService    *WalletService
+	warehouseService *WarehouseService
+	eventService     *EventService
+}
+
+func NewContractService(client *mongo.Database) *ContractService {
+	return &ContractService{
+		Client:           client,
+		engine:           contracts.NewEngine(contracts.ConfigFromEnv()),
+		cropService:      NewCropService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+		eventService:     NewEventService(client),
+	}
+}
+
+// PostContracts puts the current round's contracts on the board, unless they are already there.
+func (dc *ContractService) PostContracts(ctx context.Context) error {
+	now := time.Now()
+	round := dc.engine.Round(now)
+
+	collection := dc.Client.Collection(utils.ContractsCollection)
+
+	posted, err := collection.CountDocuments(ctx, bson.M{"round": round})
+	if err != nil || posted > 0 {
+		return err
+	}
+
+	markets, err := dc.markets(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range dc.engine.Generate(round, markets) {
+		contract.BaseModel = models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		}
+
+		// Keyed on round and slot, which are unique, so a second server or tick posting the same
+		// round adds nothing; losing the race for a slot is a duplicate key error.
+		_, err := collection.UpdateOne(
+			ctx,
+			bson.M{"round": contract.Round, "slot": contract.Slot},
+			bson.M{"$setOnInsert": contract},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// markets reads the current standard grade price and market factors of every active crop. Crops
+// nobody has traded yet sell at their base price with neutral factors.
+func (dc *ContractService) markets(ctx context.Context, now time.Time) ([]contracts.Market, error) {
+	crops, err := dc.cropService.GetAllCrops(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := dc.Client.Collection(utils.MarketPricesCollection).Find(ctx, bson.M{
+		"grade":       utils.GradeB,
+		"is_active":   true,
+		"valid_until": bson.M{"$gt": now},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var prices []models.MarketPrice
+	if err := cursor.All(ctx, &prices); err != nil {
+		return nil, err
+	}
+
+	byCrop := make(map[primitive.ObjectID]models.MarketPrice, len(prices))
+	for _, price := range prices {
+		byCrop[price.CropID] = price
+	}
+
+	markets := make([]contracts.Market, 0, len(crops))
+	for _, crop := range crops {
+		market := contracts.Market{Crop: crop, Price: crop.BasePrice, Demand: 1, Supply: 1}
+
+		if price, ok := byCrop[crop.ID]; ok {
+			market.Price, market.Demand, market.Supply = price.CurrentPrice, price.DemandFactor, price.SupplyFactor
+		}
+
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+// GetBoard returns the contracts open for acceptance, best paid first.
+func (dc *ContractService) GetBoard(ctx context.Context) ([]models.DeliveryContract, error) {
+	return dc.find(ctx, bson.M{
+		"status":           utils.ContractOpen,
+		"offer_expires_at": bson.M{"$gt": time.Now()},
+	}, bson.D{{Key: "reward", Value: -1}})
+}
+
+// GetUserContracts returns the contracts the user accepted, newest first.
+func (dc *ContractService) GetUserContracts(ctx context.Context, userId primitive.ObjectID) ([]models.DeliveryContract, error) {
+	return dc.find(ctx, bson.M{"accepted_by": userId}, bson.D{{Key: "accepted_at", Value: -1}})
+}
+
+// Accept takes an open contract for the user, holding its penalty from their wallet as collateral.
+func (dc *ContractService) Accept(ctx context.Context, userId primitive.ObjectID, contractId primitive.ObjectID) (*models.DeliveryContract, error) {
+	contract, err := dc.getContract(ctx, contractId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if contract.Status != utils.ContractOpen || !contract.OfferExpiresAt.After(now) {
+		return nil, NewServiceError(http.StatusConflict, "Contract is no longer open")
+	}
+
+	var hold *models.WalletHold
+	if contract.Penalty > 0 {
+		hold, err = dc.walletService.Hold(ctx, userId, contract.Penalty, "CONTRACT_COLLATERAL", contract.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	set := bson.M{
+		"status":      utils.ContractAccepted,
+		"accepted_by": userId,
+		"accepted_at": now,
+		"due_at":      now.Add(time.Duration(contract.DeliveryHours) * time.Hour),
+		"updated_at":  now,
+	}
+	if hold != nil {
+		set["hold_id"] = hold.ID
+	}
+
+	var accepted models.DeliveryContract
+
+	err = dc.Client.Collection(utils.ContractsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": contract.ID, "status": utils.ContractOpen, "offer_expires_at": bson.M{"$gt": now}},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&accepted)
+
+	if err != nil {
+		if hold != nil {
+			if releaseErr := dc.walletService.ReleaseHold(ctx, hold.ID); releaseErr != nil {
+				fmt.Printf("Error releasing contract collateral %s: %v\n", hold.ID.Hex(), releaseErr)
+			}
+		}
+
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusConflict, "Contract was taken, try another")
+		}
+		return nil, err
+	}
+
+	return &accepted, nil
+}
+
+// Fulfil delivers an accepted contract from the user's warehouse, using the oldest stacks of at
+// least the minimum quality. The reward is paid and the collateral returned. The contract is
+// claimed as DELIVERING with its stock reserved before anything moves, so a delivery that is
+// interrupted after that is finished by the contract worker.
+func (dc *ContractService) Fulfil(ctx context.Context, userId primitive.ObjectID, contractId primitive.ObjectID) (*models.DeliveryContract, error) {
+	contract, err := dc.getContract(ctx, contractId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if contract.Status != utils.ContractAccepted || contract.AcceptedBy != userId {
+		return nil, NewServiceError(http.StatusNotFound, "Accepted contract not found")
+	}
+
+	if !contract.DueAt.After(now) {
+		return nil, NewServiceError(http.StatusConflict, "Contract is past its delivery deadline")
+	}
+
+	reservation, err := dc.warehouseService.ReserveQualityStock(ctx, userId, contract.CropID, contract.MinQuality, contract.Quantity, "CONTRACT", contract.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	var delivering models.DeliveryContract
+
+	err = dc.Client.Collection(utils.ContractsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": contract.ID, "status": utils.ContractAccepted, "accepted_by": userId, "due_at": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{
+			"status":         utils.ContractDelivering,
+			"reservation_id": reservation.ID,
+			"quality_factor": averageQuality(reservation.Lots),
+			"updated_at":     now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&delivering)
+
+	if err != nil {
+		if releaseErr := dc.warehouseService.ReleaseReservation(ctx, reservation.ID); releaseErr != nil {
+			fmt.Printf("Error returning stock of contract %s: %v\n", contract.ID.Hex(), releaseErr)
+		}
+
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusConflict, "Contract changed, try again")
+		}
+		return nil, err
+	}
+
+	if err := dc.deliver(ctx, &delivering); err != nil {
+		return nil, err
+	}
+
+	return &delivering, nil
+}
+
+// deliver finishes a DELIVERING contract: the reserved stock is taken, then the reward paid and
+// the collateral returned. Consuming a reservation or releasing a hold twice does nothing and the
+// reward is credited once per contract, so SettleContracts finishes a delivery a crash interrupted.
+func (dc *ContractService) deliver(ctx context.Context, contract *models.DeliveryContract) error {
+	if _, err := dc.warehouseService.ConsumeReservation(ctx, contract.ReservationID); err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("Delivered %d units on contract", contract.Quantity)
+
+	if err := dc.walletService.CreditOnce(ctx, contract.AcceptedBy, contract.Reward, "CONTRACT_REWARD", description, contract.ID.Hex()); err != nil {
+		return err
+	}
+
+	if !contract.HoldID.IsZero() {
+		if err := dc.walletService.ReleaseHold(ctx, contract.HoldID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+
+	_, err := dc.Client.Collection(utils.ContractsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": contract.ID, "status": utils.ContractDelivering},
+		bson.M{"$set": bson.M{"status": utils.ContractFulfilled, "closed_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	contract.Status = utils.ContractFulfilled
+	contract.ClosedAt = now
+
+	return nil
+}
+
+// Abandon gives up an accepted contract, failing it at once and forfeiting the collateral.
+func (dc *ContractService) Abandon(ctx context.Context, userId primitive.ObjectID, contractId primitive.ObjectID) (*models.DeliveryContract, error) {
+	contract, err := dc.fail(ctx, bson.M{"_id": contractId, "status": utils.ContractAccepted, "accepted_by": userId}, "ABANDONED")
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Accepted contract not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The contract worker collects the collateral if this fails.
+	if err := dc.forfeit(ctx, contract); err != nil {
+		fmt.Printf("Error forfeiting collateral of contract %s: %v\n", contract.ID.Hex(), err)
+	}
+
+	return contract, nil
+}
+
+// SettleContracts takes offers nobody accepted off the board, finishes deliveries and forfeits a
+// crash interrupted, and fails accepted contracts that were not delivered in time, forfeiting their
+// collateral.
+func (dc *ContractService) SettleContracts(ctx context.Context) error {
+	now := time.Now()
+	collection := dc.Client.Collection(utils.ContractsCollection)
+
+	_, err := collection.UpdateMany(
+		ctx,
+		bson.M{"status": utils.ContractOpen, "offer_expires_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"status": utils.ContractExpired, "closed_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	delivering, err := dc.find(ctx, bson.M{"status": utils.ContractDelivering}, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range delivering {
+		if err := dc.deliver(ctx, &delivering[i]); err != nil {
+			fmt.Printf("Error delivering contract %s: %v\n", delivering[i].ID.Hex(), err)
+		}
+	}
+
+	forfeiting, err := dc.find(ctx, bson.M{"status": utils.ContractFailed, "forfeited": false}, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range forfeiting {
+		if err := dc.forfeit(ctx, &forfeiting[i]); err != nil {
+			fmt.Printf("Error forfeiting collateral of contract %s: %v\n", forfeiting[i].ID.Hex(), err)
+		}
+	}
+
+	overdue, err := dc.find(ctx, bson.M{"status": utils.ContractAccepted, "due_at": bson.M{"$lte": now}}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range overdue {
+		failed, err := dc.fail(ctx, bson.M{"_id": contract.ID, "status": utils.ContractAccepted}, "OVERDUE")
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error failing contract %s: %v\n", contract.ID.Hex(), err)
+			continue
+		}
+
+		if err := dc.forfeit(ctx, failed); err != nil {
+			fmt.Printf("Error forfeiting collateral of contract %s: %v\n", failed.ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// fail marks the accepted contract matching filter FAILED for reason and returns it. The contract
+// stays unforfeited until forfeit has collected its collateral.
+func (dc *ContractService) fail(ctx context.Context, filter bson.M, reason string) (*models.DeliveryContract, error) {
+	var contract models.DeliveryContract
+
+	now := time.Now()
+
+	err := dc.Client.Collection(utils.ContractsCollection).FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{
+			"status":     utils.ContractFailed,
+			"reason":     reason,
+			"forfeited":  false,
+			"closed_at":  now,
+			"updated_at": now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+// forfeit captures a failed contract's collateral hold, pays the penalty to the system wallet once
+// under the contract id and then marks the contract forfeited. The player is told by the call that
+// sets the mark, so a forfeit the worker retries is announced once.
+func (dc *ContractService) forfeit(ctx context.Context, contract *models.DeliveryContract) error {
+	if !contract.HoldID.IsZero() {
+		description := fmt.Sprintf("Penalty for failing a contract of %d units", contract.Quantity)
+
+		if err := dc.walletService.CaptureHold(ctx, contract.HoldID, "CONTRACT_PENALTY", description); err != nil {
+			return err
+		}
+
+		if err := dc.walletService.CreditSystemOnce(ctx, contract.Penalty, "CONTRACT_PENALTY", description, contract.ID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	result, err := dc.Client.Collection(utils.ContractsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": contract.ID, "status": utils.ContractFailed, "forfeited": false},
+		bson.M{"$set": bson.M{"forfeited": true, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	contract.Forfeited = true
+
+	if result.ModifiedCount == 0 {
+		return nil
+	}
+
+	dc.eventService.PublishQuietly(ctx, contract.AcceptedBy, utils.EventContractFailed, types.ContractPayload{
+		ContractID: contract.ID.Hex(),
+		CropID:     contract.CropID.Hex(),
+		Quantity:   contract.Quantity,
+		Penalty:    contract.Penalty,
+		Reason:     contract.Reason,
+	})
+
+	return nil
+}
+
+func (dc *ContractService) find(ctx context.Context, filter bson.M, sort bson.D) ([]models.DeliveryContract, error) {
+	opts := options.Find()
+	if sort != nil {
+		opts.SetSort(sort)
+	}
+
+	cursor, err := dc.Client.Collection(utils.ContractsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	found := []models.DeliveryContract{}
+	err = cursor.All(ctx, &found)
+
+	return found, err
+}
+
+func (dc *ContractService) getContract(ctx context.Context, contractId primitive.ObjectID) (*models.DeliveryContract, error) {
+	var contract models.DeliveryContract
+
+	err := dc.Client.Collection(utils.ContractsCollection).FindOne(ctx, bson.M{"_id": contractId}).Decode(&contract)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Contract not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
@@ -4,25 +4,30 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"time"
 
 	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
 	"github.com/hrutik1235/farming-server/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type CropService struct {
 	Client *mongo.Database
 
 	userService *UserService
+	soilService *SoilService
 }
 
 func NewCropService(client *mongo.Database) *CropService {
 	return &CropService{
 		Client:      client,
 		userService: NewUserService(client),
+		soilService: NewSoilService(client),
 	}
 }
 
@@ -32,7 +37,7 @@ func (c *CropService) GetCropById(cropId primitive.ObjectID) (*models.Crop, erro
 
 	var crop models.Crop
 
-	err := c.Client.Collection(utils.CropsCollection).FindOne(ctx, bson.M{"id": cropId}).Decode(&crop)
+	err := c.Client.Collection(utils.CropsCollection).FindOne(ctx, bson.M{"_id": cropId}).Decode(&crop)
 
 	if err != nil {
 		return nil, err
@@ -77,6 +82,328 @@ func (c *CropService) GetPlantedCrops(userId primitive.ObjectID, plantingId prim
 	return plantedCrops, nil
 }
 
+// CreateCrop stores a new crop definition, refusing duplicate names.
+func (cs *CropService) CreateCrop(ctx context.Context, body types.CreateCrop) (*models.Crop, error) {
+	existingCrop, _ := cs.GetCropByName(body.Name)
+
+	if existingCrop.Name == body.Name {
+		return nil, NewServiceError(http.StatusBadRequest, "Crop already exists")
+	}
+
+	careSchedule, err := toCareSchedule(body.CareSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateClimate(body.PreferredSeasons, body.WeatherSensitivity); err != nil {
+		return nil, err
+	}
+
+	if err := validateSusceptibility(body.OutbreakSusceptibility); err != nil {
+		return nil, err
+	}
+
+	if err := validateSoilImpact(body.SoilImpact); err != nil {
+		return nil, err
+	}
+
+	if err := validateGradeThresholds(body.GradeThresholds); err != nil {
+		return nil, err
+	}
+
+	companions, antagonists, err := toCropPairs(body.Companions, body.Antagonists)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	crop := models.Crop{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Name:            body.Name,
+		Description:     body.Description,
+		BasePrice:       body.BasePrice,
+		GrowthTimeHours: body.GrowthTimeHours,
+		YieldPerUnit:    body.YieldPerUnit,
+		CostPerUnit:     body.CostPerUnit,
+		IsActive:        true,
+		CareSchedule:    careSchedule,
+
+		PreferredSeasons:   body.PreferredSeasons,
+		WeatherSensitivity: body.WeatherSensitivity,
+
+		OutbreakSusceptibility: body.OutbreakSusceptibility,
+		SoilImpact:             body.SoilImpact,
+
+		Companions:  companions,
+		Antagonists: antagonists,
+
+		GradeThresholds: body.GradeThresholds,
+	}
+
+	if _, err := cs.Client.Collection(utils.CropsCollection).InsertOne(ctx, crop); err != nil {
+		return nil, err
+	}
+
+	return &crop, nil
+}
+
+// PlantCrop plants the crop on the requested number of the user's free land units, paying with
+// starter seeds first and the wallet for the rest.
+func (cs *CropService) PlantCrop(ctx context.Context, userId primitive.ObjectID, cropId primitive.ObjectID, units int) (*models.PlantedCrop, error) {
+	return cs.PlantCropOn(ctx, userId, cropId, types.PlantCrop{LandUnits: units})
+}
+
+// PlantCropOn plants the crop on the land units the selection picks. The units are claimed before
+// anything is paid; if the planting then cannot be created, the units are freed and the seeds and
+// coins given back.
+func (cs *CropService) PlantCropOn(ctx context.Context, userId primitive.ObjectID, cropId primitive.ObjectID, selection types.PlantCrop) (*models.PlantedCrop, error) {
+	landUnits, err := cs.SelectLandUnits(ctx, userId, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	units := len(landUnits)
+
+	crop, err := cs.GetCropById(cropId)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusNotFound, "Crop not found")
+		}
+		return nil, err
+	}
+
+	wallet, err := cs.userService.GetUserWallet(userId)
+	if err != nil {
+		return nil, NewServiceError(http.StatusInternalServerError, "Wallet Error")
+	}
+
+	seedCount, err := cs.GetSeedCount(ctx, userId, crop.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	seedUnits := min(seedCount, units)
+
+	if wallet.Balance < crop.CostPerUnit*float64(units-seedUnits) {
+		return nil, NewServiceError(http.StatusBadRequest, "Not enough balance")
+	}
+
+	landUnitIds := cs.GetLandUnitIDs(landUnits)
+
+	if err := cs.MarkLandUnitsOccupied(ctx, landUnitIds); err != nil {
+		return nil, err
+	}
+
+	// Seeds spent elsewhere since they were counted cover fewer units, and the wallet pays for
+	// every unit the seeds actually used do not cover.
+	seedsUsed := 0
+	totalCost := 0.0
+	soilChanged := false
+
+	// undo gives back everything taken for a planting that does not go ahead.
+	undo := func() {
+		if soilChanged {
+			if err := cs.soilService.RestoreSoil(ctx, landUnits); err != nil {
+				fmt.Printf("Error restoring soil of land units %v: %v\n", landUnitIds, err)
+			}
+		}
+
+		cs.returnSeeds(ctx, userId, crop.ID, seedsUsed)
+
+		if totalCost > 0 {
+			if err := cs.userService.RefundPlantingCost(userId, totalCost); err != nil {
+				fmt.Printf("Error refunding %.2f planting cost to %s: %v\n", totalCost, userId.Hex(), err)
+			}
+		}
+
+		cs.releaseLandUnits(ctx, landUnitIds)
+	}
+
+	if seedUnits > 0 {
+		if seedsUsed, err = cs.ConsumeSeeds(ctx, userId, crop.ID, seedUnits); err != nil {
+			undo()
+			return nil, err
+		}
+	}
+
+	if cost := crop.CostPerUnit * float64(units-seedsUsed); cost > 0 {
+		if err := cs.userService.DeductPlantingCost(userId, cost); err != nil {
+			undo()
+			return nil, err
+		}
+		totalCost = cost
+	}
+
+	// A failed soil write may still have changed some of the units, so their soil is restored too.
+	soilChanged = true
+
+	soilFactor, err := cs.soilService.PlantOn(ctx, landUnits, crop)
+	if err != nil {
+		undo()
+		return nil, err
+	}
+
+	planting, err := cs.CreatePlantedCrop(ctx, userId, crop, landUnitIds, units, totalCost, soilFactor)
+	if err != nil {
+		undo()
+		return nil, err
+	}
+
+	recordLeaseActivity(ctx, cs.Client, planting, "PLANT", fmt.Sprintf("Planted %s on %d land units", crop.Name, units))
+
+	return planting, nil
+}
+
+// SelectLandUnits resolves exactly one of a count of free units, explicit land unit ids or a
+// region of the user's land grid into land units the user may plant on right now.
+func (cs *CropService) SelectLandUnits(ctx context.Context, userId primitive.ObjectID, selection types.PlantCrop) ([]models.LandUnit, error) {
+	selectors := 0
+	for _, set := range []bool{selection.LandUnits != 0, len(selection.LandUnitIDs) > 0, selection.Region != nil} {
+		if set {
+			selectors++
+		}
+	}
+
+	if selectors != 1 {
+		return nil, NewServiceError(http.StatusBadRequest, "give exactly one of land_units, land_unit_ids or region")
+	}
+
+	switch {
+	case selection.LandUnits != 0:
+		if selection.LandUnits < 0 {
+			return nil, NewServiceError(http.StatusBadRequest, "land_units must be positive")
+		}
+
+		availableLand, err := cs.GetAvailableLandUnits(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(availableLand) < selection.LandUnits {
+			return nil, NewServiceError(http.StatusBadRequest, "Not enough land units")
+		}
+
+		return availableLand[:selection.LandUnits], nil
+
+	case len(selection.LandUnitIDs) > 0:
+		landUnits, err := cs.findLandUnitsById(ctx, selection.LandUnitIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		return landUnits, checkPlantable(userId, landUnits)
+
+	default:
+		return cs.selectLandRegion(ctx, userId, *selection.Region)
+	}
+}
+
+// findLandUnitsById loads every one of the listed land units, failing if any is missing or listed twice.
+func (cs *CropService) findLandUnitsById(ctx context.Context, landUnitIds []string) ([]models.LandUnit, error) {
+	seen := make(map[string]bool, len(landUnitIds))
+	for _, id := range landUnitIds {
+		if seen[id] {
+			return nil, NewServiceError(http.StatusBadRequest, "land unit %s is listed twice", id)
+		}
+		seen[id] = true
+	}
+
+	objectIds, err := utils.ConvertObjectIdsFromStringIds(landUnitIds)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, "invalid land unit id")
+	}
+
+	landUnits, err := cs.findLandUnits(ctx, bson.M{"_id": bson.M{"$in": objectIds}})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(landUnits) != len(objectIds) {
+		return nil, NewServiceError(http.StatusNotFound, "Land unit not found")
+	}
+
+	return landUnits, nil
+}
+
+func (cs *CropService) selectLandRegion(ctx context.Context, userId primitive.ObjectID, region types.LandRegion) ([]models.LandUnit, error) {
+	if region.X < 0 || region.Y < 0 || region.Width <= 0 || region.Height <= 0 || region.X+region.Width > utils.LandGridWidth {
+		return nil, NewServiceError(http.StatusBadRequest, "region must lie within the %d column land grid", utils.LandGridWidth)
+	}
+
+	positions := make([]int, 0, region.Width*region.Height)
+	for y := region.Y; y < region.Y+region.Height; y++ {
+		for x := region.X; x < region.X+region.Width; x++ {
+			positions = append(positions, GridPosition(x, y))
+		}
+	}
+
+	// Positions are counted on one owner's grid: the user's own, or a landowner's whose units are
+	// leased to the user. checkPlantable then keeps only the units the user may farm.
+	ownerId := userId
+	if region.OwnerID != "" {
+		var err error
+		if ownerId, err = primitive.ObjectIDFromHex(region.OwnerID); err != nil {
+			return nil, NewServiceError(http.StatusBadRequest, "invalid owner_id")
+		}
+	}
+
+	landUnits, err := cs.findLandUnits(ctx, bson.M{"owner_id": ownerId, "position": bson.M{"$in": positions}})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(landUnits) != len(positions) {
+		if ownerId == userId {
+			return nil, NewServiceError(http.StatusBadRequest, "region includes land you do not own")
+		}
+		return nil, NewServiceError(http.StatusBadRequest, "region includes land the owner does not have")
+	}
+
+	return landUnits, checkPlantable(userId, landUnits)
+}
+
+func (cs *CropService) findLandUnits(ctx context.Context, filter bson.M) ([]models.LandUnit, error) {
+	cursor, err := cs.Client.Collection(utils.LandUnitsCollection).Find(ctx, filter, options.Find().SetSort(bson.M{"position": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var landUnits []models.LandUnit
+	err = cursor.All(ctx, &landUnits)
+
+	return landUnits, err
+}
+
+// checkPlantable fails unless every unit is free and the user's to farm: owned and not leased
+// out, or leased to the user.
+func checkPlantable(userId primitive.ObjectID, landUnits []models.LandUnit) error {
+	for _, unit := range landUnits {
+		if !canFarm(userId, unit) {
+			return NewServiceError(http.StatusForbidden, "land unit %s is not yours to farm", unit.ID.Hex())
+		}
+
+		if !unit.IsAvailable {
+			return NewServiceError(http.StatusConflict, "land unit %s is occupied", unit.ID.Hex())
+		}
+	}
+
+	return nil
+}
+
+func canFarm(userId primitive.ObjectID, unit models.LandUnit) bool {
+	if unit.IsLeased {
+		return unit.LesseeID == userId
+	}
+
+	return unit.OwnerID == userId
+}
+
 func (cs *CropService) GetAllCrops(ctx context.Context) ([]models.Crop, error) {
 	collection := cs.Client.Collection(utils.CropsCollection)
 
@@ -96,8 +423,19 @@ func (cs *CropService) GetAvailableLandUnits(ctx context.Context, userId primiti
 
 	fmt.Println("USER ID: ", userId)
 
+	// Own land that is not leased out, and land leased to the user.
 	var landUnits []models.LandUnit
-	cursor, err := collection.Find(ctx, bson.M{"owner_id": userId, "is_available": true})
+	cursor, err := collection.Find(
+		ctx,
+		bson.M{
+			"is_available": true,
+			"$or": []bson.M{
+				{"owner_id": userId, "is_leased": bson.M{"$ne": true}},
+				{"lessee_id": userId, "is_leased": true},
+			},
+		},
+		options.Find().SetSort(bson.M{"position": 1}),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -117,24 +455,64 @@ func (p *CropService) GetLandUnitIDs(landUnits []models.LandUnit) []string {
 	return ids
 }
 
+// MarkLandUnitsOccupied claims the land units, failing with a conflict if any of them was taken
+// meanwhile; the ones already claimed are freed again.
 func (p *CropService) MarkLandUnitsOccupied(ctx context.Context, landUnitIDs []string) error {
 	collection := p.Client.Collection(utils.LandUnitsCollection)
 
-	_, err := collection.UpdateMany(
-		ctx,
-		bson.M{"land_unit_id": bson.M{"$in": landUnitIDs}},
-		bson.M{
-			"$set": bson.M{
-				"is_available": false,
-				"updated_at":   time.Now(),
+	landObjectIds, err := utils.ConvertObjectIdsFromStringIds(landUnitIDs)
+	if err != nil {
+		return err
+	}
+
+	claimed := make([]string, 0, len(landObjectIds))
+
+	for _, id := range landObjectIds {
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"_id": id, "is_available": true},
+			bson.M{
+				"$set": bson.M{
+					"is_available": false,
+					"updated_at":   time.Now(),
+				},
 			},
-		},
-	)
+		)
 
-	return err
+		if err == nil && result.ModifiedCount == 0 {
+			err = NewServiceError(http.StatusConflict, "land unit %s is no longer free", id.Hex())
+		}
+
+		if err != nil {
+			p.releaseLandUnits(ctx, claimed)
+			return err
+		}
+
+		claimed = append(claimed, id.Hex())
+	}
+
+	return nil
+}
+
+func (p *CropService) releaseLandUnits(ctx context.Context, landUnitIDs []string) {
+	if len(landUnitIDs) == 0 {
+		return
+	}
+
+	landObjectIds, _ := utils.ConvertObjectIdsFromStringIds(landUnitIDs)
+
+	_, err := p.Client.Collection(utils.LandUnitsCollection).UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": landObjectIds}},
+		bson.M{"$set": bson.M{"is_available": true, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		fmt.Printf("Error freeing land units %v: %v\n", landUnitIDs, err)
+	}
 }
 
-func (p *CropService) CreatePlantedCrop(ctx context.Context, userID primitive.ObjectID, crop *models.Crop, landUnitIDs []string, landUnits int, totalCost float64) (*models.PlantedCrop, error) {
+// CreatePlantedCrop stores a new planting whose expected yield is scaled by soilFactor.
+func (p *CropService) CreatePlantedCrop(ctx context.Context, userID primitive.ObjectID, crop *models.Crop, landUnitIDs []string, landUnits int, totalCost float64, soilFactor float64) (*models.PlantedCrop, error) {
 	collection := p.Client.Collection(utils.PlantedCropsCollection)
 
 	plantedAt := time.Now()
@@ -155,8 +533,11 @@ func (p *CropService) CreatePlantedCrop(ctx context.Context, userID primitive.Ob
 		ExpectedHarvestAt: expectedHarvest,
 		GrowthPercentage:  0.0,
 		IsHarvested:       false,
+		QualityFactor:     1.0,
 		TotalCost:         totalCost,
-		ExpectedYield:     crop.YieldPerUnit * landUnits,
+		ExpectedYield:     int(math.Round(float64(crop.YieldPerUnit*landUnits) * soilFactor)),
+		SoilFactor:        soilFactor,
+		Care:              newCareStatuses(crop, plantedAt),
 	}
 
 	_, err := collection.InsertOne(ctx, plantedCrop)
@@ -186,28 +567,41 @@ func (p *CropService) GetUserPlantedCrops(ctx context.Context, userID primitive.
 	return plantedCrops, err
 }
 
+// CalculateCurrentGrowth interpolates growth linearly up to ExpectedHarvestAt, starting from the
+// planting time or, once weather changed the growth speed, from the growth reached at that moment.
 func (p *CropService) CalculateCurrentGrowth(crop models.PlantedCrop) float64 {
 	now := time.Now()
-	totalDuration := crop.ExpectedHarvestAt.Sub(crop.PlantedAt)
-	elapsedDuration := now.Sub(crop.PlantedAt)
+	start, base := crop.PlantedAt, 0.0
+
+	if !crop.GrowthBaseAt.IsZero() {
+		start, base = crop.GrowthBaseAt, crop.GrowthBase
+	}
+
+	totalDuration := crop.ExpectedHarvestAt.Sub(start)
+	elapsedDuration := now.Sub(start)
 
 	if elapsedDuration <= 0 {
-		return 0.0
+		return base
 	}
 
 	if elapsedDuration >= totalDuration {
 		return 1.0
 	}
 
-	return math.Max(0.0, math.Min(1.0, float64(elapsedDuration)/float64(totalDuration)))
+	return math.Max(0.0, math.Min(1.0, base+(1-base)*float64(elapsedDuration)/float64(totalDuration)))
 }
 
 func (p *CropService) UpdateCropGrowthInDB(ctx context.Context, plantingID string, growthPercentage float64) error {
 	collection := p.Client.Collection(utils.PlantedCropsCollection)
 
-	_, err := collection.UpdateOne(
+	plantingObjectID, err := primitive.ObjectIDFromHex(plantingID)
+	if err != nil {
+		return NewServiceError(http.StatusBadRequest, "invalid planting id")
+	}
+
+	_, err = collection.UpdateOne(
 		ctx,
-		bson.M{"planting_id": plantingID},
+		bson.M{"_id": plantingObjectID},
 		bson.M{"$set": bson.M{
 			"growth_percentage": growthPercentage,
 			"updated_at":        time.Now(),
@@ -220,3 +614,192 @@ func (p *CropService) UpdateCropGrowthInDB(ctx context.Context, plantingID strin
 
 	return nil
 }
+
+// UpdateGrowthBatch stores the growth of many plantings in one round trip.
+func (p *CropService) UpdateGrowthBatch(ctx context.Context, growth map[primitive.ObjectID]float64) error {
+	if len(growth) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(growth))
+
+	for plantingID, growthPercentage := range growth {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": plantingID}).
+			SetUpdate(bson.M{"$set": bson.M{"growth_percentage": growthPercentage, "updated_at": now}}))
+	}
+
+	_, err := p.Client.Collection(utils.PlantedCropsCollection).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+
+	return err
+}
+
+// ClaimGrowthMilestone records that the planting reached milestone and returns the milestone it
+// had recorded before. claimed reports whether this call was the one that moved it, so each
+// milestone is announced once.
+func (p *CropService) ClaimGrowthMilestone(ctx context.Context, plantingID primitive.ObjectID, milestone int) (previous int, claimed bool, err error) {
+	var planting models.PlantedCrop
+
+	err = p.Client.Collection(utils.PlantedCropsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"_id": plantingID,
+			"$or": []bson.M{
+				{"growth_milestone": bson.M{"$lt": milestone}},
+				{"growth_milestone": bson.M{"$exists": false}},
+			},
+		},
+		bson.M{"$set": bson.M{
+			"growth_milestone": milestone,
+			"updated_at":       time.Now(),
+		}},
+		options.FindOneAndUpdate().SetProjection(bson.M{"growth_milestone": 1}),
+	).Decode(&planting)
+
+	if err == mongo.ErrNoDocuments {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return planting.GrowthMilestone, true, nil
+}
+
+// GetSeedCount returns how many seeds of the crop the user holds across their seed inventory.
+func (p *CropService) GetSeedCount(ctx context.Context, userID primitive.ObjectID, cropID primitive.ObjectID) (int, error) {
+	items, err := p.getSeedItems(ctx, userID, cropID)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, item := range items {
+		total += item.Quantity
+	}
+
+	return total, nil
+}
+
+// ConsumeSeeds takes up to units seeds of the crop from the user's inventory and returns how many were used.
+func (p *CropService) ConsumeSeeds(ctx context.Context, userID primitive.ObjectID, cropID primitive.ObjectID, units int) (int, error) {
+	items, err := p.getSeedItems(ctx, userID, cropID)
+	if err != nil {
+		return 0, err
+	}
+
+	collection := p.Client.Collection(utils.SeedInventoryCollection)
+	used := 0
+
+	for _, item := range items {
+		if used == units {
+			break
+		}
+
+		take := min(item.Quantity, units-used)
+
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"_id": item.ID, "quantity": bson.M{"$gte": take}},
+			bson.M{
+				"$inc": bson.M{"quantity": -take},
+				"$set": bson.M{"updated_at": time.Now()},
+			},
+		)
+		if err != nil {
+			return used, err
+		}
+
+		if result.ModifiedCount == 1 {
+			used += take
+		}
+	}
+
+	return used, nil
+}
+
+// returnSeeds gives back seeds consumed for a planting that did not go ahead.
+func (p *CropService) returnSeeds(ctx context.Context, userID primitive.ObjectID, cropID primitive.ObjectID, units int) {
+	if units <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	_, err := p.Client.Collection(utils.SeedInventoryCollection).UpdateOne(
+		ctx,
+		bson.M{"user_id": userID, "crop_id": cropID, "source": "REFUND"},
+		bson.M{
+			"$inc": bson.M{"quantity": units},
+			"$set": bson.M{"updated_at": now},
+			"$setOnInsert": bson.M{
+				"_id":        primitive.NewObjectID(),
+				"created_at": now,
+				"is_active":  true,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		fmt.Printf("Error returning %d seeds to %s: %v\n", units, userID.Hex(), err)
+	}
+}
+
+func (p *CropService) getSeedItems(ctx context.Context, userID primitive.ObjectID, cropID primitive.ObjectID) ([]models.SeedItem, error) {
+	cursor, err := p.Client.Collection(utils.SeedInventoryCollection).Find(ctx, bson.M{
+		"user_id":  userID,
+		"crop_id":  cropID,
+		"quantity": bson.M{"$gt": 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []models.SeedItem
+	err = cursor.All(ctx, &items)
+
+	return items, err
+}
+
+// GetGrowingPlantingsPage returns up to limit unharvested plantings with an id after afterID, in id order.
+func (p *CropService) GetGrowingPlantingsPage(ctx context.Context, afterID primitive.ObjectID, limit int64) ([]models.PlantedCrop, error) {
+	cursor, err := p.Client.Collection(utils.PlantedCropsCollection).Find(
+		ctx,
+		bson.M{
+			"_id":          bson.M{"$gt": afterID},
+			"is_active":    true,
+			"is_harvested": false,
+		},
+		options.Find().SetSort(bson.M{"_id": 1}).SetLimit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var plantedCrops []models.PlantedCrop
+	err = cursor.All(ctx, &plantedCrops)
+
+	return plantedCrops, err
+}
+
+// MarkHarvestReady stamps harvest_ready_at on the planting and reports whether this call was the one that set it.
+func (p *CropService) MarkHarvestReady(ctx context.Context, plantingID primitive.ObjectID) (bool, error) {
+	result, err := p.Client.Collection(utils.PlantedCropsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": plantingID, "harvest_ready_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{
+			"harvest_ready_at":  time.Now(),
+			"is_ready":          true,
+			"growth_percentage": 1.0,
+			"updated_at":        time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ServiceError is a failure the caller caused (bad input, missing record, ...) along with the HTTP
+// status it maps to, so the REST controllers and the gRPC handlers surface it the same way.
+type ServiceError struct {
+	Status  int
+	Message string
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
+
+func NewServiceError(status int, format string, args ...any) *ServiceError {
+	return &ServiceError{
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// ErrorStatus returns the HTTP status for err, defaulting to 500 for anything unexpected.
+func ErrorStatus(err error) int {
+	var serviceErr *ServiceError
+
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Status
+	}
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
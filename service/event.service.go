@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hrutik1235/farming-server/events"
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// committedEventsCounter holds the highest sequence below which every event is stored.
+	committedEventsCounter = "events_committed"
+	commitBatchSize        = 500
+
+	// eventGapTimeout is how long a taken sequence may stay missing before readers skip it, for
+	// when its publisher failed between taking the number and storing the event.
+	eventGapTimeout = 10 * time.Second
+
+	// commitPollInterval is how often the process committer looks for events stored by other
+	// servers and for gaps that have timed out; local publishes wake it straight away.
+	commitPollInterval = time.Second
+)
+
+type EventService struct {
+	Client    *mongo.Database
+	broker    *events.Broker
+	committer *eventCommitter
+}
+
+func NewEventService(client *mongo.Database) *EventService {
+	es := &EventService{
+		Client: client,
+		broker: events.Default,
+	}
+
+	defaultCommitterOnce.Do(func() {
+		defaultCommitter = newEventCommitter(es)
+		go defaultCommitter.run()
+	})
+	es.committer = defaultCommitter
+
+	return es
+}
+
+// Publish stores the event under the next sequence number, which clients use as their resume
+// cursor, and hands it to the process committer, which pushes it to live subscribers once every
+// event before it is stored. Publishers may store their events out of sequence order, so readers
+// only see events up to the committed sequence, below which none is missing.
+func (es *EventService) Publish(ctx context.Context, userId primitive.ObjectID, eventType string, payload any) (*models.Event, error) {
+	event, err := newEvent(userId, eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Sequence, err = es.nextSequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := es.Client.Collection(utils.EventsCollection).InsertOne(ctx, event); err != nil {
+		return nil, err
+	}
+
+	es.committer.add(*event)
+
+	return event, nil
+}
+
+// Broadcast pushes a transient event to live subscribers only. Use it for high-frequency
+// updates that a reconnecting client does not need replayed.
+func (es *EventService) Broadcast(userId primitive.ObjectID, eventType string, payload any) error {
+	event, err := newEvent(userId, eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	es.broker.Publish(*event)
+
+	return nil
+}
+
+// PublishQuietly is Publish for callers whose own work already succeeded and should not fail
+// because the notification could not be written.
+func (es *EventService) PublishQuietly(ctx context.Context, userId primitive.ObjectID, eventType string, payload any) {
+	if _, err := es.Publish(ctx, userId, eventType, payload); err != nil {
+		fmt.Printf("Error publishing %s event for %s: %v\n", eventType, userId.Hex(), err)
+	}
+}
+
+// GetEventsSince returns up to limit of the user's committed events after the given sequence,
+// oldest first, for readers catching up on what they missed while not subscribed.
+func (es *EventService) GetEventsSince(ctx context.Context, userId primitive.ObjectID, sequence int64, limit int64) ([]models.Event, error) {
+	committed, err := es.commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := es.Client.Collection(utils.EventsCollection).Find(
+		ctx,
+		bson.M{"user_id": userId, "sequence": bson.M{"$gt": sequence, "$lte": committed}},
+		options.Find().SetSort(bson.M{"sequence": 1}).SetLimit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.Event
+	err = cursor.All(ctx, &events)
+
+	return events, err
+}
+
+// GetFeedSince is GetEventsSince including the market-wide events published under events.Everyone.
+// It also returns the committed sequence it read up to, so a reader that got less than limit events
+// knows it has seen everything of its feed up to there.
+func (es *EventService) GetFeedSince(ctx context.Context, userId primitive.ObjectID, sequence int64, limit int64) ([]models.Event, int64, error) {
+	committed, err := es.commit(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := es.Client.Collection(utils.EventsCollection).Find(
+		ctx,
+		bson.M{
+			"user_id":  bson.M{"$in": []primitive.ObjectID{userId, events.Everyone}},
+			"sequence": bson.M{"$gt": sequence, "$lte": committed},
+		},
+		options.Find().SetSort(bson.M{"sequence": 1}).SetLimit(limit),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var feed []models.Event
+	err = cursor.All(ctx, &feed)
+
+	return feed, committed, err
+}
+
+// LatestSequence returns the highest committed event sequence, where a new reader starts. It is the
+// one this process has pushed to its subscribers, or the stored one before the committer has run.
+func (es *EventService) LatestSequence(ctx context.Context) (int64, error) {
+	if sequence := es.committer.latest(); sequence > 0 {
+		return sequence, nil
+	}
+
+	return es.commit(ctx)
+}
+
+// Subscribe registers a live subscription for the user's events on the process broker.
+func (es *EventService) Subscribe(userId primitive.ObjectID, buffer int) *events.Subscription {
+	return es.broker.Subscribe(userId, buffer)
+}
+
+// HasSubscribers reports whether anyone in this process is listening to the user's events.
+func (es *EventService) HasSubscribers(userId primitive.ObjectID) bool {
+	return es.broker.Subscribers(userId) > 0
+}
+
+func (es *EventService) nextSequence(ctx context.Context) (int64, error) {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+
+	err := es.Client.Collection(utils.CountersCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": utils.EventsCollection},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+
+	return counter.Seq, err
+}
+
+// commit moves the committed sequence over the stored events that follow it without a gap and
+// returns it. A gap older than eventGapTimeout is skipped. The move is conditional on the value
+// read, so servers committing at once never move it backwards.
+func (es *EventService) commit(ctx context.Context) (int64, error) {
+	counters := es.Client.Collection(utils.CountersCollection)
+
+	for {
+		var mark struct {
+			Seq int64 `bson:"seq"`
+		}
+
+		err := counters.FindOne(ctx, bson.M{"_id": committedEventsCounter}).Decode(&mark)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return 0, err
+		}
+
+		cursor, err := es.Client.Collection(utils.EventsCollection).Find(
+			ctx,
+			bson.M{"sequence": bson.M{"$gt": mark.Seq}},
+			options.Find().
+				SetSort(bson.M{"sequence": 1}).
+				SetLimit(commitBatchSize).
+				SetProjection(bson.M{"sequence": 1, "created_at": 1}),
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		var stored []models.Event
+		err = cursor.All(ctx, &stored)
+		cursor.Close(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		next := mark.Seq
+		for _, event := range stored {
+			// The missing events were numbered before this one, so they are at least as old.
+			if event.Sequence != next+1 && time.Since(event.CreatedAt) < eventGapTimeout {
+				break
+			}
+			next = event.Sequence
+		}
+
+		if next == mark.Seq {
+			return mark.Seq, nil
+		}
+
+		_, err = counters.UpdateOne(
+			ctx,
+			bson.M{"_id": committedEventsCounter, "seq": mark.Seq},
+			bson.M{"$set": bson.M{"seq": next}},
+			options.Update().SetUpsert(true),
+		)
+
+		// Another server moved the mark first; read it again.
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return 0, err
+		}
+	}
+}
+
+// eventCommitter is the process's one poll of the committed sequence. It keeps the events published
+// here until the committed sequence passes them, reads the ones other servers stored from the events
+// collection, and pushes both to the broker in sequence order, so live readers follow their feed from
+// memory instead of each polling the collection.
+type eventCommitter struct {
+	service *EventService
+	wake    chan struct{}
+
+	mu       sync.Mutex
+	primed   bool
+	sequence int64                  // highest sequence pushed to the broker
+	pending  map[int64]models.Event // published here and not committed yet
+}
+
+var (
+	defaultCommitter     *eventCommitter
+	defaultCommitterOnce sync.Once
+)
+
+func newEventCommitter(es *EventService) *eventCommitter {
+	return &eventCommitter{
+		service: es,
+		wake:    make(chan struct{}, 1),
+		pending: map[int64]models.Event{},
+	}
+}
+
+// add holds an event stored by this process until it is committed and wakes the committer.
+func (c *eventCommitter) add(event models.Event) {
+	c.mu.Lock()
+	if !c.primed || event.Sequence > c.sequence {
+		c.pending[event.Sequence] = event
+	}
+	c.mu.Unlock()
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// latest returns the highest sequence pushed to the broker, or 0 before the first poll.
+func (c *eventCommitter) latest() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sequence
+}
+
+func (c *eventCommitter) run() {
+	ticker := time.NewTicker(commitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.wake:
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), eventGapTimeout)
+		if err := c.advance(ctx); err != nil {
+			fmt.Printf("Error committing events: %v\n", err)
+		}
+		cancel()
+	}
+}
+
+// advance moves the committed sequence and pushes the events it passed. The first poll only finds
+// where the process starts; readers that need anything before it replay it from the collection.
+func (c *eventCommitter) advance(ctx context.Context) error {
+	committed, err := c.service.commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	after, primed := c.sequence, c.primed
+	var batch []models.Event
+	local := []int64{}
+	for sequence, event := range c.pending {
+		if sequence > after && sequence <= committed {
+			batch = append(batch, event)
+			local = append(local, sequence)
+		}
+	}
+	c.mu.Unlock()
+
+	if primed && committed > after {
+		// Every sequence in the range that was not published here was stored by another server,
+		// or was skipped after its gap timed out.
+		if int64(len(batch)) < committed-after {
+			remote, err := c.service.storedBetween(ctx, after, committed, local)
+			if err != nil {
+				return err
+			}
+			batch = append(batch, remote...)
+		}
+
+		sort.Slice(batch, func(i, j int) bool { return batch[i].Sequence < batch[j].Sequence })
+		c.service.broker.Commit(batch, after, committed)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.primed = true
+	c.sequence = max(c.sequence, committed)
+	for sequence := range c.pending {
+		if sequence <= c.sequence {
+			delete(c.pending, sequence)
+		}
+	}
+
+	return nil
+}
+
+// storedBetween reads the stored events after one sequence up to another, leaving out the given ones.
+func (es *EventService) storedBetween(ctx context.Context, after int64, through int64, except []int64) ([]models.Event, error) {
+	cursor, err := es.Client.Collection(utils.EventsCollection).Find(
+		ctx,
+		bson.M{"sequence": bson.M{"$gt": after, "$lte": through, "$nin": except}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stored []models.Event
+	err = cursor.All(ctx, &stored)
+
+	return stored, err
+}
+
+func newEvent(userId primitive.ObjectID, eventType string, payload any) (*models.Event, error) {
+	raw, err := bson.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return &models.Event{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		UserID:  userId,
+		Type:    eventType,
+		Payload: doc,
+	}, nil
+}
@@ -0,0 +1,1075 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	farmingv1 "github.com/hrutik1235/farming-server/proto/farming/v1"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// PeerDialOptions are used for every outgoing call to a peer server.
+var PeerDialOptions = []grpc.DialOption{
+	grpc.WithTransportCredentials(insecure.NewCredentials()),
+}
+
+// A trade still undecided after this long is given up on during recovery.
+const staleTradeAfter = 2 * time.Minute
+
+// An offer the remote user has not accepted after this long is aborted.
+const tradeOfferTTL = 24 * time.Hour
+
+const peerCallTimeout = 10 * time.Second
+
+type FederationService struct {
+	Client *mongo.Database
+
+	userService      *UserService
+	cropService      *CropService
+	walletService    *WalletService
+	warehouseService *WarehouseService
+	eventService     *EventService
+}
+
+func NewFederationService(client *mongo.Database) *FederationService {
+	return &FederationService{
+		Client:           client,
+		userService:      NewUserService(client),
+		cropService:      NewCropService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+		eventService:     NewEventService(client),
+	}
+}
+
+// RegisterPeer records a peer server, or refreshes its name and status if it is already known. A
+// known peer keeps the address it registered with, so no caller can redirect another peer's calls.
+func (fs *FederationService) RegisterPeer(ctx context.Context, peerId string, name string, address string) (*models.PeerConnection, error) {
+	if peerId == "" || address == "" {
+		return nil, NewServiceError(http.StatusBadRequest, "peer server id and address are required")
+	}
+
+	if peerId == utils.ServerID() {
+		return nil, NewServiceError(http.StatusBadRequest, "a server cannot peer with itself")
+	}
+
+	collection := fs.Client.Collection(utils.PeerConnectionsCollection)
+	now := time.Now()
+
+	var peer models.PeerConnection
+
+	err := collection.FindOne(ctx, bson.M{"peer_id": peerId}).Decode(&peer)
+	if err == nil && peer.Address != address {
+		return nil, NewServiceError(http.StatusConflict, "peer %s is already registered at another address", peerId)
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	err = collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"peer_id": peerId, "address": address},
+		bson.M{
+			"$set": bson.M{
+				"name":         name,
+				"status":       utils.PeerActive,
+				"last_seen_at": now,
+				"updated_at":   now,
+				"is_active":    true,
+			},
+			"$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "created_at": now},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&peer)
+
+	// A concurrent registration of the same peer at another address won the unique index.
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, NewServiceError(http.StatusConflict, "peer %s is already registered at another address", peerId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer, nil
+}
+
+// ConnectPeer introduces this server to the peer at address and records the peer it answers as.
+func (fs *FederationService) ConnectPeer(ctx context.Context, address string) (*models.PeerConnection, error) {
+	conn, err := dialPeer(address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	callCtx, cancel := peerContext(ctx)
+	defer cancel()
+
+	res, err := farmingv1.NewFederationServiceClient(conn).RegisterPeer(callCtx, &farmingv1.RegisterPeerRequest{
+		Peer: &farmingv1.PeerInfo{
+			ServerId: utils.ServerID(),
+			Name:     utils.ServerID(),
+			Address:  utils.ServerAddress(),
+		},
+	})
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadGateway, "failed to reach peer %s: %v", address, err)
+	}
+
+	return fs.RegisterPeer(ctx, res.GetPeer().GetServerId(), res.GetPeer().GetName(), address)
+}
+
+func (fs *FederationService) GetPeers(ctx context.Context) ([]models.PeerConnection, error) {
+	cursor, err := fs.Client.Collection(utils.PeerConnectionsCollection).Find(ctx, bson.M{"is_active": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	peers := []models.PeerConnection{}
+	err = cursor.All(ctx, &peers)
+
+	return peers, err
+}
+
+// GetPeer returns a registered peer, failing with 403 for servers that never registered.
+func (fs *FederationService) GetPeer(ctx context.Context, peerId string) (*models.PeerConnection, error) {
+	var peer models.PeerConnection
+
+	err := fs.Client.Collection(utils.PeerConnectionsCollection).FindOne(ctx, bson.M{"peer_id": peerId, "is_active": true}).Decode(&peer)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusForbidden, "unknown peer server %s", peerId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer, nil
+}
+
+// GetLocalDirectory returns the users hosted on this server, as shared with peers.
+func (fs *FederationService) GetLocalDirectory(ctx context.Context) ([]models.User, error) {
+	cursor, err := fs.Client.Collection(utils.UsersCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []models.User{}
+	err = cursor.All(ctx, &users)
+
+	return users, err
+}
+
+// SaveDirectory upserts the directory entries a peer shared and stamps the peer as synced.
+func (fs *FederationService) SaveDirectory(ctx context.Context, peerId string, entries []models.DirectoryEntry) error {
+	now := time.Now()
+	collection := fs.Client.Collection(utils.DirectoryCollection)
+
+	for _, entry := range entries {
+		_, err := collection.UpdateOne(
+			ctx,
+			bson.M{"peer_id": peerId, "remote_user_id": entry.RemoteUserID},
+			bson.M{
+				"$set": bson.M{
+					"username":       entry.Username,
+					"display_name":   entry.DisplayName,
+					"server_address": entry.ServerAddress,
+					"synced_at":      now,
+					"updated_at":     now,
+					"is_active":      true,
+				},
+				"$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "created_at": now},
+			},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := fs.Client.Collection(utils.PeerConnectionsCollection).UpdateOne(
+		ctx,
+		bson.M{"peer_id": peerId},
+		bson.M{"$set": bson.M{"last_synced_at": now, "last_seen_at": now, "status": utils.PeerActive}},
+	)
+
+	return err
+}
+
+// SyncDirectory swaps user directories with a peer.
+func (fs *FederationService) SyncDirectory(ctx context.Context, peerId string) ([]models.DirectoryEntry, error) {
+	peer, err := fs.GetPeer(ctx, peerId)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := fs.GetLocalDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	local := make([]*farmingv1.DirectoryEntry, len(users))
+	for i, user := range users {
+		local[i] = &farmingv1.DirectoryEntry{
+			UserId:        user.ID.Hex(),
+			Username:      user.Username,
+			DisplayName:   user.DisplayName,
+			ServerAddress: user.ServerAddress,
+		}
+	}
+
+	var res *farmingv1.ExchangeDirectoryResponse
+
+	err = fs.callPeer(ctx, peer, func(callCtx context.Context, client farmingv1.FederationServiceClient) error {
+		res, err = client.ExchangeDirectory(callCtx, &farmingv1.ExchangeDirectoryRequest{Entries: local})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.DirectoryEntry, len(res.GetEntries()))
+	for i, entry := range res.GetEntries() {
+		entries[i] = models.DirectoryEntry{
+			PeerID:        peerId,
+			RemoteUserID:  entry.GetUserId(),
+			Username:      entry.GetUsername(),
+			DisplayName:   entry.GetDisplayName(),
+			ServerAddress: entry.GetServerAddress(),
+		}
+	}
+
+	if err := fs.SaveDirectory(ctx, peerId, entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetDirectory lists the remote users learned from peers, optionally for one peer only.
+func (fs *FederationService) GetDirectory(ctx context.Context, peerId string) ([]models.DirectoryEntry, error) {
+	filter := bson.M{"is_active": true}
+	if peerId != "" {
+		filter["peer_id"] = peerId
+	}
+
+	cursor, err := fs.Client.Collection(utils.DirectoryCollection).Find(ctx, filter, options.Find().SetSort(bson.M{"username": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.DirectoryEntry{}
+	err = cursor.All(ctx, &entries)
+
+	return entries, err
+}
+
+// InitiateTrade runs a cross-server trade with this server as the coordinator. The local side is
+// put in escrow and the peer is offered the trade; its user escrows the other side by accepting
+// it, and only once both are held is the trade committed. Until then the trade stays PREPARING
+// and the federation worker keeps asking the peer. The decision is stored before anything is settled, so a crash at any point leaves
+// a record the recovery loop can finish from.
+func (fs *FederationService) InitiateTrade(ctx context.Context, userId primitive.ObjectID, body types.InitiateFederatedTrade) (*models.FederatedTrade, error) {
+	peer, err := fs.GetPeer(ctx, body.PeerID)
+	if err != nil {
+		return nil, err
+	}
+
+	crop, err := fs.cropService.GetCropByName(body.CropName)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, "unknown crop %s", body.CropName)
+	}
+
+	now := time.Now()
+
+	trade := models.FederatedTrade{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		Role:          utils.TradeCoordinator,
+		PeerID:        peer.PeerID,
+		LocalUserID:   userId,
+		RemoteUserID:  body.RemoteUserID,
+		LocalSide:     body.Side,
+		CropName:      crop.Name,
+		Quantity:      body.Quantity,
+		PricePerUnit:  body.PricePerUnit,
+		TotalAmount:   float64(body.Quantity) * body.PricePerUnit,
+		QualityFactor: 1.0,
+		Status:        utils.TradePreparing,
+	}
+	trade.TradeID = utils.ServerID() + ":" + trade.ID.Hex()
+
+	if _, err := fs.Client.Collection(utils.FederatedTradesCollection).InsertOne(ctx, trade); err != nil {
+		return nil, err
+	}
+
+	if err := fs.escrowLocalSide(ctx, &trade, crop.ID); err != nil {
+		fs.decideAndSettle(ctx, &trade, utils.TradeAborted, err.Error())
+		return nil, err
+	}
+
+	prepared, err := fs.requestPrepare(ctx, &trade)
+	if err != nil {
+		// The peer may or may not have stored the offer; aborting is safe either way since it has not committed.
+		fs.decideAndSettle(ctx, &trade, utils.TradeAborted, err.Error())
+		fs.notifyPeer(ctx, &trade)
+		return &trade, nil
+	}
+
+	fs.applyPrepared(ctx, &trade, prepared)
+
+	return &trade, nil
+}
+
+// requestPrepare asks the peer to prepare its side of a trade this server coordinates.
+func (fs *FederationService) requestPrepare(ctx context.Context, trade *models.FederatedTrade) (*farmingv1.PrepareTradeResponse, error) {
+	peer, err := fs.GetPeer(ctx, trade.PeerID)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := &farmingv1.TradeTerms{
+		TradeId:       trade.TradeID,
+		CropName:      trade.CropName,
+		Quantity:      int32(trade.Quantity),
+		PricePerUnit:  trade.PricePerUnit,
+		QualityFactor: trade.QualityFactor,
+	}
+
+	if trade.LocalSide == utils.TradeSideSeller {
+		terms.SellerId, terms.SellerServerId = trade.LocalUserID.Hex(), utils.ServerID()
+		terms.BuyerId, terms.BuyerServerId = trade.RemoteUserID, peer.PeerID
+	} else {
+		terms.BuyerId, terms.BuyerServerId = trade.LocalUserID.Hex(), utils.ServerID()
+		terms.SellerId, terms.SellerServerId = trade.RemoteUserID, peer.PeerID
+	}
+
+	var prepared *farmingv1.PrepareTradeResponse
+
+	err = fs.callPeer(ctx, peer, func(callCtx context.Context, client farmingv1.FederationServiceClient) error {
+		prepared, err = client.PrepareTrade(callCtx, &farmingv1.PrepareTradeRequest{Terms: terms})
+		return err
+	})
+
+	return prepared, err
+}
+
+// applyPrepared acts on the peer's answer to a prepare: commits once the peer holds its side,
+// aborts on a refusal, and leaves the trade PREPARING while the remote user has not accepted.
+func (fs *FederationService) applyPrepared(ctx context.Context, trade *models.FederatedTrade, prepared *farmingv1.PrepareTradeResponse) {
+	switch {
+	case prepared.GetPrepared():
+		if trade.LocalSide == utils.TradeSideBuyer && prepared.GetQualityFactor() > 0 {
+			trade.QualityFactor = prepared.GetQualityFactor()
+			fs.updateTrade(ctx, trade.TradeID, trade.Role, bson.M{"quality_factor": trade.QualityFactor})
+		}
+		fs.decideAndSettle(ctx, trade, utils.TradeCommitted, "")
+	case prepared.GetReason() == utils.TradeAwaitingAcceptance:
+		return
+	default:
+		fs.decideAndSettle(ctx, trade, utils.TradeAborted, prepared.GetReason())
+	}
+
+	fs.notifyPeer(ctx, trade)
+}
+
+// GetTrades returns the federated trades the user took part in, newest first.
+func (fs *FederationService) GetTrades(ctx context.Context, userId primitive.ObjectID) ([]models.FederatedTrade, error) {
+	cursor, err := fs.Client.Collection(utils.FederatedTradesCollection).Find(
+		ctx,
+		bson.M{"local_user_id": userId},
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	trades := []models.FederatedTrade{}
+	err = cursor.All(ctx, &trades)
+
+	return trades, err
+}
+
+// PrepareTrade offers the local user a trade a peer is coordinating. Nothing is escrowed on the
+// coordinator's word alone: the trade waits as OFFERED, answered with TradeAwaitingAcceptance,
+// until the user accepts it with AcceptTrade. Repeating the call returns the current answer, and
+// a trade the coordinator already aborted is refused.
+func (fs *FederationService) PrepareTrade(ctx context.Context, peerId string, terms types.FederatedTradeTerms) (bool, string, float64, error) {
+	if _, err := fs.GetPeer(ctx, peerId); err != nil {
+		return false, "", 0, err
+	}
+
+	var side, localUser, remoteUser, remoteServer string
+
+	switch utils.ServerID() {
+	case terms.SellerServerID:
+		side, localUser, remoteUser, remoteServer = utils.TradeSideSeller, terms.SellerID, terms.BuyerID, terms.BuyerServerID
+	case terms.BuyerServerID:
+		side, localUser, remoteUser, remoteServer = utils.TradeSideBuyer, terms.BuyerID, terms.SellerID, terms.SellerServerID
+	default:
+		return false, "", 0, NewServiceError(http.StatusBadRequest, "trade %s has no side on this server", terms.TradeID)
+	}
+
+	if remoteServer != peerId {
+		return false, "", 0, NewServiceError(http.StatusForbidden, "peer %s is not a party to trade %s", peerId, terms.TradeID)
+	}
+
+	if terms.Quantity <= 0 || terms.PricePerUnit <= 0 {
+		return false, "", 0, NewServiceError(http.StatusBadRequest, "quantity and price must be positive")
+	}
+
+	userId, err := primitive.ObjectIDFromHex(localUser)
+	if err != nil {
+		return false, "invalid user id", 0, nil
+	}
+
+	if _, err := fs.userService.GetUserById(userId); err != nil {
+		return false, "unknown user", 0, nil
+	}
+
+	crop, err := fs.cropService.GetCropByName(terms.CropName)
+	if err != nil {
+		return false, "unknown crop " + terms.CropName, 0, nil
+	}
+
+	now := time.Now()
+
+	trade := models.FederatedTrade{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		TradeID:       terms.TradeID,
+		Role:          utils.TradeParticipant,
+		PeerID:        peerId,
+		LocalUserID:   userId,
+		RemoteUserID:  remoteUser,
+		LocalSide:     side,
+		CropName:      crop.Name,
+		Quantity:      terms.Quantity,
+		PricePerUnit:  terms.PricePerUnit,
+		TotalAmount:   float64(terms.Quantity) * terms.PricePerUnit,
+		QualityFactor: terms.QualityFactor,
+		Status:        utils.TradeOffered,
+	}
+
+	existing, inserted, err := fs.insertParticipantTrade(ctx, &trade)
+	if err != nil {
+		return false, "", 0, err
+	}
+
+	if !inserted {
+		switch existing.Status {
+		case utils.TradePrepared, utils.TradeCommitted:
+			return true, "", existing.QualityFactor, nil
+		case utils.TradeAborted:
+			if existing.Reason != "" {
+				return false, existing.Reason, 0, nil
+			}
+			return false, "trade already aborted", 0, nil
+		default:
+			return false, utils.TradeAwaitingAcceptance, 0, nil
+		}
+	}
+
+	fs.eventService.PublishQuietly(ctx, userId, utils.EventTradeOffer, types.TradeOfferPayload{
+		TradeID:      trade.TradeID,
+		FromUserID:   remoteUser,
+		CropID:       crop.ID.Hex(),
+		Quantity:     trade.Quantity,
+		PricePerUnit: trade.PricePerUnit,
+	})
+
+	return false, utils.TradeAwaitingAcceptance, 0, nil
+}
+
+// AcceptTrade escrows the user's side of a trade a peer offered them, after which the trade is
+// PREPARED and the coordinator can commit it.
+func (fs *FederationService) AcceptTrade(ctx context.Context, userId primitive.ObjectID, tradeId string) (*models.FederatedTrade, error) {
+	trade, err := fs.getOffer(ctx, userId, tradeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fs.updateTrade(ctx, tradeId, utils.TradeParticipant, bson.M{"status": utils.TradePreparing}, utils.TradeOffered) {
+		return nil, NewServiceError(http.StatusConflict, "trade %s is no longer on offer", tradeId)
+	}
+
+	crop, err := fs.cropService.GetCropByName(trade.CropName)
+	if err != nil {
+		fs.decideAndSettle(ctx, trade, utils.TradeAborted, "unknown crop "+trade.CropName)
+		return nil, NewServiceError(http.StatusBadRequest, "unknown crop %s", trade.CropName)
+	}
+
+	if err := fs.escrowLocalSide(ctx, trade, crop.ID); err != nil {
+		fs.decideAndSettle(ctx, trade, utils.TradeAborted, err.Error())
+		return nil, err
+	}
+
+	if !fs.updateTrade(ctx, tradeId, utils.TradeParticipant, bson.M{"status": utils.TradePrepared}, utils.TradePreparing) {
+		// An abort arrived while the escrow was being placed.
+		fs.settleLocal(ctx, tradeId, utils.TradeParticipant)
+		return nil, NewServiceError(http.StatusConflict, "trade %s was aborted", tradeId)
+	}
+
+	return fs.getTrade(ctx, tradeId, utils.TradeParticipant)
+}
+
+// DeclineTrade turns down a trade a peer offered the user. The coordinator learns of it the next
+// time it asks.
+func (fs *FederationService) DeclineTrade(ctx context.Context, userId primitive.ObjectID, tradeId string) (*models.FederatedTrade, error) {
+	trade, err := fs.getOffer(ctx, userId, tradeId)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.decideAndSettle(ctx, trade, utils.TradeAborted, "declined by the user")
+
+	if trade.Status != utils.TradeAborted {
+		return nil, NewServiceError(http.StatusConflict, "trade %s is already %s", tradeId, trade.Status)
+	}
+
+	return trade, nil
+}
+
+// getOffer returns a trade a peer offered the user, failing with 404 for other users' trades.
+func (fs *FederationService) getOffer(ctx context.Context, userId primitive.ObjectID, tradeId string) (*models.FederatedTrade, error) {
+	trade, err := fs.getTrade(ctx, tradeId, utils.TradeParticipant)
+	if err == mongo.ErrNoDocuments || (err == nil && trade.LocalUserID != userId) {
+		return nil, NewServiceError(http.StatusNotFound, "trade %s not found", tradeId)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if trade.Status != utils.TradeOffered {
+		return nil, NewServiceError(http.StatusConflict, "trade %s is already %s", tradeId, trade.Status)
+	}
+
+	return trade, nil
+}
+
+// DecideTrade applies the coordinator's commit or abort to a prepared trade. Decisions are
+// idempotent, and an abort for a trade never seen is kept so a late prepare is refused.
+func (fs *FederationService) DecideTrade(ctx context.Context, peerId string, tradeId string, decision string) (string, error) {
+	if _, err := fs.GetPeer(ctx, peerId); err != nil {
+		return "", err
+	}
+
+	trade, err := fs.getTrade(ctx, tradeId, utils.TradeParticipant)
+	if err == mongo.ErrNoDocuments {
+		if decision == utils.TradeCommitted {
+			return "", NewServiceError(http.StatusNotFound, "trade %s was never prepared", tradeId)
+		}
+
+		return utils.TradeAborted, fs.insertAbortTombstone(ctx, peerId, tradeId)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if trade.PeerID != peerId {
+		return "", NewServiceError(http.StatusForbidden, "peer %s is not a party to trade %s", peerId, tradeId)
+	}
+
+	from := []string{utils.TradeOffered, utils.TradePreparing, utils.TradePrepared}
+	if decision == utils.TradeCommitted {
+		from = []string{utils.TradePrepared}
+	}
+
+	fs.updateTrade(ctx, tradeId, utils.TradeParticipant, bson.M{"status": decision, "decided_at": time.Now()}, from...)
+
+	trade, err = fs.getTrade(ctx, tradeId, utils.TradeParticipant)
+	if err != nil {
+		return "", err
+	}
+
+	if trade.Status != decision {
+		return trade.Status, NewServiceError(http.StatusConflict, "trade %s is already %s", tradeId, trade.Status)
+	}
+
+	fs.settleLocal(ctx, tradeId, utils.TradeParticipant)
+
+	return trade.Status, nil
+}
+
+// GetCoordinatedTradeStatus answers a participant asking how a trade this server coordinates ended.
+func (fs *FederationService) GetCoordinatedTradeStatus(ctx context.Context, peerId string, tradeId string) (string, error) {
+	if _, err := fs.GetPeer(ctx, peerId); err != nil {
+		return "", err
+	}
+
+	trade, err := fs.getTrade(ctx, tradeId, utils.TradeCoordinator)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if trade.PeerID != peerId {
+		return "", NewServiceError(http.StatusForbidden, "peer %s is not a party to trade %s", peerId, tradeId)
+	}
+
+	return trade.Status, nil
+}
+
+// RecoverTrades finishes trades interrupted by a crash or an unreachable peer: settles decided
+// trades, re-sends decisions the peer has not acknowledged, asks peers about offers still waiting
+// for their user, aborts stale undecided and expired trades and asks coordinators about stale
+// prepared ones.
+func (fs *FederationService) RecoverTrades(ctx context.Context) error {
+	cursor, err := fs.Client.Collection(utils.FederatedTradesCollection).Find(ctx, bson.M{
+		"$or": []bson.M{
+			{"status": bson.M{"$in": []string{utils.TradeOffered, utils.TradePreparing, utils.TradePrepared}}},
+			{"local_settled": false},
+			{"role": utils.TradeCoordinator, "peer_notified": false},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var trades []models.FederatedTrade
+	if err := cursor.All(ctx, &trades); err != nil {
+		return err
+	}
+
+	stale := time.Now().Add(-staleTradeAfter)
+	expired := time.Now().Add(-tradeOfferTTL)
+
+	for i := range trades {
+		trade := &trades[i]
+
+		switch trade.Status {
+		case utils.TradeCommitted, utils.TradeAborted:
+			if !trade.LocalSettled {
+				fs.settleLocal(ctx, trade.TradeID, trade.Role)
+			}
+			if trade.Role == utils.TradeCoordinator && !trade.PeerNotified {
+				fs.notifyPeer(ctx, trade)
+			}
+
+		case utils.TradeOffered:
+			if trade.CreatedAt.After(expired) {
+				continue
+			}
+			fs.decideAndSettle(ctx, trade, utils.TradeAborted, "offer expired")
+
+		case utils.TradePreparing:
+			escrowed := !trade.ReservationID.IsZero() || !trade.HoldID.IsZero()
+
+			if trade.Role == utils.TradeCoordinator && escrowed && trade.CreatedAt.After(expired) {
+				fs.pollPrepared(ctx, trade)
+				continue
+			}
+
+			if trade.UpdatedAt.After(stale) {
+				continue
+			}
+			// Nothing was committed anywhere yet, so presume abort.
+			fs.decideAndSettle(ctx, trade, utils.TradeAborted, "timed out while preparing")
+			if trade.Role == utils.TradeCoordinator {
+				fs.notifyPeer(ctx, trade)
+			}
+
+		case utils.TradePrepared:
+			if trade.UpdatedAt.After(stale) {
+				continue
+			}
+			fs.resolvePrepared(ctx, trade)
+		}
+	}
+
+	return nil
+}
+
+// pollPrepared asks the peer again about a trade whose remote user had not accepted yet.
+func (fs *FederationService) pollPrepared(ctx context.Context, trade *models.FederatedTrade) {
+	prepared, err := fs.requestPrepare(ctx, trade)
+	if err != nil {
+		// The offer may still be waiting on the peer; it expires after tradeOfferTTL.
+		fmt.Printf("Error polling federated trade %s: %v\n", trade.TradeID, err)
+		return
+	}
+
+	fs.applyPrepared(ctx, trade, prepared)
+}
+
+// resolvePrepared asks the coordinator how a trade this server prepared ended.
+func (fs *FederationService) resolvePrepared(ctx context.Context, trade *models.FederatedTrade) {
+	peer, err := fs.GetPeer(ctx, trade.PeerID)
+	if err != nil {
+		fmt.Printf("Error resolving federated trade %s: %v\n", trade.TradeID, err)
+		return
+	}
+
+	var res *farmingv1.GetTradeStatusResponse
+
+	err = fs.callPeer(ctx, peer, func(callCtx context.Context, client farmingv1.FederationServiceClient) error {
+		res, err = client.GetTradeStatus(callCtx, &farmingv1.GetTradeStatusRequest{TradeId: trade.TradeID})
+		return err
+	})
+	if err != nil {
+		// Prepared trades must wait for the coordinator; it may have committed.
+		fmt.Printf("Error resolving federated trade %s: %v\n", trade.TradeID, err)
+		return
+	}
+
+	decision := ""
+
+	switch res.GetStatus() {
+	case farmingv1.TradeStatus_TRADE_STATUS_COMMITTED:
+		decision = utils.TradeCommitted
+	case farmingv1.TradeStatus_TRADE_STATUS_ABORTED, farmingv1.TradeStatus_TRADE_STATUS_UNKNOWN:
+		decision = utils.TradeAborted
+	default:
+		return
+	}
+
+	if _, err := fs.DecideTrade(ctx, trade.PeerID, trade.TradeID, decision); err != nil {
+		fmt.Printf("Error resolving federated trade %s: %v\n", trade.TradeID, err)
+	}
+}
+
+// escrowLocalSide holds the goods of a local seller, or the money of a local buyer and warehouse
+// space for what they buy.
+func (fs *FederationService) escrowLocalSide(ctx context.Context, trade *models.FederatedTrade, cropId primitive.ObjectID) error {
+	if trade.LocalSide == utils.TradeSideSeller {
+		reservation, err := fs.warehouseService.ReserveStock(ctx, trade.LocalUserID, cropId, trade.Quantity, "FEDERATED_TRADE", trade.TradeID)
+		if err != nil {
+			return err
+		}
+
+		trade.ReservationID = reservation.ID
+		trade.QualityFactor = averageQuality(reservation.Lots)
+
+		fs.updateTrade(ctx, trade.TradeID, trade.Role, bson.M{"reservation_id": reservation.ID, "quality_factor": trade.QualityFactor})
+
+		return nil
+	}
+
+	// Room for the goods is set aside up front, so a committed trade can always deliver them.
+	if err := fs.warehouseService.ReserveCapacity(ctx, trade.LocalUserID, trade.Quantity, trade.ID.Hex()); err != nil {
+		return err
+	}
+
+	hold, err := fs.walletService.Hold(ctx, trade.LocalUserID, trade.TotalAmount, "FEDERATED_TRADE", trade.TradeID)
+	if err != nil {
+		if releaseErr := fs.warehouseService.ReleaseCapacity(ctx, trade.LocalUserID, trade.ID.Hex()); releaseErr != nil {
+			fmt.Printf("Error releasing warehouse space of federated trade %s: %v\n", trade.TradeID, releaseErr)
+		}
+		return err
+	}
+
+	trade.HoldID = hold.ID
+
+	fs.updateTrade(ctx, trade.TradeID, trade.Role, bson.M{"hold_id": hold.ID})
+
+	return nil
+}
+
+// decideAndSettle records the outcome of an undecided trade and applies it locally. If the trade
+// was decided concurrently, the stored decision wins.
+func (fs *FederationService) decideAndSettle(ctx context.Context, trade *models.FederatedTrade, decision string, reason string) {
+	fs.updateTrade(ctx, trade.TradeID, trade.Role, bson.M{
+		"status":     decision,
+		"reason":     reason,
+		"decided_at": time.Now(),
+	}, utils.TradeOffered, utils.TradePreparing, utils.TradePrepared)
+
+	fs.settleLocal(ctx, trade.TradeID, trade.Role)
+
+	if stored, err := fs.getTrade(ctx, trade.TradeID, trade.Role); err == nil {
+		*trade = *stored
+	}
+}
+
+// settleLocal moves this server's escrow according to the stored decision and, for a commit,
+// pays the local seller or delivers to the local buyer. Every step is idempotent, keyed by the
+// trade, and local_settled is only set once all of them succeeded, so recovery retries the rest.
+func (fs *FederationService) settleLocal(ctx context.Context, tradeId string, role string) {
+	trade, err := fs.getTrade(ctx, tradeId, role)
+	if err != nil || trade.LocalSettled {
+		return
+	}
+
+	switch trade.Status {
+	case utils.TradeAborted:
+		err = fs.releaseEscrow(ctx, trade)
+	case utils.TradeCommitted:
+		err = fs.captureEscrow(ctx, trade)
+		if err == nil && trade.LocalSide == utils.TradeSideSeller {
+			description := fmt.Sprintf("Sold %d %s to %s", trade.Quantity, trade.CropName, trade.PeerID)
+			err = fs.walletService.CreditOnce(ctx, trade.LocalUserID, trade.TotalAmount, "FEDERATED_TRADE", description, trade.TradeID)
+		} else if err == nil {
+			err = fs.deliverGoods(ctx, trade)
+		}
+	default:
+		return
+	}
+
+	if err != nil {
+		fmt.Printf("Error settling federated trade %s: %v\n", tradeId, err)
+		return
+	}
+
+	fs.updateTrade(ctx, tradeId, role, bson.M{"local_settled": true}, trade.Status)
+}
+
+func (fs *FederationService) releaseEscrow(ctx context.Context, trade *models.FederatedTrade) error {
+	if trade.LocalSide == utils.TradeSideSeller {
+		reservation, err := fs.warehouseService.GetReservationByReference(ctx, trade.TradeID)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return fs.warehouseService.ReleaseReservation(ctx, reservation.ID)
+	}
+
+	if err := fs.warehouseService.ReleaseCapacity(ctx, trade.LocalUserID, trade.ID.Hex()); err != nil {
+		return err
+	}
+
+	hold, err := fs.walletService.GetHoldByReference(ctx, trade.TradeID)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return fs.walletService.ReleaseHold(ctx, hold.ID)
+}
+
+func (fs *FederationService) captureEscrow(ctx context.Context, trade *models.FederatedTrade) error {
+	if trade.LocalSide == utils.TradeSideSeller {
+		reservation, err := fs.warehouseService.GetReservationByReference(ctx, trade.TradeID)
+		if err != nil {
+			return err
+		}
+
+		_, err = fs.warehouseService.ConsumeReservation(ctx, reservation.ID)
+		return err
+	}
+
+	hold, err := fs.walletService.GetHoldByReference(ctx, trade.TradeID)
+	if err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("Bought %d %s from %s", trade.Quantity, trade.CropName, trade.PeerID)
+
+	return fs.walletService.CaptureHold(ctx, hold.ID, "FEDERATED_TRADE", description)
+}
+
+// deliverGoods stores the bought goods in the space reserved for them, once per trade.
+func (fs *FederationService) deliverGoods(ctx context.Context, trade *models.FederatedTrade) error {
+	crop, err := fs.cropService.GetCropByName(trade.CropName)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	return fs.warehouseService.StoreItemOnce(ctx, &models.WarehouseItem{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		UserID:        trade.LocalUserID,
+		CropID:        crop.ID,
+		Quantity:      trade.Quantity,
+		BasePrice:     crop.BasePrice,
+		CurrentPrice:  trade.PricePerUnit,
+		StoredAt:      now,
+		ExpiresAt:     now.Add(7 * 24 * time.Hour),
+		QualityFactor: trade.QualityFactor,
+		Source:        "TRADE",
+	}, "FEDERATED_TRADE:"+trade.TradeID, trade.ID.Hex())
+}
+
+// notifyPeer sends the coordinator's decision to the participant and marks it acknowledged.
+func (fs *FederationService) notifyPeer(ctx context.Context, trade *models.FederatedTrade) {
+	peer, err := fs.GetPeer(ctx, trade.PeerID)
+	if err != nil {
+		fmt.Printf("Error notifying peer of federated trade %s: %v\n", trade.TradeID, err)
+		return
+	}
+
+	request := &farmingv1.TradeDecisionRequest{TradeId: trade.TradeID}
+
+	err = fs.callPeer(ctx, peer, func(callCtx context.Context, client farmingv1.FederationServiceClient) error {
+		if trade.Status == utils.TradeCommitted {
+			_, err := client.CommitTrade(callCtx, request)
+			return err
+		}
+
+		_, err := client.AbortTrade(callCtx, request)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error notifying peer of federated trade %s: %v\n", trade.TradeID, err)
+		return
+	}
+
+	trade.PeerNotified = true
+	fs.updateTrade(ctx, trade.TradeID, trade.Role, bson.M{"peer_notified": true})
+}
+
+// callPeer dials a peer, runs call with a timeout and tracks whether the peer is reachable.
+func (fs *FederationService) callPeer(ctx context.Context, peer *models.PeerConnection, call func(context.Context, farmingv1.FederationServiceClient) error) error {
+	conn, err := dialPeer(peer.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	callCtx, cancel := peerContext(ctx)
+	defer cancel()
+
+	err = call(callCtx, farmingv1.NewFederationServiceClient(conn))
+
+	status := utils.PeerActive
+	if err != nil {
+		status = utils.PeerUnreachable
+	}
+
+	update := bson.M{"status": status}
+	if err == nil {
+		update["last_seen_at"] = time.Now()
+	}
+
+	fs.Client.Collection(utils.PeerConnectionsCollection).UpdateOne(ctx, bson.M{"peer_id": peer.PeerID}, bson.M{"$set": update})
+
+	return err
+}
+
+func (fs *FederationService) getTrade(ctx context.Context, tradeId string, role string) (*models.FederatedTrade, error) {
+	var trade models.FederatedTrade
+
+	err := fs.Client.Collection(utils.FederatedTradesCollection).FindOne(ctx, bson.M{"trade_id": tradeId, "role": role}).Decode(&trade)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trade, nil
+}
+
+// updateTrade sets fields on a trade, only while it is in one of the given statuses if any are
+// given, and reports whether it matched.
+func (fs *FederationService) updateTrade(ctx context.Context, tradeId string, role string, set bson.M, statuses ...string) bool {
+	filter := bson.M{"trade_id": tradeId, "role": role}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+
+	set["updated_at"] = time.Now()
+
+	result, err := fs.Client.Collection(utils.FederatedTradesCollection).UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		fmt.Printf("Error updating federated trade %s: %v\n", tradeId, err)
+		return false
+	}
+
+	return result.MatchedCount > 0
+}
+
+// insertParticipantTrade stores the trade unless the peer already sent it, returning the stored
+// copy in that case.
+func (fs *FederationService) insertParticipantTrade(ctx context.Context, trade *models.FederatedTrade) (*models.FederatedTrade, bool, error) {
+	result, err := fs.Client.Collection(utils.FederatedTradesCollection).UpdateOne(
+		ctx,
+		bson.M{"trade_id": trade.TradeID, "role": utils.TradeParticipant},
+		bson.M{"$setOnInsert": trade},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if result.UpsertedCount > 0 {
+		return trade, true, nil
+	}
+
+	existing, err := fs.getTrade(ctx, trade.TradeID, utils.TradeParticipant)
+
+	return existing, false, err
+}
+
+func (fs *FederationService) insertAbortTombstone(ctx context.Context, peerId string, tradeId string) error {
+	now := time.Now()
+
+	_, err := fs.Client.Collection(utils.FederatedTradesCollection).UpdateOne(
+		ctx,
+		bson.M{"trade_id": tradeId, "role": utils.TradeParticipant},
+		bson.M{"$setOnInsert": models.FederatedTrade{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			},
+			TradeID:      tradeId,
+			Role:         utils.TradeParticipant,
+			PeerID:       peerId,
+			Status:       utils.TradeAborted,
+			Reason:       "aborted before prepare",
+			LocalSettled: true,
+			DecidedAt:    now,
+		}},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+func dialPeer(address string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(address, PeerDialOptions...)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadGateway, "failed to dial peer %s: %v", address, err)
+	}
+
+	return conn, nil
+}
+
+// peerContext identifies this server to the peer, with the federation secret when one is set for
+// peers that don't use mutual TLS, and bounds the call.
+func peerContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "server_id", utils.ServerID())
+	if secret := utils.FederationSecret(); secret != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "federation_secret", secret)
+	}
+
+	return context.WithTimeout(ctx, peerCallTimeout)
+}
@@ -0,0 +1,594 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ForwardService struct {
+	Client *mongo.Database
+
+	config           utils.ForwardConfig
+	marketService    *MarketService
+	walletService    *WalletService
+	warehouseService *WarehouseService
+	eventService     *EventService
+}
+
+func NewForwardService(client *mongo.Database) *ForwardService {
+	return &ForwardService{
+		Client:           client,
+		config:           utils.ForwardConfigFromEnv(),
+		marketService:    NewMarketService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+		eventService:     NewEventService(client),
+	}
+}
+
+// OfferForward offers part of the seller's growing planting at a locked price, holding the
+// collateral from their wallet. The planting's open and active contracts may not add up to more
+// than its expected yield.
+func (fs *ForwardService) OfferForward(ctx context.Context, sellerId primitive.ObjectID, body types.OfferForward) (*models.ForwardContract, error) {
+	plantingId, err := primitive.ObjectIDFromHex(body.PlantingID)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, "invalid planting id")
+	}
+
+	var planting models.PlantedCrop
+
+	err = fs.Client.Collection(utils.PlantedCropsCollection).FindOne(ctx, bson.M{"_id": plantingId, "user_id": sellerId, "is_active": true}).Decode(&planting)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Planting not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if planting.IsHarvested || !planting.ExpectedHarvestAt.After(now) {
+		return nil, NewServiceError(http.StatusBadRequest, "Only plantings still growing can be sold forward")
+	}
+
+	committed, err := fs.committed(ctx, planting.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if committed+body.Quantity > planting.ExpectedYield {
+		return nil, NewServiceError(http.StatusBadRequest, "Only %d of the expected %d units are left to sell", max(0, planting.ExpectedYield-committed), planting.ExpectedYield)
+	}
+
+	price := roundCoins(body.PricePerUnit)
+	if price <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "price_per_unit must be at least 0.01")
+	}
+
+	total := roundCoins(price * float64(body.Quantity))
+
+	contract := models.ForwardContract{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		SellerID:     sellerId,
+		PlantingID:   planting.ID,
+		CropID:       planting.CropID,
+		Quantity:     body.Quantity,
+		PricePerUnit: price,
+		TotalAmount:  total,
+		Collateral:   math.Max(0.01, roundCoins(total*fs.config.CollateralRate)),
+		DeliverAfter: planting.ExpectedHarvestAt,
+		Status:       utils.ForwardOpen,
+	}
+
+	hold, err := fs.walletService.Hold(ctx, sellerId, contract.Collateral, "FORWARD_COLLATERAL", contract.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	contract.SellerHoldID = hold.ID
+
+	if _, err := fs.Client.Collection(utils.ForwardsCollection).InsertOne(ctx, contract); err != nil {
+		fs.releaseHold(ctx, hold.ID)
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+// GetForwards returns the forward contracts with the given status, soonest delivery first.
+func (fs *ForwardService) GetForwards(ctx context.Context, status string) ([]models.ForwardContract, error) {
+	return fs.find(ctx, bson.M{"status": status}, bson.D{{Key: "deliver_after", Value: 1}})
+}
+
+// GetUserForwards returns the forward contracts the user sold or bought, newest first.
+func (fs *ForwardService) GetUserForwards(ctx context.Context, userId primitive.ObjectID) ([]models.ForwardContract, error) {
+	return fs.find(ctx, bson.M{"$or": []bson.M{{"seller_id": userId}, {"buyer_id": userId}}}, bson.D{{Key: "created_at", Value: -1}})
+}
+
+// AcceptForward buys an open forward contract, holding its full price from the buyer's wallet
+// until the harvest delivers.
+func (fs *ForwardService) AcceptForward(ctx context.Context, buyerId primitive.ObjectID, contractId primitive.ObjectID) (*models.ForwardContract, error) {
+	contract, err := fs.getContract(ctx, contractId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case contract.Status != utils.ForwardOpen:
+		return nil, NewServiceError(http.StatusConflict, "Contract is no longer open")
+	case contract.SellerID == buyerId:
+		return nil, NewServiceError(http.StatusBadRequest, "You can't buy your own contract")
+	}
+
+	hold, err := fs.walletService.Hold(ctx, buyerId, contract.TotalAmount, "FORWARD_PURCHASE", contract.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	var accepted models.ForwardContract
+
+	err = fs.Client.Collection(utils.ForwardsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": contract.ID, "status": utils.ForwardOpen},
+		bson.M{"$set": bson.M{
+			"status":        utils.ForwardActive,
+			"buyer_id":      buyerId,
+			"buyer_hold_id": hold.ID,
+			"accepted_at":   now,
+			"updated_at":    now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&accepted)
+
+	if err != nil {
+		fs.releaseHold(ctx, hold.ID)
+
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusConflict, "Contract was taken or withdrawn")
+		}
+		return nil, err
+	}
+
+	return &accepted, nil
+}
+
+// CancelForward withdraws the seller's open offer and returns the collateral.
+func (fs *ForwardService) CancelForward(ctx context.Context, sellerId primitive.ObjectID, contractId primitive.ObjectID) (*models.ForwardContract, error) {
+	contract, err := fs.close(ctx, bson.M{"_id": contractId, "seller_id": sellerId, "status": utils.ForwardOpen}, utils.ForwardCancelled)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Open contract not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+// DeliverHarvest delivers a planting's active forward contracts out of the harvest, oldest
+// first, and settles each at its locked price. Room for the delivery is reserved in the buyer's
+// warehouse before the contract is claimed; units the buyer has no room for stay with the grower
+// and are settled in cash like any other shortfall, at the market price. Contracts a harvest
+// already settled are delivered again under the same key, so a resumed harvest neither stores
+// their stock twice nor gives it to the grower. Delivered units are taken off result.Quantity and
+// listed in result.Forwards, and offers nobody bought are withdrawn.
+func (fs *ForwardService) DeliverHarvest(ctx context.Context, planting *models.PlantedCrop, result *models.HarvestResult) error {
+	if err := fs.closeMany(ctx, bson.M{"planting_id": planting.ID, "status": utils.ForwardOpen}, utils.ForwardExpired); err != nil {
+		return err
+	}
+
+	contracts, err := fs.find(ctx, bson.M{
+		"planting_id": planting.ID,
+		"status":      bson.M{"$in": []string{utils.ForwardActive, utils.ForwardDelivered, utils.ForwardShortfall}},
+	}, bson.D{{Key: "created_at", Value: 1}})
+	if err != nil {
+		return err
+	}
+
+	price := -1.0
+
+	for i := range contracts {
+		contract := &contracts[i]
+
+		if contract.Status == utils.ForwardActive {
+			if price < 0 {
+				if price, err = fs.marketService.GetCurrentPrice(ctx, planting.CropID); err != nil {
+					return err
+				}
+			}
+
+			if err := fs.claimDelivery(ctx, contract, min(contract.Quantity, result.Quantity), price); err != nil {
+				return err
+			}
+		} else if contract.Delivered == 0 {
+			// Settled without this harvest, by the forward worker.
+			continue
+		}
+
+		if contract.Delivered > 0 {
+			key := "FORWARD:" + contract.ID.Hex()
+
+			item := harvestItem(contract.BuyerID, result)
+			item.Quantity = contract.Delivered
+
+			if err := fs.warehouseService.StoreItemOnce(ctx, &item, key, key); err != nil {
+				return err
+			}
+
+			if err := fs.warehouseService.ReleaseCapacity(ctx, contract.BuyerID, key); err != nil {
+				fmt.Printf("Error releasing space of forward contract %s: %v\n", contract.ID.Hex(), err)
+			}
+		}
+
+		result.Quantity -= contract.Delivered
+		result.Forwards = append(result.Forwards, models.ForwardDelivery{
+			ContractID: contract.ID,
+			BuyerID:    contract.BuyerID,
+			Quantity:   contract.Delivered,
+			Shortfall:  contract.Shortfall,
+			Penalty:    contract.Penalty,
+		})
+	}
+
+	return nil
+}
+
+// claimDelivery reserves room for delivered units in the buyer's warehouse, or delivers none if
+// there is no room, and settles the contract with them. If the contract was settled elsewhere in
+// the meantime, contract is reloaded as it was settled.
+func (fs *ForwardService) claimDelivery(ctx context.Context, contract *models.ForwardContract, delivered int, price float64) error {
+	key := "FORWARD:" + contract.ID.Hex()
+
+	if delivered > 0 {
+		if err := fs.warehouseService.ReserveCapacity(ctx, contract.BuyerID, delivered, key); err != nil {
+			fmt.Printf("Buyer of forward contract %s can't take delivery, settling in cash: %v\n", contract.ID.Hex(), err)
+			delivered = 0
+		}
+	}
+
+	settled, err := fs.settle(ctx, contract, delivered, price)
+	if err == nil && !settled {
+		contract, err = fs.reload(ctx, contract)
+	}
+
+	// Space reserved for a delivery that did not happen goes back.
+	if delivered > 0 && (err != nil || contract.Delivered == 0) {
+		if releaseErr := fs.warehouseService.ReleaseCapacity(ctx, contract.BuyerID, key); releaseErr != nil {
+			fmt.Printf("Error releasing space of forward contract %s: %v\n", contract.ID.Hex(), releaseErr)
+		}
+	}
+
+	return err
+}
+
+// reload reads contract again into the same struct and returns it.
+func (fs *ForwardService) reload(ctx context.Context, contract *models.ForwardContract) (*models.ForwardContract, error) {
+	fresh, err := fs.getContract(ctx, contract.ID)
+	if err != nil {
+		return contract, err
+	}
+
+	*contract = *fresh
+
+	return contract, nil
+}
+
+// SettleOverdue fails active contracts whose planting was not harvested within the grace period
+// after its current expected harvest, as a full shortfall, expires offers nobody bought in time
+// and retries settlement payments that failed.
+func (fs *ForwardService) SettleOverdue(ctx context.Context) error {
+	now := time.Now()
+
+	if err := fs.closeMany(ctx, bson.M{"status": utils.ForwardOpen, "deliver_after": bson.M{"$lte": now}}, utils.ForwardExpired); err != nil {
+		return err
+	}
+
+	unpaid, err := fs.find(ctx, bson.M{
+		"status":   bson.M{"$in": []string{utils.ForwardDelivered, utils.ForwardShortfall}},
+		"paid_out": false,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range unpaid {
+		if err := fs.payOut(ctx, &unpaid[i]); err != nil {
+			fmt.Printf("Error paying out forward contract %s: %v\n", unpaid[i].ID.Hex(), err)
+		}
+	}
+
+	grace := time.Duration(fs.config.GraceHours) * time.Hour
+
+	overdue, err := fs.find(ctx, bson.M{"status": utils.ForwardActive, "deliver_after": bson.M{"$lte": now.Add(-grace)}}, nil)
+	if err != nil {
+		return err
+	}
+
+	prices := make(map[primitive.ObjectID]float64)
+
+	for i := range overdue {
+		contract := &overdue[i]
+
+		// Weather and care move the harvest, so the deadline follows the planting.
+		due, err := fs.dueAt(ctx, contract)
+		if err != nil {
+			fmt.Printf("Error loading planting of forward contract %s: %v\n", contract.ID.Hex(), err)
+			continue
+		}
+		if now.Before(due.Add(grace)) {
+			continue
+		}
+
+		price, ok := prices[contract.CropID]
+		if !ok {
+			if price, err = fs.marketService.GetCurrentPrice(ctx, contract.CropID); err != nil {
+				fmt.Printf("Error pricing forward contract %s: %v\n", contract.ID.Hex(), err)
+				continue
+			}
+			prices[contract.CropID] = price
+		}
+
+		if _, err := fs.settle(ctx, contract, 0, price); err != nil {
+			fmt.Printf("Error settling forward contract %s: %v\n", contract.ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// dueAt returns when the contract's planting is now expected to be harvested, or when it was
+// harvested. A planting that is gone falls back to the expected harvest the contract was offered
+// with.
+func (fs *ForwardService) dueAt(ctx context.Context, contract *models.ForwardContract) (time.Time, error) {
+	var planting models.PlantedCrop
+
+	err := fs.Client.Collection(utils.PlantedCropsCollection).FindOne(ctx, bson.M{"_id": contract.PlantingID}).Decode(&planting)
+	if err == mongo.ErrNoDocuments {
+		return contract.DeliverAfter, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch {
+	case planting.IsHarvested:
+		return planting.HarvestedAt, nil
+	case planting.Harvest != nil:
+		// Claimed by a harvest that has not finished; harvesting again delivers the contract.
+		return planting.Harvest.HarvestedAt, nil
+	case !planting.IsActive:
+		return contract.DeliverAfter, nil
+	}
+
+	return planting.ExpectedHarvestAt, nil
+}
+
+// settle closes an active contract with delivered units. The buyer pays the locked price for them
+// and gets the rest of their payment back; a shortfall pays the buyer the market price of the
+// missing units out of the seller's collateral. It reports whether this call settled the contract.
+func (fs *ForwardService) settle(ctx context.Context, contract *models.ForwardContract, delivered int, marketPrice float64) (bool, error) {
+	now := time.Now()
+
+	shortfall := contract.Quantity - delivered
+	penalty := math.Min(contract.Collateral, roundCoins(float64(shortfall)*marketPrice))
+
+	status := utils.ForwardDelivered
+	if shortfall > 0 {
+		status = utils.ForwardShortfall
+	}
+
+	// Claiming the contract first keeps the harvest and the worker from both settling it.
+	result, err := fs.Client.Collection(utils.ForwardsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": contract.ID, "status": utils.ForwardActive},
+		bson.M{"$set": bson.M{
+			"status":       status,
+			"delivered":    delivered,
+			"shortfall":    shortfall,
+			"market_price": marketPrice,
+			"penalty":      penalty,
+			"settled_at":   now,
+			"paid_out":     false,
+			"updated_at":   now,
+		}},
+	)
+	if err != nil || result.ModifiedCount == 0 {
+		return false, err
+	}
+
+	contract.Status = status
+	contract.Delivered = delivered
+	contract.Shortfall = shortfall
+	contract.MarketPrice = marketPrice
+	contract.Penalty = penalty
+	contract.SettledAt = now
+
+	// The forward worker retries payments that fail here.
+	if err := fs.payOut(ctx, contract); err != nil {
+		fmt.Printf("Error paying out forward contract %s: %v\n", contract.ID.Hex(), err)
+	}
+
+	fs.eventService.PublishQuietly(ctx, contract.BuyerID, utils.EventForwardSettled, types.ForwardSettledPayload{
+		ContractID: contract.ID.Hex(),
+		CropID:     contract.CropID.Hex(),
+		Status:     status,
+		Delivered:  delivered,
+		Shortfall:  shortfall,
+		Penalty:    penalty,
+	})
+
+	return true, nil
+}
+
+// payOut moves a settled contract's money: the buyer's payment for the delivered units goes to the
+// seller and the penalty out of the collateral to the buyer, and what is left of both holds goes
+// back. Hold parts and credits are taken under the contract id and paid_out is only set at the
+// end, so the forward worker finishes a payout that stopped halfway without paying anyone twice.
+func (fs *ForwardService) payOut(ctx context.Context, contract *models.ForwardContract) error {
+	description := fmt.Sprintf("Forward contract of %d units, %d delivered", contract.Quantity, contract.Delivered)
+	key := contract.ID.Hex()
+
+	paid := roundCoins(float64(contract.Delivered) * contract.PricePerUnit)
+	if err := fs.spendHold(ctx, contract.BuyerHoldID, paid, contract.TotalAmount, "FORWARD_PURCHASE", description, key); err != nil {
+		return err
+	}
+
+	if paid > 0 {
+		if err := fs.walletService.CreditOnce(ctx, contract.SellerID, paid, "FORWARD_SALE", description, key); err != nil {
+			return err
+		}
+	}
+
+	if err := fs.spendHold(ctx, contract.SellerHoldID, contract.Penalty, contract.Collateral, "FORWARD_PENALTY", description, key); err != nil {
+		return err
+	}
+
+	if contract.Penalty > 0 {
+		if err := fs.walletService.CreditOnce(ctx, contract.BuyerID, contract.Penalty, "FORWARD_PENALTY", description, key); err != nil {
+			return err
+		}
+	}
+
+	_, err := fs.Client.Collection(utils.ForwardsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": contract.ID},
+		bson.M{"$set": bson.M{"paid_out": true, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	contract.PaidOut = true
+
+	return nil
+}
+
+// spendHold captures amount of a hold of held, as a part keyed by key, and releases the rest.
+func (fs *ForwardService) spendHold(ctx context.Context, holdId primitive.ObjectID, amount float64, held float64, category string, description string, key string) error {
+	if amount >= held {
+		return fs.walletService.CaptureHold(ctx, holdId, category, description)
+	}
+
+	if amount > 0 {
+		if err := fs.walletService.CaptureHoldPart(ctx, holdId, amount, category, description, key); err != nil {
+			return err
+		}
+	}
+
+	return fs.walletService.ReleaseHold(ctx, holdId)
+}
+
+// committed returns the units of the planting already offered or sold forward.
+func (fs *ForwardService) committed(ctx context.Context, plantingId primitive.ObjectID) (int, error) {
+	contracts, err := fs.find(ctx, bson.M{
+		"planting_id": plantingId,
+		"status":      bson.M{"$in": []string{utils.ForwardOpen, utils.ForwardActive}},
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	quantity := 0
+	for _, contract := range contracts {
+		quantity += contract.Quantity
+	}
+
+	return quantity, nil
+}
+
+// close moves the open contract matching filter to status and returns the seller's collateral.
+func (fs *ForwardService) close(ctx context.Context, filter bson.M, status string) (*models.ForwardContract, error) {
+	var contract models.ForwardContract
+
+	now := time.Now()
+
+	err := fs.Client.Collection(utils.ForwardsCollection).FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"status": status, "settled_at": now, "updated_at": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&contract)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.releaseHold(ctx, contract.SellerHoldID)
+
+	return &contract, nil
+}
+
+// closeMany closes every open contract matching filter, one at a time so each collateral is
+// returned exactly once.
+func (fs *ForwardService) closeMany(ctx context.Context, filter bson.M, status string) error {
+	open, err := fs.find(ctx, filter, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range open {
+		_, err := fs.close(ctx, bson.M{"_id": contract.ID, "status": utils.ForwardOpen}, status)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error closing forward contract %s: %v\n", contract.ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+func (fs *ForwardService) find(ctx context.Context, filter bson.M, sort bson.D) ([]models.ForwardContract, error) {
+	opts := options.Find()
+	if sort != nil {
+		opts.SetSort(sort)
+	}
+
+	cursor, err := fs.Client.Collection(utils.ForwardsCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	found := []models.ForwardContract{}
+	err = cursor.All(ctx, &found)
+
+	return found, err
+}
+
+func (fs *ForwardService) getContract(ctx context.Context, contractId primitive.ObjectID) (*models.ForwardContract, error) {
+	var contract models.ForwardContract
+
+	err := fs.Client.Collection(utils.ForwardsCollection).FindOne(ctx, bson.M{"_id": contractId}).Decode(&contract)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Contract not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+func (fs *ForwardService) releaseHold(ctx context.Context, holdId primitive.ObjectID) {
+	if err := fs.walletService.ReleaseHold(ctx, holdId); err != nil {
+		fmt.Printf("Error releasing hold %s: %v\n", holdId.Hex(), err)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"time"
 
 	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/outbreak"
 	"github.com/hrutik1235/farming-server/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,16 +16,26 @@ import (
 )
 
 type HarvestService struct {
-	Client        *mongo.Database
-	marketService *MarketService
-	cropService   *CropService
+	Client           *mongo.Database
+	marketService    *MarketService
+	cropService      *CropService
+	warehouseService *WarehouseService
+	soilService      *SoilService
+	adjacencyService *AdjacencyService
+	leaseService     *LeaseService
+	forwardService   *ForwardService
 }
 
 func NewHarvestService(client *mongo.Database) *HarvestService {
 	return &HarvestService{
-		Client:        client,
-		marketService: NewMarketService(client),
-		cropService:   NewCropService(client),
+		Client:           client,
+		marketService:    NewMarketService(client),
+		cropService:      NewCropService(client),
+		warehouseService: NewWarehouseService(client),
+		soilService:      NewSoilService(client),
+		adjacencyService: NewAdjacencyService(client),
+		leaseService:     NewLeaseService(client),
+		forwardService:   NewForwardService(client),
 	}
 }
 
@@ -43,6 +55,117 @@ func (hs *HarvestService) GetPlantedCrop(userId primitive.ObjectID, plantingId p
 	return &plantedCroop, nil
 }
 
+// HarvestCrop fully harvests a grown planting, stores the yield in the warehouse and frees its
+// land. The planting is claimed with its result before anything is stored, so two harvests can't
+// both store the yield. Every part of the yield is stored keyed by the planting or contract, and
+// the planting is only finished once all of it is, so a harvest that fails halfway is finished
+// with the same result by harvesting again.
+func (hs *HarvestService) HarvestCrop(ctx context.Context, userId primitive.ObjectID, plantingId primitive.ObjectID) (*models.HarvestResult, error) {
+	plantedCrop, err := hs.GetPlantedCrop(userId, plantingId)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusNotFound, "Planting not found")
+		}
+		return nil, err
+	}
+
+	harvestResult := plantedCrop.Harvest
+
+	if harvestResult == nil {
+		if err := hs.ValidateHarvest(plantedCrop); err != nil {
+			return nil, NewServiceError(http.StatusBadRequest, "%s", err.Error())
+		}
+
+		harvestResult, err = hs.CalculateHarvestResult(plantedCrop, 1.0)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := hs.leaseService.SplitHarvest(ctx, plantedCrop, harvestResult); err != nil {
+			return nil, err
+		}
+
+		if harvestResult, err = hs.claimHarvest(ctx, plantingId, harvestResult); err != nil {
+			return nil, err
+		}
+	}
+
+	// Forward buyers are delivered out of what the grower keeps, into their own warehouse.
+	if err := hs.forwardService.DeliverHarvest(ctx, plantedCrop, harvestResult); err != nil {
+		return nil, err
+	}
+
+	// Crop-share landowners get their part of the harvest in their own warehouse.
+	for _, share := range harvestResult.Shares {
+		hs.leaseService.DeliverShare(ctx, share, plantedCrop.ID, harvestItem(share.OwnerID, harvestResult))
+	}
+
+	if harvestResult.Quantity > 0 {
+		warehouseItem := harvestItem(userId, harvestResult)
+
+		if err := hs.warehouseService.StoreItemOnce(ctx, &warehouseItem, "HARVEST:"+plantingId.Hex(), ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := hs.FreeLandUnits(ctx, plantedCrop.LandUnitIDs); err != nil {
+		return nil, err
+	}
+
+	if err := hs.soilService.MarkFallow(ctx, plantedCrop.LandUnitIDs); err != nil {
+		fmt.Printf("Error marking land fallow: %v\n", err)
+	}
+
+	if err := hs.MarkCropAsHarvested(plantingId, harvestResult); err != nil {
+		return nil, err
+	}
+
+	shared := 0
+	for _, share := range harvestResult.Shares {
+		shared += share.Quantity
+	}
+
+	forwarded := 0
+	for _, delivery := range harvestResult.Forwards {
+		forwarded += delivery.Quantity
+	}
+
+	recordLeaseActivity(ctx, hs.Client, plantedCrop, "HARVEST", fmt.Sprintf("Harvested %d units at quality %.2f (grade %s), %d of them shared with landowners", harvestResult.Quantity+forwarded+shared, harvestResult.QualityFactor, harvestResult.Grade, shared))
+
+	return harvestResult, nil
+}
+
+// claimHarvest records result as the planting's harvest, provided it is not harvested or being
+// harvested already, and returns the result to store. A harvest claimed by an earlier call that
+// never finished is returned instead, so it is finished as it was claimed.
+func (hs *HarvestService) claimHarvest(ctx context.Context, plantingId primitive.ObjectID, result *models.HarvestResult) (*models.HarvestResult, error) {
+	collection := hs.Client.Collection(utils.PlantedCropsCollection)
+
+	claimed, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": plantingId, "is_harvested": false, "harvest": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"harvest": result, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claimed.ModifiedCount == 1 {
+		return result, nil
+	}
+
+	var planting models.PlantedCrop
+	if err := collection.FindOne(ctx, bson.M{"_id": plantingId}).Decode(&planting); err != nil {
+		return nil, err
+	}
+
+	if planting.IsHarvested || planting.Harvest == nil {
+		return nil, NewServiceError(http.StatusConflict, "crop is already harvested")
+	}
+
+	return planting.Harvest, nil
+}
+
 func (hs *HarvestService) ValidateHarvest(plantedCrop *models.PlantedCrop) error {
 	if plantedCrop.IsHarvested {
 		return fmt.Errorf("crop is already harvested")
@@ -141,9 +264,10 @@ func (hs *HarvestService) CalculateQualityFactor(platedCrop *models.PlantedCrop,
 		partialHarvestEffect = 0.1
 	}
 
-	quantity := baseQuantity - earlyHarvestPenalty + partialHarvestEffect
+	quantity := math.Max(0.1, math.Min(1.0, baseQuantity-earlyHarvestPenalty+partialHarvestEffect))
 
-	return math.Max(0.1, math.Min(1.0, quantity))
+	// Care taken or missed while growing scales the result, which may then exceed 1.0.
+	return math.Max(0.1, math.Min(utils.MaxCareQuality, quantity*careQualityOf(platedCrop)))
 }
 
 func (hs *HarvestService) CalculateHarvestResult(plantedCrop *models.PlantedCrop, harvestPercentage float64) (*models.HarvestResult, error) {
@@ -154,13 +278,36 @@ func (hs *HarvestService) CalculateHarvestResult(plantedCrop *models.PlantedCrop
 		return nil, err
 	}
 
+	// Companion and antagonist neighbours change quality, pollinators nearby add yield.
+	adjacency, err := hs.adjacencyService.Evaluate(context.TODO(), plantedCrop)
+	if err != nil {
+		return nil, err
+	}
+
 	qualityFactor := hs.CalculateQualityFactor(plantedCrop, harvestPercentage)
+	qualityFactor = math.Max(0.1, math.Min(utils.MaxCareQuality, qualityFactor*(1+adjacency.QualityChange)))
 
 	baseYield := plantedCrop.ExpectedYield
 
-	actualYield := int(float64(baseYield) * harvestPercentage * qualityFactor)
+	// Whatever an untreated pest or disease destroyed is lost.
+	damage := outbreak.PlantingDamage(*plantedCrop, time.Now())
+
+	actualYield := int(float64(baseYield) * harvestPercentage * qualityFactor * (1 - damage) * (1 + adjacency.YieldBonus))
+
+	// The harvest is graded under the crop's thresholds and valued at that grade's market price.
+	crop, err := hs.cropService.GetCropById(plantedCrop.CropID)
+	if err != nil {
+		return nil, err
+	}
+
+	grade := utils.QualityGrade(qualityFactor, crop.GradeThresholds)
+
+	gradePrice, err := hs.marketService.GetGradePrice(context.TODO(), plantedCrop.CropID, grade)
+	if err != nil {
+		return nil, err
+	}
 
-	totalValue := float64(actualYield) * currentPrice * qualityFactor
+	totalValue := float64(actualYield) * gradePrice
 
 	return &models.HarvestResult{
 		PlantingID:        plantedCrop.ID,
@@ -170,11 +317,13 @@ func (hs *HarvestService) CalculateHarvestResult(plantedCrop *models.PlantedCrop
 		HarvestPercentage: harvestPercentage,
 		Quantity:          actualYield,
 		QualityFactor:     qualityFactor,
+		Grade:             grade,
 		BasePrice:         currentPrice,
-		ActualPrice:       currentPrice * qualityFactor,
+		ActualPrice:       gradePrice,
 		TotalValue:        totalValue,
 		HarvestedAt:       time.Now(),
 		IsPartial:         false,
+		Adjacency:         adjacency,
 	}, nil
 }
 
@@ -184,7 +333,7 @@ func (hs *HarvestService) MarkCropAsHarvested(plantindId primitive.ObjectID, har
 
 	_, err := collection.UpdateOne(
 		context.TODO(),
-		bson.M{"_id": plantindId},
+		bson.M{"_id": plantindId, "is_harvested": false},
 		bson.M{"$set": bson.M{
 			"is_harvested":   true,
 			"harvested_at":   time.Now(),
@@ -198,7 +347,16 @@ func (hs *HarvestService) MarkCropAsHarvested(plantindId primitive.ObjectID, har
 }
 
 func (hs *HarvestService) AddToWarehouse(ctx context.Context, userId primitive.ObjectID, harvestResult *models.HarvestResult) error {
-	warehouseItem := models.WarehouseItem{
+	warehouseItem := harvestItem(userId, harvestResult)
+
+	err := hs.StoreItemInWareHouse(&warehouseItem)
+
+	return err
+}
+
+// harvestItem is the warehouse stock a harvest result gives userId.
+func harvestItem(userId primitive.ObjectID, harvestResult *models.HarvestResult) models.WarehouseItem {
+	return models.WarehouseItem{
 		UserID:        userId,
 		CropID:        harvestResult.CropID,
 		Quantity:      harvestResult.Quantity,
@@ -207,64 +365,12 @@ func (hs *HarvestService) AddToWarehouse(ctx context.Context, userId primitive.O
 		StoredAt:      time.Now(),
 		ExpiresAt:     time.Now().Add(7 * 24 * time.Hour), // 7 days
 		QualityFactor: harvestResult.QualityFactor,
+		Grade:         harvestResult.Grade,
 		IsExpired:     false,
 		Source:        "harvest",
 	}
-
-	err := hs.StoreItemInWareHouse(&warehouseItem)
-
-	return err
 }
 
 func (hs *HarvestService) StoreItemInWareHouse(warehouseItem *models.WarehouseItem) error {
-
-	collection := hs.Client.Collection(utils.WarehouseCollection)
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	defaultCapacity := 1000
-
-	var warehouse models.Warehouse
-
-	err := collection.FindOne(ctx, bson.M{"user_id": warehouseItem.UserID}).Decode(&warehouse)
-
-	if err == mongo.ErrNoDocuments {
-		newWarehouse := models.Warehouse{
-			BaseModel: models.BaseModel{
-				ID:        primitive.NewObjectID(),
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
-			UserID:        warehouseItem.UserID,
-			TotalCapacity: defaultCapacity,
-			UsedCapacity:  warehouseItem.Quantity,
-			Items:         []models.WarehouseItem{*warehouseItem},
-		}
-
-		_, err := collection.InsertOne(ctx, newWarehouse)
-
-		if err != nil {
-			return err
-		}
-	} else if err != nil {
-		return err
-	} else {
-		if warehouse.UsedCapacity+warehouseItem.Quantity > warehouse.TotalCapacity {
-			return fmt.Errorf("warehouse is full")
-		}
-
-		update := bson.M{
-			"$push": bson.M{"items": warehouseItem},
-			"$inc":  bson.M{"used_capacity": warehouseItem.Quantity},
-			"$set":  bson.M{"updated_at": time.Now()},
-		}
-
-		_, err = collection.UpdateOne(ctx, bson.M{"user_id": warehouseItem.UserID}, update)
-
-		if err != nil {
-			return fmt.Errorf("failed to update warehouse: %v", err)
-		}
-	}
-	return nil
+	return hs.warehouseService.StoreItem(warehouseItem)
 }
@@ -0,0 +1,492 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// landSupplyCounter counts the land units sold; each user's "land:<id>" counter holds the highest
+// position and the number of units they hold.
+const landSupplyCounter = "land_supply"
+
+type landCounter struct {
+	Seq   int `bson:"seq"`
+	Owned int `bson:"owned"`
+}
+
+type LandService struct {
+	Client *mongo.Database
+
+	config        utils.LandConfig
+	cropService   *CropService
+	userService   *UserService
+	walletService *WalletService
+}
+
+func NewLandService(client *mongo.Database) *LandService {
+	return &LandService{
+		Client:        client,
+		config:        utils.LandConfigFromEnv(),
+		cropService:   NewCropService(client),
+		userService:   NewUserService(client),
+		walletService: NewWalletService(client),
+	}
+}
+
+// GetLandMap lays the user's land out on the grid with what is growing on each cell.
+func (ls *LandService) GetLandMap(ctx context.Context, userId primitive.ObjectID) (*models.LandMap, error) {
+	landUnits, err := ls.cropService.findLandUnits(ctx, bson.M{"owner_id": userId})
+	if err != nil {
+		return nil, err
+	}
+
+	plantings, err := ls.getPlantingsOn(ctx, landUnits)
+	if err != nil {
+		return nil, err
+	}
+
+	cropNames := make(map[primitive.ObjectID]string)
+	now := time.Now()
+
+	landMap := &models.LandMap{
+		Width: utils.LandGridWidth,
+		Cells: make([]models.LandCell, 0, len(landUnits)),
+	}
+
+	for _, unit := range landUnits {
+		x, y := GridCell(unit.Position)
+		landMap.Height = max(landMap.Height, y+1)
+
+		cell := models.LandCell{
+			X:           x,
+			Y:           y,
+			Position:    unit.Position,
+			LandUnitID:  unit.ID,
+			IsAvailable: unit.IsAvailable,
+			IsLeased:    unit.IsLeased,
+			LesseeID:    unit.LesseeID,
+			Soil:        CurrentSoil(unit, now),
+		}
+
+		if unit.Structure != nil {
+			cell.Structure = unit.Structure.Kind
+		}
+		cell.ListingID = unit.ListingID
+		cell.AuctionID = unit.AuctionID
+
+		if planting, ok := plantings[unit.ID.Hex()]; ok {
+			if _, ok := cropNames[planting.CropID]; !ok {
+				if crop, err := ls.cropService.GetCropById(planting.CropID); err == nil {
+					cropNames[planting.CropID] = crop.Name
+				}
+			}
+
+			cell.PlantingID = planting.ID
+			cell.CropID = planting.CropID
+			cell.CropName = cropNames[planting.CropID]
+			cell.GrowthPercentage = ls.cropService.CalculateCurrentGrowth(planting)
+		}
+
+		landMap.Cells = append(landMap.Cells, cell)
+	}
+
+	return landMap, nil
+}
+
+// QuoteExpansion prices buying units more land for the user, up to the most land one user may hold.
+func (ls *LandService) QuoteExpansion(ctx context.Context, userId primitive.ObjectID, units int) (*models.LandQuote, error) {
+	if units <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "units must be positive")
+	}
+
+	counter, err := ls.getLandCounter(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked before pricing, which walks every unit.
+	if units > ls.config.MaxUnits-counter.Owned {
+		return nil, NewServiceError(http.StatusBadRequest, "You can hold at most %d land units", ls.config.MaxUnits)
+	}
+
+	sold, err := ls.supplySold(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LandQuote{
+		Units:      units,
+		Price:      ls.config.Price(counter.Owned, units),
+		Owned:      counter.Owned,
+		MaxUnits:   ls.config.MaxUnits,
+		SupplyLeft: max(0, ls.config.Supply-sold),
+	}, nil
+}
+
+// ExpandLand sells the user a block of new land units at the positions after their last one. Each
+// unit costs more the more land the user already holds.
+func (ls *LandService) ExpandLand(ctx context.Context, userId primitive.ObjectID, units int) (*models.LandExpansion, error) {
+	if units <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "units must be positive")
+	}
+
+	user, err := ls.userService.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	counter, err := ls.getLandCounter(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if units > ls.config.MaxUnits-counter.Owned {
+		return nil, NewServiceError(http.StatusBadRequest, "You can hold at most %d land units", ls.config.MaxUnits)
+	}
+
+	price := ls.config.Price(counter.Owned, units)
+
+	if err := ls.reserveSupply(ctx, units); err != nil {
+		return nil, err
+	}
+
+	claimed, err := ls.claimPositions(ctx, userId, counter, units)
+	if err != nil {
+		ls.releaseSupply(ctx, units)
+		return nil, err
+	}
+
+	referenceId := primitive.NewObjectID().Hex()
+	description := fmt.Sprintf("Bought %d land units", units)
+
+	if price > 0 {
+		if _, err := ls.walletService.Debit(ctx, userId, price, "LAND_PURCHASE", description, referenceId); err != nil {
+			ls.unclaimPositions(ctx, userId, claimed, units)
+			ls.releaseSupply(ctx, units)
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	landUnits := make([]models.LandUnit, 0, units)
+	documents := make([]interface{}, 0, units)
+
+	for position := claimed.Seq - units + 1; position <= claimed.Seq; position++ {
+		unit := models.LandUnit{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			},
+			Land:        fmt.Sprintf("%s_land", user.Username),
+			OwnerID:     userId,
+			SizeUnits:   1,
+			Position:    position,
+			IsAvailable: true,
+		}
+
+		landUnits = append(landUnits, unit)
+		documents = append(documents, unit)
+	}
+
+	if _, err := ls.Client.Collection(utils.LandUnitsCollection).InsertMany(ctx, documents); err != nil {
+		if price > 0 {
+			if _, refundErr := ls.walletService.Credit(ctx, userId, price, "LAND_REFUND", description, referenceId); refundErr != nil {
+				fmt.Printf("Error refunding land purchase %s: %v\n", referenceId, refundErr)
+			}
+		}
+
+		ls.unclaimPositions(ctx, userId, claimed, units)
+		ls.releaseSupply(ctx, units)
+		return nil, err
+	}
+
+	return &models.LandExpansion{
+		LandUnits: landUnits,
+		Price:     price,
+		Owned:     claimed.Owned,
+	}, nil
+}
+
+// getLandCounter returns the user's land counter, starting it from the land they hold on first use.
+func (ls *LandService) getLandCounter(ctx context.Context, userId primitive.ObjectID) (*landCounter, error) {
+	counters := ls.Client.Collection(utils.CountersCollection)
+	key := landCounterKey(userId)
+
+	var counter landCounter
+
+	err := counters.FindOne(ctx, bson.M{"_id": key}).Decode(&counter)
+	if err != mongo.ErrNoDocuments {
+		return &counter, err
+	}
+
+	landUnits, err := ls.cropService.findLandUnits(ctx, bson.M{"owner_id": userId})
+	if err != nil {
+		return nil, err
+	}
+
+	// Positions past the starter plot would clash with the ones onboarding has yet to insert.
+	if len(landUnits) < utils.InitialLandUnitSize {
+		return nil, NewServiceError(http.StatusConflict, "Your starter land is still being set up")
+	}
+
+	counter = landCounter{Seq: landUnits[len(landUnits)-1].Position, Owned: len(landUnits)}
+
+	_, err = counters.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$setOnInsert": counter}, options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	err = counters.FindOne(ctx, bson.M{"_id": key}).Decode(&counter)
+
+	return &counter, err
+}
+
+// claimPositions takes the next positions for units more land on the user's grid. Claiming against
+// the holdings the price was worked out from stops two purchases sharing them; the claimed
+// positions run up to the returned counter's Seq.
+func (ls *LandService) claimPositions(ctx context.Context, userId primitive.ObjectID, counter *landCounter, units int) (*landCounter, error) {
+	var claimed landCounter
+
+	err := ls.Client.Collection(utils.CountersCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": landCounterKey(userId), "owned": counter.Owned},
+		bson.M{"$inc": bson.M{"seq": units, "owned": units}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&claimed)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusConflict, "Your land changed, try again")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &claimed, nil
+}
+
+// unclaimPositions gives back positions claimed for a purchase that failed. They are reused only
+// when no later purchase claimed positions after them.
+func (ls *LandService) unclaimPositions(ctx context.Context, userId primitive.ObjectID, claimed *landCounter, units int) {
+	counters := ls.Client.Collection(utils.CountersCollection)
+	key := landCounterKey(userId)
+
+	result, err := counters.UpdateOne(ctx, bson.M{"_id": key, "seq": claimed.Seq}, bson.M{"$inc": bson.M{"seq": -units, "owned": -units}})
+	if err == nil && result.ModifiedCount == 0 {
+		_, err = counters.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$inc": bson.M{"owned": -units}})
+	}
+
+	if err != nil {
+		fmt.Printf("Error unclaiming land of user %s: %v\n", userId.Hex(), err)
+	}
+}
+
+// dropOwned lowers the seller's holdings after a unit was transferred away.
+func (ls *LandService) dropOwned(ctx context.Context, userId primitive.ObjectID) error {
+	_, err := ls.Client.Collection(utils.CountersCollection).UpdateOne(ctx, bson.M{"_id": landCounterKey(userId)}, bson.M{"$inc": bson.M{"owned": -1}})
+
+	return err
+}
+
+// reserveSupply takes units from the land the server has left for sale.
+func (ls *LandService) reserveSupply(ctx context.Context, units int) error {
+	counters := ls.Client.Collection(utils.CountersCollection)
+
+	_, err := counters.UpdateOne(ctx, bson.M{"_id": landSupplyCounter}, bson.M{"$setOnInsert": bson.M{"seq": 0}}, options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	result, err := counters.UpdateOne(
+		ctx,
+		bson.M{"_id": landSupplyCounter, "seq": bson.M{"$lte": ls.config.Supply - units}},
+		bson.M{"$inc": bson.M{"seq": units}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.ModifiedCount == 0 {
+		return NewServiceError(http.StatusConflict, "Not enough land left for sale")
+	}
+
+	return nil
+}
+
+func (ls *LandService) releaseSupply(ctx context.Context, units int) {
+	_, err := ls.Client.Collection(utils.CountersCollection).UpdateOne(ctx, bson.M{"_id": landSupplyCounter}, bson.M{"$inc": bson.M{"seq": -units}})
+	if err != nil {
+		fmt.Printf("Error releasing %d units of land supply: %v\n", units, err)
+	}
+}
+
+func (ls *LandService) supplySold(ctx context.Context) (int, error) {
+	var counter landCounter
+
+	err := ls.Client.Collection(utils.CountersCollection).FindOne(ctx, bson.M{"_id": landSupplyCounter}).Decode(&counter)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+
+	return counter.Seq, err
+}
+
+func landCounterKey(userId primitive.ObjectID) string {
+	return "land:" + userId.Hex()
+}
+
+// BuildStructure builds a structure on a free land unit the user owns and has not leased out. The
+// unit is taken before the cost is paid and given back if paying fails.
+func (ls *LandService) BuildStructure(ctx context.Context, userId primitive.ObjectID, landUnitId primitive.ObjectID, kind string) (*models.LandUnit, error) {
+	spec, ok := structureSpecs[kind]
+	if !ok {
+		return nil, NewServiceError(http.StatusBadRequest, "unknown structure %q", kind)
+	}
+
+	unit, err := ls.getOwnLandUnit(ctx, userId, landUnitId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case unit.IsLeased:
+		return nil, NewServiceError(http.StatusConflict, "Land unit is leased out")
+	case unit.Structure != nil:
+		return nil, NewServiceError(http.StatusConflict, "Land unit already has a %s", strings.ToLower(unit.Structure.Kind))
+	case !unit.IsAvailable:
+		return nil, NewServiceError(http.StatusConflict, "Land unit is occupied")
+	}
+
+	now := time.Now()
+	collection := ls.Client.Collection(utils.LandUnitsCollection)
+
+	var updated models.LandUnit
+
+	err = collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": unit.ID, "owner_id": userId, "is_leased": bson.M{"$ne": true}, "is_available": true},
+		bson.M{"$set": bson.M{
+			"structure":    models.Structure{Kind: kind, BuiltAt: now},
+			"is_available": false,
+			"updated_at":   now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusConflict, "Land unit changed, try again")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Cost > 0 {
+		description := fmt.Sprintf("Built a %s", strings.ToLower(kind))
+
+		if _, err := ls.walletService.Debit(ctx, userId, spec.Cost, "STRUCTURE", description, unit.ID.Hex()); err != nil {
+			if _, revertErr := ls.clearStructure(ctx, userId, unit.ID); revertErr != nil {
+				fmt.Printf("Error removing unpaid structure from land unit %s: %v\n", unit.ID.Hex(), revertErr)
+			}
+			return nil, err
+		}
+	}
+
+	return &updated, nil
+}
+
+// RemoveStructure takes down the structure on the user's land unit and frees the unit. Nothing is refunded.
+func (ls *LandService) RemoveStructure(ctx context.Context, userId primitive.ObjectID, landUnitId primitive.ObjectID) error {
+	removed, err := ls.clearStructure(ctx, userId, landUnitId)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return NewServiceError(http.StatusNotFound, "No structure on land unit")
+	}
+
+	return nil
+}
+
+func (ls *LandService) clearStructure(ctx context.Context, userId primitive.ObjectID, landUnitId primitive.ObjectID) (bool, error) {
+	result, err := ls.Client.Collection(utils.LandUnitsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": landUnitId, "owner_id": userId, "structure": bson.M{"$exists": true}},
+		bson.M{
+			"$unset": bson.M{"structure": ""},
+			"$set":   bson.M{"is_available": true, "updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+func (ls *LandService) getOwnLandUnit(ctx context.Context, userId primitive.ObjectID, landUnitId primitive.ObjectID) (*models.LandUnit, error) {
+	var unit models.LandUnit
+
+	err := ls.Client.Collection(utils.LandUnitsCollection).FindOne(ctx, bson.M{"_id": landUnitId, "owner_id": userId}).Decode(&unit)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Land unit not found")
+	}
+
+	return &unit, err
+}
+
+// getPlantingsOn returns the unharvested plantings on the land units, by land unit id.
+func (ls *LandService) getPlantingsOn(ctx context.Context, landUnits []models.LandUnit) (map[string]models.PlantedCrop, error) {
+	byUnit := make(map[string]models.PlantedCrop)
+	if len(landUnits) == 0 {
+		return byUnit, nil
+	}
+
+	cursor, err := ls.Client.Collection(utils.PlantedCropsCollection).Find(
+		ctx,
+		bson.M{
+			"land_unit_ids": bson.M{"$in": ls.cropService.GetLandUnitIDs(landUnits)},
+			"is_active":     true,
+			"is_harvested":  false,
+		},
+		options.Find().SetProjection(bson.M{"partial_harvests": 0}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var plantings []models.PlantedCrop
+	if err := cursor.All(ctx, &plantings); err != nil {
+		return nil, err
+	}
+
+	for _, planting := range plantings {
+		for _, unitId := range planting.LandUnitIDs {
+			byUnit[unitId] = planting
+		}
+	}
+
+	return byUnit, nil
+}
+
+// GridCell returns the zero based column and row of a land position.
+func GridCell(position int) (x int, y int) {
+	return (position - 1) % utils.LandGridWidth, (position - 1) / utils.LandGridWidth
+}
+
+// GridPosition returns the land position of a grid cell.
+func GridPosition(x int, y int) int {
+	return y*utils.LandGridWidth + x + 1
+}
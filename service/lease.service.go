@@ -0,0 +1,695 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const rentPeriod = 24 * time.Hour
+
+type LeaseService struct {
+	Client *mongo.Database
+
+	cropService      *CropService
+	walletService    *WalletService
+	warehouseService *WarehouseService
+	eventService     *EventService
+}
+
+func NewLeaseService(client *mongo.Database) *LeaseService {
+	return &LeaseService{
+		Client:           client,
+		cropService:      NewCropService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+		eventService:     NewEventService(client),
+	}
+}
+
+// RequestLease asks the owner of the land units to lease them to the tenant. All the units must
+// belong to one owner.
+func (ls *LeaseService) RequestLease(ctx context.Context, tenantId primitive.ObjectID, body types.RequestLease) (*models.Lease, error) {
+	terms := body.Terms
+	if terms == "" {
+		terms = utils.LeaseFlat
+	}
+
+	switch {
+	case terms == utils.LeaseFlat && body.LeasePrice <= 0:
+		return nil, NewServiceError(http.StatusBadRequest, "lease_price must be positive for FLAT leases")
+	case terms == utils.LeaseRent && body.DailyRent <= 0:
+		return nil, NewServiceError(http.StatusBadRequest, "daily_rent must be positive for RENT leases")
+	case terms == utils.LeaseCropShare && body.OwnerSharePercent <= 0:
+		return nil, NewServiceError(http.StatusBadRequest, "owner_share_percent must be positive for CROP_SHARE leases")
+	}
+
+	landUnits, err := ls.cropService.findLandUnitsById(ctx, body.LandUnitIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerId := landUnits[0].OwnerID
+
+	for _, unit := range landUnits {
+		switch {
+		case unit.OwnerID != ownerId:
+			return nil, NewServiceError(http.StatusBadRequest, "all land units must belong to one owner")
+		case unit.OwnerID == tenantId:
+			return nil, NewServiceError(http.StatusBadRequest, "You can't lease your own land")
+		case unit.IsLeased:
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is already leased", unit.ID.Hex())
+		case !unit.AuctionID.IsZero():
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is up for lease auction", unit.ID.Hex())
+		}
+	}
+
+	now := time.Now()
+	id := primitive.NewObjectID()
+
+	lease := models.Lease{
+		BaseModel: models.BaseModel{
+			ID:        id,
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		LeaseID:        id.Hex(),
+		LandownerID:    ownerId.Hex(),
+		TenantID:       tenantId.Hex(),
+		LandUnitIDs:    body.LandUnitIDs,
+		TotalLandUnits: len(landUnits),
+		Status:         utils.LeasePending,
+		DurationDays:   body.DurationDays,
+		Terms:          terms,
+		RequestedAt:    now,
+	}
+
+	switch terms {
+	case utils.LeaseFlat:
+		lease.LeasePrice = body.LeasePrice
+	case utils.LeaseRent:
+		lease.DailyRent = body.DailyRent
+	case utils.LeaseCropShare:
+		lease.OwnerSharePercent = body.OwnerSharePercent
+	}
+
+	if _, err := ls.Client.Collection(utils.LeasesCollection).InsertOne(ctx, lease); err != nil {
+		return nil, err
+	}
+
+	ls.eventService.PublishQuietly(ctx, ownerId, utils.EventLeaseRequest, types.LeaseRequestPayload{
+		LeaseID:     lease.LeaseID,
+		RequesterID: lease.TenantID,
+		LandUnits:   lease.TotalLandUnits,
+		Price:       lease.LeasePrice,
+		Terms:       lease.Terms,
+	})
+
+	return &lease, nil
+}
+
+// GetLeases returns the leases the user is the landowner or the tenant of, newest first.
+func (ls *LeaseService) GetLeases(ctx context.Context, userId primitive.ObjectID) ([]models.Lease, error) {
+	cursor, err := ls.Client.Collection(utils.LeasesCollection).Find(
+		ctx,
+		bson.M{"$or": []bson.M{{"landowner_id": userId.Hex()}, {"tenant_id": userId.Hex()}}},
+		options.Find().SetSort(bson.M{"requested_at": -1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	leases := []models.Lease{}
+	err = cursor.All(ctx, &leases)
+
+	return leases, err
+}
+
+// AcceptLease hands the land units of a pending lease to the tenant. FLAT leases are paid in full
+// and RENT leases pay their first day now; if the land is no longer free or the tenant can't pay,
+// the lease is rejected.
+func (ls *LeaseService) AcceptLease(ctx context.Context, ownerId primitive.ObjectID, leaseId string) (*models.Lease, error) {
+	lease, err := ls.getLease(ctx, leaseId)
+	if err != nil {
+		return nil, err
+	}
+
+	if lease.LandownerID != ownerId.Hex() {
+		return nil, NewServiceError(http.StatusForbidden, "Only the landowner can accept a lease")
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"status":      utils.LeaseActive,
+		"accepted_at": now,
+		"start_time":  now,
+		"end_time":    now.AddDate(0, 0, lease.DurationDays),
+		"updated_at":  now,
+	}
+
+	if lease.Terms == utils.LeaseRent {
+		set["rent_paid_days"] = 1
+		set["next_rent_at"] = now.Add(rentPeriod)
+	}
+
+	// Activating first means a tenant cancelling meanwhile can't leave land leased to nobody.
+	err = ls.Client.Collection(utils.LeasesCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"lease_id": lease.LeaseID, "status": utils.LeasePending},
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(lease)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusConflict, "Lease is no longer pending")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tenantId, _ := primitive.ObjectIDFromHex(lease.TenantID)
+
+	if err := ls.claimLandUnits(ctx, lease, ownerId, tenantId); err != nil {
+		ls.endLease(ctx, lease, utils.LeaseActive, utils.LeaseRejected, "land is no longer free")
+		return nil, err
+	}
+
+	payment, category := lease.LeasePrice, "LEASE_PAYMENT"
+	switch lease.Terms {
+	case utils.LeaseRent:
+		payment, category = lease.DailyRent, "LEASE_RENT"
+	case utils.LeaseCropShare:
+		payment = 0
+	}
+
+	if payment > 0 {
+		if err := ls.pay(ctx, lease, tenantId, ownerId, payment, category, category+":1"); err != nil {
+			ls.releaseLandUnits(ctx, lease)
+			ls.endLease(ctx, lease, utils.LeaseActive, utils.LeaseRejected, "tenant could not pay")
+			return nil, err
+		}
+	}
+
+	return lease, nil
+}
+
+// RejectLease lets the landowner turn a pending lease down.
+func (ls *LeaseService) RejectLease(ctx context.Context, ownerId primitive.ObjectID, leaseId string) (*models.Lease, error) {
+	return ls.closePending(ctx, bson.M{"lease_id": leaseId, "landowner_id": ownerId.Hex()}, utils.LeaseRejected, "rejected by landowner")
+}
+
+// CancelLease lets the tenant withdraw a pending lease request.
+func (ls *LeaseService) CancelLease(ctx context.Context, tenantId primitive.ObjectID, leaseId string) (*models.Lease, error) {
+	return ls.closePending(ctx, bson.M{"lease_id": leaseId, "tenant_id": tenantId.Hex()}, utils.LeaseCancelled, "cancelled by tenant")
+}
+
+// ChargeRent charges each RENT lease for every day that has come due. A tenant who can't pay
+// loses the lease.
+func (ls *LeaseService) ChargeRent(ctx context.Context) error {
+	now := time.Now()
+
+	cursor, err := ls.Client.Collection(utils.LeasesCollection).Find(ctx, bson.M{
+		"status":       utils.LeaseActive,
+		"terms":        utils.LeaseRent,
+		"next_rent_at": bson.M{"$lte": now},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var leases []models.Lease
+	if err := cursor.All(ctx, &leases); err != nil {
+		return err
+	}
+
+	for i := range leases {
+		lease := &leases[i]
+
+		for lease.RentPaidDays < lease.DurationDays && !lease.NextRentAt.After(now) {
+			if !ls.chargeDay(ctx, lease) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// chargeDay charges one day of rent, ending the lease if the tenant can't pay it, and reports
+// whether to go on charging. Any other failure hands the day back for the next run.
+func (ls *LeaseService) chargeDay(ctx context.Context, lease *models.Lease) bool {
+	next := lease.NextRentAt.Add(rentPeriod)
+
+	// Claiming the day first keeps two workers from charging it twice.
+	result, err := ls.Client.Collection(utils.LeasesCollection).UpdateOne(
+		ctx,
+		bson.M{"lease_id": lease.LeaseID, "status": utils.LeaseActive, "rent_paid_days": lease.RentPaidDays},
+		bson.M{
+			"$inc": bson.M{"rent_paid_days": 1},
+			"$set": bson.M{"next_rent_at": next, "updated_at": time.Now()},
+		},
+	)
+	if err != nil || result.ModifiedCount == 0 {
+		if err != nil {
+			fmt.Printf("Error charging rent for lease %s: %v\n", lease.LeaseID, err)
+		}
+		return false
+	}
+
+	tenantId, _ := primitive.ObjectIDFromHex(lease.TenantID)
+	ownerId, _ := primitive.ObjectIDFromHex(lease.LandownerID)
+
+	err = ls.pay(ctx, lease, tenantId, ownerId, lease.DailyRent, "LEASE_RENT", fmt.Sprintf("LEASE_RENT:%d", lease.RentPaidDays+1))
+	if serviceErr, ok := err.(*ServiceError); ok && serviceErr.Status == http.StatusBadRequest {
+		fmt.Printf("Terminating lease %s: %v\n", lease.LeaseID, err)
+
+		ls.endLease(ctx, lease, utils.LeaseActive, utils.LeaseTerminated, "rent not paid")
+		ls.releaseLandUnits(ctx, lease)
+		return false
+	}
+	if err != nil {
+		// The rent is keyed by the day, so handing the day back lets the next run charge it again.
+		fmt.Printf("Error charging rent for lease %s, retrying later: %v\n", lease.LeaseID, err)
+
+		_, err = ls.Client.Collection(utils.LeasesCollection).UpdateOne(
+			ctx,
+			bson.M{"lease_id": lease.LeaseID, "status": utils.LeaseActive, "rent_paid_days": lease.RentPaidDays + 1},
+			bson.M{
+				"$inc": bson.M{"rent_paid_days": -1},
+				"$set": bson.M{"next_rent_at": lease.NextRentAt, "updated_at": time.Now()},
+			},
+		)
+		if err != nil {
+			fmt.Printf("Error handing back rent day of lease %s: %v\n", lease.LeaseID, err)
+		}
+		return false
+	}
+
+	lease.RentPaidDays++
+	lease.NextRentAt = next
+
+	return true
+}
+
+// ExpireLeases ends active leases whose term is over and gives the land back to its owner.
+// Plantings already on it stay until they are harvested.
+func (ls *LeaseService) ExpireLeases(ctx context.Context) error {
+	cursor, err := ls.Client.Collection(utils.LeasesCollection).Find(ctx, bson.M{
+		"status":   utils.LeaseActive,
+		"end_time": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var leases []models.Lease
+	if err := cursor.All(ctx, &leases); err != nil {
+		return err
+	}
+
+	for i := range leases {
+		if ls.endLease(ctx, &leases[i], utils.LeaseActive, utils.LeaseExpired, "") {
+			ls.releaseLandUnits(ctx, &leases[i])
+		}
+	}
+
+	return nil
+}
+
+// SplitHarvest gives landowners their crop-share of a harvest. A lease counts for a planting
+// started while it was active, even if the harvest comes after it ended. Each share is taken from
+// the part of the harvest grown on that lease's units, and the tenant keeps the rest.
+func (ls *LeaseService) SplitHarvest(ctx context.Context, planting *models.PlantedCrop, result *models.HarvestResult) error {
+	leases, err := leasesCovering(ctx, ls.Client, planting)
+	if err != nil {
+		return err
+	}
+
+	harvested := result.Quantity
+
+	for _, lease := range leases {
+		if lease.Terms != utils.LeaseCropShare {
+			continue
+		}
+
+		leased := 0
+		for _, unitId := range planting.LandUnitIDs {
+			if slices.Contains(lease.LandUnitIDs, unitId) {
+				leased++
+			}
+		}
+
+		share := int(math.Floor(float64(harvested) * float64(leased) / float64(len(planting.LandUnitIDs)) * lease.OwnerSharePercent / 100))
+		if share <= 0 {
+			continue
+		}
+
+		ownerId, _ := primitive.ObjectIDFromHex(lease.LandownerID)
+
+		result.Shares = append(result.Shares, models.HarvestShare{
+			LeaseID:  lease.LeaseID,
+			OwnerID:  ownerId,
+			Quantity: share,
+		})
+		result.Quantity -= share
+	}
+
+	result.TotalValue = float64(result.Quantity) * result.ActualPrice
+
+	return nil
+}
+
+// GetActivity returns a page of what the tenant did on the lease's land, newest first, to either
+// party of the lease.
+func (ls *LeaseService) GetActivity(ctx context.Context, userId primitive.ObjectID, leaseId string, page int, limit int) ([]models.LeaseActivity, int64, error) {
+	lease, err := ls.getLease(ctx, leaseId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if lease.LandownerID != userId.Hex() && lease.TenantID != userId.Hex() {
+		return nil, 0, NewServiceError(http.StatusForbidden, "Only the landowner and the tenant can see a lease's activity")
+	}
+
+	if page < 1 || limit < 1 || limit > activityPageSize {
+		return nil, 0, NewServiceError(http.StatusBadRequest, "page must be positive and limit between 1 and %d", activityPageSize)
+	}
+
+	collection := ls.Client.Collection(utils.LeaseActivitiesCollection)
+	filter := bson.M{"lease_id": lease.LeaseID}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := collection.Find(
+		ctx,
+		filter,
+		options.Find().
+			SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+			SetSkip(int64((page-1)*limit)).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	activities := []models.LeaseActivity{}
+	err = cursor.All(ctx, &activities)
+
+	return activities, total, err
+}
+
+// leasesCovering returns the tenant's leases the planting was started under.
+func leasesCovering(ctx context.Context, client *mongo.Database, planting *models.PlantedCrop) ([]models.Lease, error) {
+	cursor, err := client.Collection(utils.LeasesCollection).Find(ctx, bson.M{
+		"tenant_id":     planting.UserID.Hex(),
+		"land_unit_ids": bson.M{"$in": planting.LandUnitIDs},
+		"status":        bson.M{"$in": []string{utils.LeaseActive, utils.LeaseExpired, utils.LeaseTerminated}},
+		"start_time":    bson.M{"$lte": planting.PlantedAt},
+		"end_time":      bson.M{"$gte": planting.PlantedAt},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var leases []models.Lease
+	err = cursor.All(ctx, &leases)
+
+	return leases, err
+}
+
+// recordLeaseActivity logs something the tenant did with a planting on each lease it was started
+// under. Plantings on the tenant's own land have none.
+func recordLeaseActivity(ctx context.Context, client *mongo.Database, planting *models.PlantedCrop, activityType string, description string) {
+	leases, err := leasesCovering(ctx, client, planting)
+	if err != nil {
+		fmt.Printf("Error finding leases of planting %s: %v\n", planting.ID.Hex(), err)
+		return
+	}
+
+	now := time.Now()
+
+	for _, lease := range leases {
+		activity := models.LeaseActivity{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			},
+			LeaseID:      lease.LeaseID,
+			ActivityType: activityType,
+			TenantID:     lease.TenantID,
+			PlantingID:   planting.ID.Hex(),
+			CropID:       planting.CropID.Hex(),
+			Description:  description,
+			Timestamp:    now,
+		}
+
+		if _, err := client.Collection(utils.LeaseActivitiesCollection).InsertOne(ctx, activity); err != nil {
+			fmt.Printf("Error recording %s activity for lease %s: %v\n", activityType, lease.LeaseID, err)
+		}
+	}
+}
+
+// claimLandUnits marks the lease's units leased to the tenant, provided each is still the owner's,
+// free and not listed for sale.
+func (ls *LeaseService) claimLandUnits(ctx context.Context, lease *models.Lease, ownerId primitive.ObjectID, tenantId primitive.ObjectID) error {
+	landObjectIds, err := utils.ConvertObjectIdsFromStringIds(lease.LandUnitIDs)
+	if err != nil {
+		return err
+	}
+
+	collection := ls.Client.Collection(utils.LandUnitsCollection)
+	claimed := make([]string, 0, len(landObjectIds))
+
+	for _, id := range landObjectIds {
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{
+				"_id":          id,
+				"owner_id":     ownerId,
+				"is_available": true,
+				"is_leased":    bson.M{"$ne": true},
+				"structure":    bson.M{"$exists": false},
+				"listing_id":   bson.M{"$exists": false},
+			},
+			bson.M{"$set": bson.M{"is_leased": true, "lessee_id": tenantId, "updated_at": time.Now()}},
+		)
+
+		if err == nil && result.ModifiedCount == 0 {
+			err = NewServiceError(http.StatusConflict, "land unit %s is not free to lease", id.Hex())
+		}
+
+		if err != nil {
+			ls.releaseLandUnits(ctx, &models.Lease{LandUnitIDs: claimed, TenantID: lease.TenantID})
+			return err
+		}
+
+		claimed = append(claimed, id.Hex())
+	}
+
+	return nil
+}
+
+// releaseLandUnits gives the lease's units back to their owner.
+func (ls *LeaseService) releaseLandUnits(ctx context.Context, lease *models.Lease) {
+	if len(lease.LandUnitIDs) == 0 {
+		return
+	}
+
+	landObjectIds, _ := utils.ConvertObjectIdsFromStringIds(lease.LandUnitIDs)
+	tenantId, _ := primitive.ObjectIDFromHex(lease.TenantID)
+
+	_, err := ls.Client.Collection(utils.LandUnitsCollection).UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": landObjectIds}, "is_leased": true, "lessee_id": tenantId},
+		bson.M{
+			"$set":   bson.M{"is_leased": false, "updated_at": time.Now()},
+			"$unset": bson.M{"lessee_id": ""},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error releasing land of lease %s: %v\n", lease.LeaseID, err)
+	}
+}
+
+// pay takes amount from the tenant and pays it to the landowner once, under key. If the landowner
+// can't be paid right away the payment is queued on the lease, so the tenant is never charged for
+// nothing.
+func (ls *LeaseService) pay(ctx context.Context, lease *models.Lease, tenantId primitive.ObjectID, ownerId primitive.ObjectID, amount float64, category string, key string) error {
+	description := fmt.Sprintf("Lease of %d land units", lease.TotalLandUnits)
+
+	if err := ls.walletService.DebitOnce(ctx, tenantId, amount, category, description, lease.LeaseID+":"+key); err != nil {
+		return err
+	}
+
+	payout := models.LeasePayout{
+		Key:         lease.LeaseID + ":" + key,
+		OwnerID:     ownerId.Hex(),
+		Amount:      amount,
+		Description: description,
+	}
+
+	if err := ls.payOut(ctx, payout); err != nil {
+		fmt.Printf("Error paying landowner of lease %s, queueing the payment: %v\n", lease.LeaseID, err)
+		ls.queuePayout(ctx, lease.LeaseID, payout)
+	}
+
+	return nil
+}
+
+// DeliverShare stores a crop share in the landowner's warehouse, queueing it on the lease if it
+// can't be stored right away.
+func (ls *LeaseService) DeliverShare(ctx context.Context, share models.HarvestShare, plantingId primitive.ObjectID, item models.WarehouseItem) {
+	item.UserID = share.OwnerID
+	item.Quantity = share.Quantity
+
+	payout := models.LeasePayout{
+		Key:         share.LeaseID + ":SHARE:" + plantingId.Hex(),
+		OwnerID:     share.OwnerID.Hex(),
+		Item:        &item,
+		Description: fmt.Sprintf("Crop share of lease %s", share.LeaseID),
+	}
+
+	if err := ls.payOut(ctx, payout); err != nil {
+		fmt.Printf("Error storing crop share of lease %s, queueing it: %v\n", share.LeaseID, err)
+		ls.queuePayout(ctx, share.LeaseID, payout)
+	}
+}
+
+// RetryPayouts makes the landowner payments queued on leases, dropping each once it is made.
+func (ls *LeaseService) RetryPayouts(ctx context.Context) error {
+	collection := ls.Client.Collection(utils.LeasesCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{"pending_payouts.0": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var leases []models.Lease
+	if err := cursor.All(ctx, &leases); err != nil {
+		return err
+	}
+
+	for _, lease := range leases {
+		for _, payout := range lease.PendingPayouts {
+			if err := ls.payOut(ctx, payout); err != nil {
+				fmt.Printf("Error retrying payment %s of lease %s: %v\n", payout.Key, lease.LeaseID, err)
+				continue
+			}
+
+			_, err := collection.UpdateOne(
+				ctx,
+				bson.M{"lease_id": lease.LeaseID},
+				bson.M{"$pull": bson.M{"pending_payouts": bson.M{"key": payout.Key}}},
+			)
+			if err != nil {
+				fmt.Printf("Error dropping paid payment %s of lease %s: %v\n", payout.Key, lease.LeaseID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// payOut makes one landowner payment; repeating it after it succeeded changes nothing.
+func (ls *LeaseService) payOut(ctx context.Context, payout models.LeasePayout) error {
+	if payout.Item != nil {
+		return ls.warehouseService.StoreItemOnce(ctx, payout.Item, "LEASE_PAYOUT:"+payout.Key, "")
+	}
+
+	ownerId, err := primitive.ObjectIDFromHex(payout.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	return ls.walletService.CreditOnce(ctx, ownerId, payout.Amount, "LEASE_INCOME", payout.Description, payout.Key)
+}
+
+func (ls *LeaseService) queuePayout(ctx context.Context, leaseId string, payout models.LeasePayout) {
+	_, err := ls.Client.Collection(utils.LeasesCollection).UpdateOne(
+		ctx,
+		bson.M{"lease_id": leaseId, "pending_payouts.key": bson.M{"$ne": payout.Key}},
+		bson.M{"$push": bson.M{"pending_payouts": payout}},
+	)
+	if err != nil {
+		fmt.Printf("Error queueing payment %s of lease %s: %v\n", payout.Key, leaseId, err)
+	}
+}
+
+// endLease moves the lease from status to the final status and reports whether it did.
+func (ls *LeaseService) endLease(ctx context.Context, lease *models.Lease, from string, to string, reason string) bool {
+	set := bson.M{"status": to, "updated_at": time.Now()}
+	if reason != "" {
+		set["reason"] = reason
+	}
+
+	result, err := ls.Client.Collection(utils.LeasesCollection).UpdateOne(
+		ctx,
+		bson.M{"lease_id": lease.LeaseID, "status": from},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		fmt.Printf("Error ending lease %s: %v\n", lease.LeaseID, err)
+		return false
+	}
+
+	return result.ModifiedCount == 1
+}
+
+func (ls *LeaseService) closePending(ctx context.Context, filter bson.M, status string, reason string) (*models.Lease, error) {
+	filter["status"] = utils.LeasePending
+
+	var lease models.Lease
+
+	err := ls.Client.Collection(utils.LeasesCollection).FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"status": status, "reason": reason, "updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&lease)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Pending lease not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lease, nil
+}
+
+func (ls *LeaseService) getLease(ctx context.Context, leaseId string) (*models.Lease, error) {
+	var lease models.Lease
+
+	err := ls.Client.Collection(utils.LeasesCollection).FindOne(ctx, bson.M{"lease_id": leaseId}).Decode(&lease)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Lease not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lease, nil
+}
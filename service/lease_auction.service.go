@@ -0,0 +1,527 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// A bid must beat the highest one by this fraction of it
+	auctionMinIncrement = 0.05
+
+	// A bid arriving this close to the end pushes the end back to this long after the bid
+	auctionSnipeWindow = 2 * time.Minute
+)
+
+type LeaseAuctionService struct {
+	Client *mongo.Database
+
+	leaseService  *LeaseService
+	walletService *WalletService
+	eventService  *EventService
+}
+
+func NewLeaseAuctionService(client *mongo.Database) *LeaseAuctionService {
+	return &LeaseAuctionService{
+		Client:        client,
+		leaseService:  NewLeaseService(client),
+		walletService: NewWalletService(client),
+		eventService:  NewEventService(client),
+	}
+}
+
+// CreateAuction puts a lease on the owner's land units up for auction. The units can't be planted,
+// leased, listed or built on until the auction closes or is cancelled.
+func (as *LeaseAuctionService) CreateAuction(ctx context.Context, ownerId primitive.ObjectID, body types.CreateLeaseAuction) (*models.LeaseAuction, error) {
+	landUnits, err := as.leaseService.cropService.findLandUnitsById(ctx, body.LandUnitIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, unit := range landUnits {
+		switch {
+		case unit.OwnerID != ownerId:
+			return nil, NewServiceError(http.StatusForbidden, "land unit %s is not yours", unit.ID.Hex())
+		case unit.IsLeased:
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is leased out", unit.ID.Hex())
+		case !unit.ListingID.IsZero():
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is listed for sale", unit.ID.Hex())
+		case !unit.AuctionID.IsZero():
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is already up for auction", unit.ID.Hex())
+		case unit.Structure != nil:
+			return nil, NewServiceError(http.StatusConflict, "land unit %s has a %s on it", unit.ID.Hex(), unit.Structure.Kind)
+		case !unit.IsAvailable:
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is planted", unit.ID.Hex())
+		}
+	}
+
+	now := time.Now()
+
+	auction := models.LeaseAuction{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		OwnerID:      ownerId,
+		LandUnitIDs:  make([]primitive.ObjectID, 0, len(landUnits)),
+		DurationDays: body.DurationDays,
+		ReservePrice: body.ReservePrice,
+		Status:       utils.AuctionOpen,
+		EndsAt:       now.Add(time.Duration(body.DurationMinutes) * time.Minute),
+	}
+
+	collection := as.Client.Collection(utils.LandUnitsCollection)
+
+	for _, unit := range landUnits {
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{
+				"_id":          unit.ID,
+				"owner_id":     ownerId,
+				"is_available": true,
+				"is_leased":    bson.M{"$ne": true},
+				"structure":    bson.M{"$exists": false},
+			},
+			bson.M{"$set": bson.M{"is_available": false, "auction_id": auction.ID, "updated_at": now}},
+		)
+
+		if err == nil && result.ModifiedCount == 0 {
+			err = NewServiceError(http.StatusConflict, "land unit %s is no longer free", unit.ID.Hex())
+		}
+
+		if err != nil {
+			as.freeLandUnits(ctx, auction.ID)
+			return nil, err
+		}
+
+		auction.LandUnitIDs = append(auction.LandUnitIDs, unit.ID)
+	}
+
+	if _, err := as.Client.Collection(utils.LeaseAuctionsCollection).InsertOne(ctx, auction); err != nil {
+		as.freeLandUnits(ctx, auction.ID)
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+// GetAuctions returns the lease auctions with the given status, ending soonest first.
+func (as *LeaseAuctionService) GetAuctions(ctx context.Context, status string) ([]models.LeaseAuction, error) {
+	cursor, err := as.Client.Collection(utils.LeaseAuctionsCollection).Find(
+		ctx,
+		bson.M{"status": status},
+		options.Find().SetSort(bson.M{"ends_at": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	auctions := []models.LeaseAuction{}
+	err = cursor.All(ctx, &auctions)
+
+	return auctions, err
+}
+
+// GetBids returns an auction's bids, highest first.
+func (as *LeaseAuctionService) GetBids(ctx context.Context, auctionId primitive.ObjectID) ([]models.LeaseBid, error) {
+	if _, err := as.getAuction(ctx, auctionId); err != nil {
+		return nil, err
+	}
+
+	cursor, err := as.Client.Collection(utils.LeaseBidsCollection).Find(
+		ctx,
+		bson.M{"auction_id": auctionId},
+		options.Find().SetSort(bson.M{"amount": -1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	bids := []models.LeaseBid{}
+	err = cursor.All(ctx, &bids)
+
+	return bids, err
+}
+
+// CancelAuction lets the owner take down an open auction nobody has bid on and frees its units.
+func (as *LeaseAuctionService) CancelAuction(ctx context.Context, ownerId primitive.ObjectID, auctionId primitive.ObjectID) (*models.LeaseAuction, error) {
+	auction, err := as.getAuction(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case auction.OwnerID != ownerId:
+		return nil, NewServiceError(http.StatusForbidden, "Only the landowner can cancel an auction")
+	case auction.Status != utils.AuctionOpen:
+		return nil, NewServiceError(http.StatusConflict, "Auction is no longer open")
+	case auction.Bids > 0:
+		return nil, NewServiceError(http.StatusConflict, "Auctions with bids can't be cancelled")
+	}
+
+	err = as.Client.Collection(utils.LeaseAuctionsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": auction.ID, "status": utils.AuctionOpen, "bids": 0},
+		bson.M{"$set": bson.M{"status": utils.AuctionCancelled, "closed_at": time.Now(), "updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(auction)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusConflict, "Auction changed, try again")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	as.freeLandUnits(ctx, auction.ID)
+
+	return auction, nil
+}
+
+// PlaceBid bids on an open auction. The amount is held from the bidder's wallet, and the bid it
+// beats gets its funds back. A bid in the last minutes pushes the end back, so there is always
+// time to answer it.
+func (as *LeaseAuctionService) PlaceBid(ctx context.Context, bidderId primitive.ObjectID, auctionId primitive.ObjectID, amount float64) (*models.LeaseBid, error) {
+	auction, err := as.getAuction(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if auction.Status != utils.AuctionOpen || !auction.EndsAt.After(now) {
+		return nil, NewServiceError(http.StatusConflict, "Auction is closed")
+	}
+
+	if auction.OwnerID == bidderId {
+		return nil, NewServiceError(http.StatusBadRequest, "You can't bid on your own land")
+	}
+
+	minimum := auction.ReservePrice
+	if auction.Bids > 0 {
+		minimum = auction.HighestBid * (1 + auctionMinIncrement)
+	}
+
+	if amount < minimum {
+		return nil, NewServiceError(http.StatusBadRequest, "Bid must be at least %.2f", minimum)
+	}
+
+	bid := models.LeaseBid{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		AuctionID: auction.ID,
+		BidderID:  bidderId,
+		Amount:    amount,
+		Status:    utils.BidActive,
+		PlacedAt:  now,
+	}
+
+	hold, err := as.walletService.Hold(ctx, bidderId, amount, "LEASE_BID", bid.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	bid.HoldID = hold.ID
+
+	update := bson.M{
+		"$set": bson.M{
+			"highest_bid":       amount,
+			"highest_bidder_id": bidderId,
+			"highest_bid_id":    bid.ID,
+			"highest_hold_id":   hold.ID,
+			"updated_at":        now,
+		},
+		"$inc": bson.M{"bids": 1},
+	}
+
+	if auction.EndsAt.Sub(now) < auctionSnipeWindow {
+		update["$set"].(bson.M)["ends_at"] = now.Add(auctionSnipeWindow)
+		update["$inc"].(bson.M)["extensions"] = 1
+	}
+
+	// Matching the bid count means no other bid got in since the auction was read.
+	result, err := as.Client.Collection(utils.LeaseAuctionsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": auction.ID, "status": utils.AuctionOpen, "bids": auction.Bids, "ends_at": bson.M{"$gt": now}},
+		update,
+	)
+	if err == nil && result.ModifiedCount == 0 {
+		err = NewServiceError(http.StatusConflict, "Another bid came in or the auction closed, try again")
+	}
+	if err != nil {
+		as.releaseHold(ctx, hold.ID)
+		return nil, err
+	}
+
+	if _, err := as.Client.Collection(utils.LeaseBidsCollection).InsertOne(ctx, bid); err != nil {
+		fmt.Printf("Error recording bid %s on lease auction %s: %v\n", bid.ID.Hex(), auction.ID.Hex(), err)
+	}
+
+	if auction.Bids > 0 {
+		as.refundBid(ctx, auction.HighestBidID, auction.HighestHoldID)
+
+		if auction.HighestBidderID != bidderId {
+			as.eventService.PublishQuietly(ctx, auction.HighestBidderID, utils.EventOutbid, types.AuctionPayload{
+				AuctionID: auction.ID.Hex(),
+				Amount:    amount,
+			})
+		}
+	}
+
+	return &bid, nil
+}
+
+// CloseAuctions settles every auction that has ended, and any a previous run left settling.
+func (as *LeaseAuctionService) CloseAuctions(ctx context.Context) error {
+	cursor, err := as.Client.Collection(utils.LeaseAuctionsCollection).Find(ctx, bson.M{"$or": []bson.M{
+		{"status": utils.AuctionOpen, "ends_at": bson.M{"$lte": time.Now()}},
+		{"status": utils.AuctionSettling},
+	}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var auctions []models.LeaseAuction
+	if err := cursor.All(ctx, &auctions); err != nil {
+		return err
+	}
+
+	for i := range auctions {
+		auction := &auctions[i]
+
+		if auction.Status == utils.AuctionOpen {
+			// A late bid may have pushed the end back since the auction was read.
+			err := as.Client.Collection(utils.LeaseAuctionsCollection).FindOneAndUpdate(
+				ctx,
+				bson.M{"_id": auction.ID, "status": utils.AuctionOpen, "ends_at": bson.M{"$lte": time.Now()}},
+				bson.M{"$set": bson.M{"status": utils.AuctionSettling, "updated_at": time.Now()}},
+				options.FindOneAndUpdate().SetReturnDocument(options.After),
+			).Decode(auction)
+
+			if err == mongo.ErrNoDocuments {
+				continue
+			}
+			if err != nil {
+				fmt.Printf("Error closing lease auction %s: %v\n", auction.ID.Hex(), err)
+				continue
+			}
+		}
+
+		if err := as.settle(ctx, auction); err != nil {
+			fmt.Printf("Error settling lease auction %s: %v\n", auction.ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// settle awards a closed auction to its highest bidder: the units are leased to them, their held
+// bid is taken and paid to the owner, and every other bid is refunded. An auction without bids
+// frees its units. Every step checks what was already done, so it can be repeated until it
+// succeeds.
+func (as *LeaseAuctionService) settle(ctx context.Context, auction *models.LeaseAuction) error {
+	if auction.Bids == 0 {
+		if _, err := as.finish(ctx, auction, utils.AuctionUnsold, ""); err != nil {
+			return err
+		}
+
+		as.freeLandUnits(ctx, auction.ID)
+		return nil
+	}
+
+	now := time.Now()
+
+	_, err := as.Client.Collection(utils.LandUnitsCollection).UpdateMany(
+		ctx,
+		bson.M{"auction_id": auction.ID},
+		bson.M{
+			"$set":   bson.M{"is_available": true, "is_leased": true, "lessee_id": auction.HighestBidderID, "updated_at": now},
+			"$unset": bson.M{"auction_id": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	landUnitIds := make([]string, 0, len(auction.LandUnitIDs))
+	for _, id := range auction.LandUnitIDs {
+		landUnitIds = append(landUnitIds, id.Hex())
+	}
+
+	// The lease shares the auction's id, so a repeated settle finds it already there.
+	lease := models.Lease{
+		BaseModel: models.BaseModel{
+			ID:        auction.ID,
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		LeaseID:        auction.ID.Hex(),
+		LandownerID:    auction.OwnerID.Hex(),
+		TenantID:       auction.HighestBidderID.Hex(),
+		LandUnitIDs:    landUnitIds,
+		TotalLandUnits: len(landUnitIds),
+		Status:         utils.LeaseActive,
+		DurationDays:   auction.DurationDays,
+		Terms:          utils.LeaseFlat,
+		LeasePrice:     auction.HighestBid,
+		RequestedAt:    auction.CreatedAt,
+		AcceptedAt:     now,
+		StartTime:      now,
+		EndTime:        now.AddDate(0, 0, auction.DurationDays),
+		AuctionID:      auction.ID.Hex(),
+	}
+
+	if _, err := as.Client.Collection(utils.LeasesCollection).InsertOne(ctx, lease); err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	description := fmt.Sprintf("Lease of %d land units won at auction", lease.TotalLandUnits)
+
+	if err := as.walletService.CaptureHold(ctx, auction.HighestHoldID, "LEASE_PAYMENT", description); err != nil {
+		return err
+	}
+
+	// The owner is paid once per auction before it is awarded, so a failed payment leaves the
+	// auction settling for the next run to retry.
+	if err := as.walletService.CreditOnce(ctx, auction.OwnerID, auction.HighestBid, "LEASE_INCOME", description, auction.ID.Hex()); err != nil {
+		return err
+	}
+
+	awarded, err := as.finish(ctx, auction, utils.AuctionAwarded, lease.LeaseID)
+	if err != nil {
+		return err
+	}
+
+	if awarded {
+		as.eventService.PublishQuietly(ctx, auction.HighestBidderID, utils.EventAuctionWon, types.AuctionPayload{
+			AuctionID: auction.ID.Hex(),
+			Amount:    auction.HighestBid,
+			LeaseID:   lease.LeaseID,
+		})
+	}
+
+	if _, err := as.Client.Collection(utils.LeaseBidsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": auction.HighestBidID},
+		bson.M{"$set": bson.M{"status": utils.BidWon, "updated_at": now}},
+	); err != nil {
+		fmt.Printf("Error marking winning bid %s: %v\n", auction.HighestBidID.Hex(), err)
+	}
+
+	// Bids whose refund was interrupted when they were outbid.
+	cursor, err := as.Client.Collection(utils.LeaseBidsCollection).Find(ctx, bson.M{
+		"auction_id": auction.ID,
+		"status":     utils.BidActive,
+		"_id":        bson.M{"$ne": auction.HighestBidID},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var losing []models.LeaseBid
+	if err := cursor.All(ctx, &losing); err != nil {
+		return err
+	}
+
+	for _, bid := range losing {
+		as.refundBid(ctx, bid.ID, bid.HoldID)
+	}
+
+	return nil
+}
+
+// finish moves a settling auction to its final status and reports whether this call did.
+func (as *LeaseAuctionService) finish(ctx context.Context, auction *models.LeaseAuction, status string, leaseId string) (bool, error) {
+	now := time.Now()
+
+	set := bson.M{"status": status, "closed_at": now, "updated_at": now}
+	if leaseId != "" {
+		set["lease_id"] = leaseId
+	}
+
+	result, err := as.Client.Collection(utils.LeaseAuctionsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": auction.ID, "status": utils.AuctionSettling},
+		bson.M{"$set": set},
+	)
+	if err != nil || result.ModifiedCount == 0 {
+		return false, err
+	}
+
+	auction.Status = status
+	auction.LeaseID = leaseId
+	auction.ClosedAt = now
+
+	return true, nil
+}
+
+// refundBid gives an outbid bidder their held funds back.
+func (as *LeaseAuctionService) refundBid(ctx context.Context, bidId primitive.ObjectID, holdId primitive.ObjectID) {
+	if err := as.walletService.ReleaseHold(ctx, holdId); err != nil {
+		fmt.Printf("Error refunding bid %s: %v\n", bidId.Hex(), err)
+		return
+	}
+
+	_, err := as.Client.Collection(utils.LeaseBidsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": bidId, "status": utils.BidActive},
+		bson.M{"$set": bson.M{"status": utils.BidOutbid, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		fmt.Printf("Error marking bid %s outbid: %v\n", bidId.Hex(), err)
+	}
+}
+
+func (as *LeaseAuctionService) getAuction(ctx context.Context, auctionId primitive.ObjectID) (*models.LeaseAuction, error) {
+	var auction models.LeaseAuction
+
+	err := as.Client.Collection(utils.LeaseAuctionsCollection).FindOne(ctx, bson.M{"_id": auctionId}).Decode(&auction)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Auction not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+func (as *LeaseAuctionService) freeLandUnits(ctx context.Context, auctionId primitive.ObjectID) {
+	_, err := as.Client.Collection(utils.LandUnitsCollection).UpdateMany(
+		ctx,
+		bson.M{"auction_id": auctionId},
+		bson.M{
+			"$set":   bson.M{"is_available": true, "updated_at": time.Now()},
+			"$unset": bson.M{"auction_id": ""},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error freeing land of lease auction %s: %v\n", auctionId.Hex(), err)
+	}
+}
+
+func (as *LeaseAuctionService) releaseHold(ctx context.Context, holdId primitive.ObjectID) {
+	if err := as.walletService.ReleaseHold(ctx, holdId); err != nil {
+		fmt.Printf("Error releasing bid hold %s: %v\n", holdId.Hex(), err)
+	}
+}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ListingService struct {
+	Client *mongo.Database
+
+	landService   *LandService
+	userService   *UserService
+	walletService *WalletService
+}
+
+func NewListingService(client *mongo.Database) *ListingService {
+	return &ListingService{
+		Client:        client,
+		landService:   NewLandService(client),
+		userService:   NewUserService(client),
+		walletService: NewWalletService(client),
+	}
+}
+
+// CreateListing lists the seller's land units for sale together. Listed units can't be planted,
+// leased or built on until the listing is cancelled.
+func (ls *ListingService) CreateListing(ctx context.Context, sellerId primitive.ObjectID, body types.ListLand) (*models.LandListing, error) {
+	// Start the seller's land counter while they hold all of their land, so the sale can lower it.
+	if _, err := ls.landService.getLandCounter(ctx, sellerId); err != nil {
+		return nil, err
+	}
+
+	landUnits, err := ls.landService.cropService.findLandUnitsById(ctx, body.LandUnitIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, unit := range landUnits {
+		switch {
+		case unit.OwnerID != sellerId:
+			return nil, NewServiceError(http.StatusForbidden, "land unit %s is not yours", unit.ID.Hex())
+		case unit.IsLeased:
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is leased out", unit.ID.Hex())
+		case !unit.ListingID.IsZero():
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is already listed", unit.ID.Hex())
+		case !unit.AuctionID.IsZero():
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is up for lease auction", unit.ID.Hex())
+		case unit.Structure != nil:
+			return nil, NewServiceError(http.StatusConflict, "land unit %s has a %s on it", unit.ID.Hex(), unit.Structure.Kind)
+		case !unit.IsAvailable:
+			return nil, NewServiceError(http.StatusConflict, "land unit %s is planted", unit.ID.Hex())
+		}
+	}
+
+	now := time.Now()
+
+	listing := models.LandListing{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		SellerID:    sellerId,
+		LandUnitIDs: make([]primitive.ObjectID, 0, len(landUnits)),
+		Price:       body.Price,
+		Status:      utils.ListingOpen,
+	}
+
+	collection := ls.Client.Collection(utils.LandUnitsCollection)
+
+	for _, unit := range landUnits {
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{
+				"_id":          unit.ID,
+				"owner_id":     sellerId,
+				"is_available": true,
+				"is_leased":    bson.M{"$ne": true},
+				"structure":    bson.M{"$exists": false},
+			},
+			bson.M{"$set": bson.M{"is_available": false, "listing_id": listing.ID, "updated_at": now}},
+		)
+
+		if err == nil && result.ModifiedCount == 0 {
+			err = NewServiceError(http.StatusConflict, "land unit %s is no longer free", unit.ID.Hex())
+		}
+
+		if err != nil {
+			ls.unlistLandUnits(ctx, listing.ID)
+			return nil, err
+		}
+
+		listing.LandUnitIDs = append(listing.LandUnitIDs, unit.ID)
+	}
+
+	if _, err := ls.Client.Collection(utils.LandListingsCollection).InsertOne(ctx, listing); err != nil {
+		ls.unlistLandUnits(ctx, listing.ID)
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+// GetListings returns the listings with the given status, newest first.
+func (ls *ListingService) GetListings(ctx context.Context, status string) ([]models.LandListing, error) {
+	cursor, err := ls.Client.Collection(utils.LandListingsCollection).Find(
+		ctx,
+		bson.M{"status": status},
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	listings := []models.LandListing{}
+	err = cursor.All(ctx, &listings)
+
+	return listings, err
+}
+
+// CancelListing takes the seller's open listing down and frees its land units.
+func (ls *ListingService) CancelListing(ctx context.Context, sellerId primitive.ObjectID, listingId primitive.ObjectID) (*models.LandListing, error) {
+	var listing models.LandListing
+
+	err := ls.Client.Collection(utils.LandListingsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": listingId, "seller_id": sellerId, "status": utils.ListingOpen},
+		bson.M{"$set": bson.M{"status": utils.ListingCancelled, "updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&listing)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Open listing not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ls.unlistLandUnits(ctx, listing.ID)
+
+	return &listing, nil
+}
+
+// BuyListing buys an open listing. The price is held from the buyer's wallet and the listing
+// claimed before any land changes hands, so a sale interrupted after that is finished by the
+// land worker instead of being lost.
+func (ls *ListingService) BuyListing(ctx context.Context, buyerId primitive.ObjectID, listingId primitive.ObjectID) (*models.LandListing, error) {
+	listing, err := ls.getListing(ctx, listingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.Status == utils.ListingSettling && listing.BuyerID == buyerId {
+		return ls.settle(ctx, listing)
+	}
+
+	if listing.Status != utils.ListingOpen {
+		return nil, NewServiceError(http.StatusConflict, "Listing is no longer for sale")
+	}
+
+	if listing.SellerID == buyerId {
+		return nil, NewServiceError(http.StatusBadRequest, "You can't buy your own land")
+	}
+
+	units := len(listing.LandUnitIDs)
+
+	counter, err := ls.landService.getLandCounter(ctx, buyerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if counter.Owned+units > ls.landService.config.MaxUnits {
+		return nil, NewServiceError(http.StatusBadRequest, "You can hold at most %d land units", ls.landService.config.MaxUnits)
+	}
+
+	hold, err := ls.walletService.Hold(ctx, buyerId, listing.Price, "LAND_SALE", listing.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := ls.landService.claimPositions(ctx, buyerId, counter, units)
+	if err != nil {
+		ls.releaseHold(ctx, hold.ID)
+		return nil, err
+	}
+
+	positions := make([]int, 0, units)
+	for position := claimed.Seq - units + 1; position <= claimed.Seq; position++ {
+		positions = append(positions, position)
+	}
+
+	err = ls.Client.Collection(utils.LandListingsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": listing.ID, "status": utils.ListingOpen},
+		bson.M{"$set": bson.M{
+			"status":     utils.ListingSettling,
+			"buyer_id":   buyerId,
+			"hold_id":    hold.ID,
+			"positions":  positions,
+			"updated_at": time.Now(),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(listing)
+
+	if err != nil {
+		ls.releaseHold(ctx, hold.ID)
+		ls.landService.unclaimPositions(ctx, buyerId, claimed, units)
+
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusConflict, "Listing is no longer for sale")
+		}
+		return nil, err
+	}
+
+	return ls.settle(ctx, listing)
+}
+
+// RecoverSales finishes sales that were claimed but not settled, and releases buyers' holds that
+// were placed but never recorded on a listing.
+func (ls *ListingService) RecoverSales(ctx context.Context) error {
+	if err := ls.releaseStrandedHolds(ctx); err != nil {
+		fmt.Printf("Error releasing stranded land sale holds: %v\n", err)
+	}
+
+	cursor, err := ls.Client.Collection(utils.LandListingsCollection).Find(ctx, bson.M{"status": utils.ListingSettling})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var listings []models.LandListing
+	if err := cursor.All(ctx, &listings); err != nil {
+		return err
+	}
+
+	for i := range listings {
+		if _, err := ls.settle(ctx, &listings[i]); err != nil {
+			fmt.Printf("Error settling land listing %s: %v\n", listings[i].ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// GetTransfers returns a land unit's ownership history, oldest first.
+func (ls *ListingService) GetTransfers(ctx context.Context, landUnitId primitive.ObjectID) ([]models.LandTransfer, error) {
+	cursor, err := ls.Client.Collection(utils.LandTransfersCollection).Find(
+		ctx,
+		bson.M{"land_unit_id": landUnitId},
+		options.Find().SetSort(bson.M{"transferred_at": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	transfers := []models.LandTransfer{}
+	err = cursor.All(ctx, &transfers)
+
+	return transfers, err
+}
+
+// settle moves each land unit of a claimed listing to the buyer, then takes the buyer's held
+// payment, pays the seller and marks the listing sold. A unit only moves while the seller still
+// owns it under this listing and the seller is credited once per listing, so the land worker can
+// finish a sale that stopped after some units had moved.
+func (ls *ListingService) settle(ctx context.Context, listing *models.LandListing) (*models.LandListing, error) {
+	buyer, err := ls.userService.GetUserById(listing.BuyerID)
+	if err != nil {
+		return nil, err
+	}
+
+	units := ls.Client.Collection(utils.LandUnitsCollection)
+	sharePrice := listing.Price / float64(len(listing.LandUnitIDs))
+
+	for i, unitId := range listing.LandUnitIDs {
+		now := time.Now()
+
+		var previous models.LandUnit
+
+		err := units.FindOneAndUpdate(
+			ctx,
+			bson.M{"_id": unitId, "owner_id": listing.SellerID, "listing_id": listing.ID},
+			bson.M{
+				"$set": bson.M{
+					"owner_id":     listing.BuyerID,
+					"land":         fmt.Sprintf("%s_land", buyer.Username),
+					"position":     listing.Positions[i],
+					"is_available": true,
+					"updated_at":   now,
+				},
+				"$unset": bson.M{"listing_id": ""},
+			},
+		).Decode(&previous)
+
+		// Moved on an earlier attempt.
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ls.landService.dropOwned(ctx, listing.SellerID); err != nil {
+			fmt.Printf("Error updating land holdings of user %s: %v\n", listing.SellerID.Hex(), err)
+		}
+
+		transfer := models.LandTransfer{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			},
+			LandUnitID:    unitId,
+			FromID:        listing.SellerID,
+			ToID:          listing.BuyerID,
+			ListingID:     listing.ID,
+			Price:         sharePrice,
+			FromPosition:  previous.Position,
+			ToPosition:    listing.Positions[i],
+			TransferredAt: now,
+		}
+
+		if _, err := ls.Client.Collection(utils.LandTransfersCollection).InsertOne(ctx, transfer); err != nil {
+			fmt.Printf("Error recording transfer of land unit %s: %v\n", unitId.Hex(), err)
+		}
+	}
+
+	description := fmt.Sprintf("Land sale of %d units", len(listing.LandUnitIDs))
+
+	if err := ls.walletService.CaptureHold(ctx, listing.HoldID, "LAND_PURCHASE", description); err != nil {
+		return nil, err
+	}
+
+	if hold, err := ls.walletService.GetHold(ctx, listing.HoldID); err != nil {
+		return nil, err
+	} else if hold.Status != utils.EscrowCaptured {
+		return nil, NewServiceError(http.StatusConflict, "buyer's payment for listing %s was released", listing.ID.Hex())
+	}
+
+	// The seller is paid once per listing, before it is marked sold, so a failed payment leaves
+	// the listing settling for the land worker to retry.
+	if err := ls.walletService.CreditOnce(ctx, listing.SellerID, listing.Price, "LAND_SALE", description, listing.ID.Hex()); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	_, err = ls.Client.Collection(utils.LandListingsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": listing.ID, "status": utils.ListingSettling},
+		bson.M{"$set": bson.M{"status": utils.ListingSold, "sold_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	listing.Status = utils.ListingSold
+	listing.SoldAt = now
+
+	return listing, nil
+}
+
+func (ls *ListingService) getListing(ctx context.Context, listingId primitive.ObjectID) (*models.LandListing, error) {
+	var listing models.LandListing
+
+	err := ls.Client.Collection(utils.LandListingsCollection).FindOne(ctx, bson.M{"_id": listingId}).Decode(&listing)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Listing not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+func (ls *ListingService) unlistLandUnits(ctx context.Context, listingId primitive.ObjectID) {
+	_, err := ls.Client.Collection(utils.LandUnitsCollection).UpdateMany(
+		ctx,
+		bson.M{"listing_id": listingId},
+		bson.M{
+			"$set":   bson.M{"is_available": true, "updated_at": time.Now()},
+			"$unset": bson.M{"listing_id": ""},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error unlisting land of listing %s: %v\n", listingId.Hex(), err)
+	}
+}
+
+// releaseStrandedHolds releases land sale holds whose listing was never claimed with them, which
+// happens when a purchase stops between placing the hold and claiming the listing.
+func (ls *ListingService) releaseStrandedHolds(ctx context.Context) error {
+	holds, err := ls.walletService.StaleHolds(ctx, "LAND_SALE")
+	if err != nil {
+		return err
+	}
+
+	listings := ls.Client.Collection(utils.LandListingsCollection)
+
+	for _, hold := range holds {
+		listingId, err := primitive.ObjectIDFromHex(hold.ReferenceID)
+		if err != nil {
+			continue
+		}
+
+		recorded, err := listings.CountDocuments(ctx, bson.M{"_id": listingId, "hold_id": hold.ID})
+		if err != nil {
+			return err
+		}
+
+		if recorded == 0 {
+			ls.releaseHold(ctx, hold.ID)
+		}
+	}
+
+	return nil
+}
+
+func (ls *ListingService) releaseHold(ctx context.Context, holdId primitive.ObjectID) {
+	if err := ls.walletService.ReleaseHold(ctx, holdId); err != nil {
+		fmt.Printf("Error releasing land sale hold %s: %v\n", holdId.Hex(), err)
+	}
+}
@@ -2,31 +2,174 @@ package service
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
 	"time"
 
+	"github.com/hrutik1235/farming-server/events"
 	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
 	"github.com/hrutik1235/farming-server/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MarketService struct {
 	client *mongo.Database
+
+	eventService     *EventService
+	walletService    *WalletService
+	warehouseService *WarehouseService
 }
 
 func NewMarketService(client *mongo.Database) *MarketService {
-	return &MarketService{client: client}
+	return &MarketService{
+		client:           client,
+		eventService:     NewEventService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+	}
+}
+
+// gradeMarketDepth is how many units traded move a grade's supply or demand factor by one.
+const gradeMarketDepth = 1000.0
+
+// gradeMarketReversion is the share of a factor's distance from 1.0 that each shift takes back
+// before adding the new units, so one-sided trading levels off instead of growing for good.
+const gradeMarketReversion = 0.05
+
+// SellCrop sells the user's oldest unexpired stock of the crop to the market, each lot at the
+// current price of its grade. The stock is reserved until the wallet is credited, so a failed
+// credit puts it back. What was sold adds to the supply of each grade.
+func (ms *MarketService) SellCrop(ctx context.Context, userID primitive.ObjectID, cropID primitive.ObjectID, quantity int) (*models.SaleResult, error) {
+	price, err := ms.GetCurrentPrice(ctx, cropID)
+	if err != nil {
+		return nil, err
+	}
+
+	saleID := primitive.NewObjectID().Hex()
+
+	reservation, err := ms.warehouseService.ReserveStock(ctx, userID, cropID, quantity, "SALE", saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	grades, total, err := ms.priceLots(ctx, cropID, reservation.Lots)
+	if err != nil {
+		if releaseErr := ms.warehouseService.ReleaseReservation(ctx, reservation.ID); releaseErr != nil {
+			fmt.Printf("Error releasing reservation %s: %v\n", reservation.ID.Hex(), releaseErr)
+		}
+		return nil, err
+	}
+
+	consumed, err := ms.warehouseService.ConsumeReservation(ctx, reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+	if consumed == nil {
+		return nil, NewServiceError(http.StatusConflict, "sale %s no longer holds its stock", saleID)
+	}
+
+	description := fmt.Sprintf("Sold %d units to the market", quantity)
+
+	wallet, err := ms.walletService.Credit(ctx, userID, total, "CROP_SALE", description, saleID)
+	if err != nil {
+		// Unpaid stock goes back where it came from.
+		if restoreErr := ms.warehouseService.restoreLots(ctx, userID, reservation.Lots); restoreErr != nil {
+			fmt.Printf("Error returning stock of sale %s: %v\n", saleID, restoreErr)
+		}
+		return nil, err
+	}
+
+	for _, sale := range grades {
+		ms.shiftMarket(ctx, cropID, sale.Grade, sale.Quantity, 0, "SALE")
+	}
+
+	return &models.SaleResult{
+		CropID:        cropID,
+		Quantity:      quantity,
+		MarketPrice:   price,
+		QualityFactor: averageQuality(reservation.Lots),
+		TotalAmount:   total,
+		Balance:       wallet.Balance,
+		SoldAt:        time.Now(),
+		Grades:        grades,
+	}, nil
 }
 
-// GetCurrentPrice with lazy initialization - creates market price if not exists
+// priceLots prices each grade among the lots at that grade's market price, best grade first.
+func (ms *MarketService) priceLots(ctx context.Context, cropID primitive.ObjectID, lots []models.WarehouseItem) ([]models.GradeSale, float64, error) {
+	sales := []models.GradeSale{}
+	total := 0.0
+
+	for _, grade := range utils.Grades {
+		quantity := 0
+		for _, lot := range lots {
+			if lot.Grade == grade {
+				quantity += lot.Quantity
+			}
+		}
+
+		if quantity == 0 {
+			continue
+		}
+
+		price, err := ms.GetGradePrice(ctx, cropID, grade)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		amount := roundCoins(price * float64(quantity))
+		sales = append(sales, models.GradeSale{Grade: grade, Quantity: quantity, PricePerUnit: price, TotalAmount: amount})
+		total += amount
+	}
+
+	return sales, roundCoins(total), nil
+}
+
+// GetCurrentPrice returns the market price of the crop's standard grade, B.
 func (ms *MarketService) GetCurrentPrice(ctx context.Context, cropID primitive.ObjectID) (float64, error) {
+	return ms.GetGradePrice(ctx, cropID, utils.GradeB)
+}
+
+// GetGradePrice returns the market price of a grade of the crop.
+func (ms *MarketService) GetGradePrice(ctx context.Context, cropID primitive.ObjectID, grade string) (float64, error) {
+	marketPrice, err := ms.getMarketPrice(ctx, cropID, grade)
+	if err != nil {
+		return 0, err
+	}
+
+	return marketPrice.CurrentPrice, nil
+}
+
+// GetMarketPrices returns the crop's market price of every grade, best grade first.
+func (ms *MarketService) GetMarketPrices(ctx context.Context, cropID primitive.ObjectID) ([]models.MarketPrice, error) {
+	prices := make([]models.MarketPrice, 0, len(utils.Grades))
+
+	for _, grade := range utils.Grades {
+		marketPrice, err := ms.getMarketPrice(ctx, cropID, grade)
+		if err != nil {
+			return nil, err
+		}
+
+		prices = append(prices, *marketPrice)
+	}
+
+	return prices, nil
+}
+
+// getMarketPrice with lazy initialization - creates the grade's market price if not exists
+func (ms *MarketService) getMarketPrice(ctx context.Context, cropID primitive.ObjectID, grade string) (*models.MarketPrice, error) {
 	collection := ms.client.Collection(utils.MarketPricesCollection)
 
 	var marketPrice models.MarketPrice
 	err := collection.FindOne(ctx, bson.M{
-		"_id":         cropID,
+		"crop_id":     cropID,
+		"grade":       grade,
 		"is_active":   true,
 		"valid_until": bson.M{"$gt": time.Now()},
 	}).Decode(&marketPrice)
@@ -34,22 +177,29 @@ func (ms *MarketService) GetCurrentPrice(ctx context.Context, cropID primitive.O
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			// Market price doesn't exist - create it lazily
-			return ms.CreateAndGetMarketPrice(ctx, cropID)
+			return ms.CreateMarketPrice(ctx, cropID, grade)
 		}
-		return 0, err
+		return nil, err
 	}
 
-	return marketPrice.CurrentPrice, nil
+	return &marketPrice, nil
 }
 
-// Create market price for crop and return the price
-func (ms *MarketService) CreateAndGetMarketPrice(ctx context.Context, cropID primitive.ObjectID) (float64, error) {
+// CreateMarketPrice creates the market price of a grade of the crop, starting at the crop's base
+// price scaled by the grade's price factor.
+func (ms *MarketService) CreateMarketPrice(ctx context.Context, cropID primitive.ObjectID, grade string) (*models.MarketPrice, error) {
+	if !slices.Contains(utils.Grades, grade) {
+		return nil, NewServiceError(http.StatusBadRequest, "grade must be one of %v", utils.Grades)
+	}
+
 	// Get crop base price first
 	basePrice, err := ms.GetBasePriceFromCrop(ctx, cropID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
+	gradePrice := roundCoins(basePrice * utils.GradePriceFactors[grade])
+
 	// Create new market price
 	marketPrice := models.MarketPrice{
 		BaseModel: models.BaseModel{
@@ -59,8 +209,9 @@ func (ms *MarketService) CreateAndGetMarketPrice(ctx context.Context, cropID pri
 			IsActive:  true,
 		},
 		CropID:       cropID,
-		CurrentPrice: basePrice,
-		BasePrice:    basePrice,
+		Grade:        grade,
+		CurrentPrice: gradePrice,
+		BasePrice:    gradePrice,
 		DemandFactor: 1.0, // Neutral demand
 		SupplyFactor: 1.0, // Neutral supply
 		LastUpdated:  time.Now(),
@@ -71,10 +222,10 @@ func (ms *MarketService) CreateAndGetMarketPrice(ctx context.Context, cropID pri
 	collection := ms.client.Collection(utils.MarketPricesCollection)
 	_, err = collection.InsertOne(ctx, marketPrice)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return marketPrice.CurrentPrice, nil
+	return &marketPrice, nil
 }
 
 // Get base price from crop definition
@@ -89,10 +240,147 @@ func (ms *MarketService) GetBasePriceFromCrop(ctx context.Context, cropID primit
 
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return 0, errors.New("crop not found")
+			return 0, NewServiceError(http.StatusNotFound, "Crop not found")
 		}
 		return 0, err
 	}
 
 	return crop.BasePrice, nil
 }
+
+// UpdatePrice sets the current market price of a grade of the crop, records it in the price
+// history and publishes a market-wide PRICE_CHANGED event.
+func (ms *MarketService) UpdatePrice(ctx context.Context, cropID primitive.ObjectID, grade string, price float64, reason string) error {
+	if price <= 0 {
+		return NewServiceError(http.StatusBadRequest, "price must be positive")
+	}
+
+	previous, err := ms.GetGradePrice(ctx, cropID, grade)
+	if err != nil {
+		return err
+	}
+
+	if price == previous {
+		return nil
+	}
+
+	now := time.Now()
+	changePercent := (price - previous) / previous * 100
+
+	_, err = ms.client.Collection(utils.MarketPricesCollection).UpdateOne(
+		ctx,
+		bson.M{"crop_id": cropID, "grade": grade, "is_active": true, "valid_until": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{
+			"current_price":  price,
+			"change_percent": changePercent,
+			"last_updated":   now,
+			"updated_at":     now,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	history := models.PriceHistory{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		CropID:    cropID.Hex(),
+		Grade:     grade,
+		Price:     price,
+		Timestamp: now,
+		Reason:    reason,
+	}
+
+	if _, err := ms.client.Collection(utils.PriceHistoryCollection).InsertOne(ctx, history); err != nil {
+		fmt.Printf("Error recording price history for %s: %v\n", cropID.Hex(), err)
+	}
+
+	ms.eventService.PublishQuietly(ctx, events.Everyone, utils.EventPriceChanged, types.PriceChangedPayload{
+		CropID:        cropID.Hex(),
+		Grade:         grade,
+		Price:         price,
+		PreviousPrice: previous,
+		ChangePercent: changePercent,
+		Reason:        reason,
+	})
+
+	return nil
+}
+
+// shiftMarket adds units sold to the market to a grade's supply factor and units bought to its
+// demand factor, after pulling both back toward 1.0, then reprices the grade at its base price
+// scaled by demand over supply, between half and twice the base.
+func (ms *MarketService) shiftMarket(ctx context.Context, cropID primitive.ObjectID, grade string, supplied int, demanded int, reason string) {
+	marketPrice, err := ms.getMarketPrice(ctx, cropID, grade)
+	if err != nil {
+		fmt.Printf("Error loading %s market price of %s: %v\n", grade, cropID.Hex(), err)
+		return
+	}
+
+	err = ms.client.Collection(utils.MarketPricesCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": marketPrice.ID},
+		bson.A{bson.M{"$set": bson.M{
+			"supply_factor": revertedFactor("$supply_factor", float64(supplied)/gradeMarketDepth),
+			"demand_factor": revertedFactor("$demand_factor", float64(demanded)/gradeMarketDepth),
+			"updated_at":    time.Now(),
+		}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(marketPrice)
+	if err != nil {
+		fmt.Printf("Error shifting %s market of %s: %v\n", grade, cropID.Hex(), err)
+		return
+	}
+
+	ratio := math.Max(0.5, math.Min(2, marketPrice.DemandFactor/marketPrice.SupplyFactor))
+
+	if err := ms.UpdatePrice(ctx, cropID, grade, roundCoins(marketPrice.BasePrice*ratio), reason); err != nil {
+		fmt.Printf("Error repricing %s market of %s: %v\n", grade, cropID.Hex(), err)
+	}
+}
+
+// revertedFactor is the update expression that moves the factor at field gradeMarketReversion of
+// the way back to 1.0 and then adds delta.
+func revertedFactor(field string, delta float64) bson.M {
+	return bson.M{"$add": bson.A{
+		1,
+		bson.M{"$multiply": bson.A{bson.M{"$subtract": bson.A{bson.M{"$ifNull": bson.A{field, 1}}, 1}}, 1 - gradeMarketReversion}},
+		delta,
+	}}
+}
+
+// validateGradeThresholds checks that a crop setting its own thresholds sets all of A, B and C,
+// each strictly below the one before.
+func validateGradeThresholds(thresholds map[string]float64) error {
+	if len(thresholds) == 0 {
+		return nil
+	}
+
+	graded := utils.Grades[:len(utils.Grades)-1]
+
+	for grade := range thresholds {
+		if !slices.Contains(graded, grade) {
+			return NewServiceError(http.StatusBadRequest, "grade thresholds may only be set for %v", graded)
+		}
+	}
+
+	above := utils.MaxCareQuality
+	for _, grade := range graded {
+		threshold, ok := thresholds[grade]
+		if !ok {
+			return NewServiceError(http.StatusBadRequest, "grade thresholds must set all of %v", graded)
+		}
+
+		if threshold <= 0 || threshold >= above {
+			return NewServiceError(http.StatusBadRequest, "grade %s threshold must be between 0 and %.2f", grade, above)
+		}
+
+		above = threshold
+	}
+
+	return nil
+}
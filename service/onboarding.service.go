@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	OnboardingPending   = "PENDING"
+	OnboardingCompleted = "COMPLETED"
+	OnboardingFailed    = "FAILED"
+)
+
+type OnboardingService struct {
+	Client *mongo.Database
+
+	userService *UserService
+	cropService *CropService
+}
+
+func NewOnboardingService(client *mongo.Database) *OnboardingService {
+	return &OnboardingService{
+		Client:      client,
+		userService: NewUserService(client),
+		cropService: NewCropService(client),
+	}
+}
+
+// Onboard runs every onboarding step that has not completed yet for the registered user.
+// Each step is idempotent on its own and is flagged on the onboarding record once done,
+// so replaying the same event only redoes the steps that failed.
+func (o *OnboardingService) Onboard(ctx context.Context, raw []byte) error {
+	var event types.UserRegisteredEvent
+
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return fmt.Errorf("invalid UserRegistered event: %v", err)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(event.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user id in UserRegistered event: %v", err)
+	}
+
+	record, err := o.getOrCreateRecord(ctx, userId, event.Username, raw)
+	if err != nil {
+		return err
+	}
+
+	if record.Status == OnboardingCompleted {
+		return nil
+	}
+
+	steps := []struct {
+		done  bool
+		field string
+		run   func() error
+	}{
+		{record.WalletCreated, "wallet_created", func() error { return o.userService.EnsureWallet(ctx, userId) }},
+		{record.LandAllocated, "land_allocated", func() error { return o.userService.EnsureStarterLand(ctx, userId, event.Username) }},
+		{record.SeedsGranted, "seeds_granted", func() error { return o.GrantStarterSeeds(ctx, userId) }},
+		{record.WelcomeSent, "welcome_sent", func() error { return o.SendWelcomeNotification(ctx, userId, event.Name) }},
+	}
+
+	for _, step := range steps {
+		if step.done {
+			continue
+		}
+
+		if err := step.run(); err != nil {
+			o.markFailed(ctx, userId, err)
+			return fmt.Errorf("onboarding step %s failed: %v", step.field, err)
+		}
+
+		if err := o.markStep(ctx, userId, step.field); err != nil {
+			return err
+		}
+	}
+
+	_, err = o.Client.Collection(utils.OnboardingCollection).UpdateOne(
+		ctx,
+		bson.M{"user_id": userId},
+		bson.M{"$set": bson.M{
+			"status":       OnboardingCompleted,
+			"last_error":   "",
+			"completed_at": time.Now(),
+			"updated_at":   time.Now(),
+		}},
+	)
+
+	return err
+}
+
+// IsRecorded reports whether a register event no longer needs its offset held: its onboarding
+// record completed or was marked FAILED for the retry loop, or the event is malformed and can never
+// succeed.
+func (o *OnboardingService) IsRecorded(ctx context.Context, raw []byte) bool {
+	var event types.UserRegisteredEvent
+
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return true
+	}
+
+	userId, err := primitive.ObjectIDFromHex(event.UserID)
+	if err != nil {
+		return true
+	}
+
+	count, err := o.Client.Collection(utils.OnboardingCollection).CountDocuments(ctx, bson.M{
+		"user_id": userId,
+		"status":  bson.M{"$ne": OnboardingPending},
+	})
+
+	return err == nil && count > 0
+}
+
+// GetFailedOnboardings returns onboarding records that stopped on an error and are due for a retry,
+// and PENDING records untouched for longer than stale, whose event was never published or consumed.
+func (o *OnboardingService) GetFailedOnboardings(ctx context.Context, maxAttempts int, stale time.Duration) ([]models.Onboarding, error) {
+	cursor, err := o.Client.Collection(utils.OnboardingCollection).Find(ctx, bson.M{"$or": bson.A{
+		bson.M{"status": OnboardingFailed, "attempts": bson.M{"$lt": maxAttempts}},
+		bson.M{"status": OnboardingPending, "updated_at": bson.M{"$lt": time.Now().Add(-stale)}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []models.Onboarding
+	err = cursor.All(ctx, &records)
+
+	return records, err
+}
+
+// GrantStarterSeeds gives the user a handful of seeds of every active crop.
+func (o *OnboardingService) GrantStarterSeeds(ctx context.Context, userId primitive.ObjectID) error {
+	crops, err := o.cropService.GetAllCrops(ctx)
+	if err != nil {
+		return err
+	}
+
+	collection := o.Client.Collection(utils.SeedInventoryCollection)
+
+	for _, crop := range crops {
+		now := time.Now()
+
+		_, err := collection.UpdateOne(
+			ctx,
+			bson.M{"user_id": userId, "crop_id": crop.ID, "source": "STARTER"},
+			bson.M{"$setOnInsert": models.SeedItem{
+				BaseModel: models.BaseModel{
+					ID:        primitive.NewObjectID(),
+					CreatedAt: now,
+					UpdatedAt: now,
+					IsActive:  true,
+				},
+				UserID:   userId,
+				CropID:   crop.ID,
+				Quantity: utils.StarterSeedUnits,
+				Source:   "STARTER",
+			}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *OnboardingService) SendWelcomeNotification(ctx context.Context, userId primitive.ObjectID, name string) error {
+	now := time.Now()
+
+	_, err := o.Client.Collection(utils.NotificationsCollection).UpdateOne(
+		ctx,
+		bson.M{"user_id": userId, "type": "WELCOME"},
+		bson.M{"$setOnInsert": models.Notification{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			},
+			UserID:  userId,
+			Type:    "WELCOME",
+			Title:   "Welcome to your farm",
+			Message: fmt.Sprintf("Hi %s, your wallet, land and starter seeds are ready. Happy farming!", name),
+		}},
+		options.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+func (o *OnboardingService) getOrCreateRecord(ctx context.Context, userId primitive.ObjectID, username string, raw []byte) (*models.Onboarding, error) {
+	return upsertOnboardingRecord(ctx, o.Client, userId, username, raw)
+}
+
+// upsertOnboardingRecord returns the user's onboarding record, creating it PENDING with the raw
+// UserRegistered event if there is none yet.
+func upsertOnboardingRecord(ctx context.Context, client *mongo.Database, userId primitive.ObjectID, username string, raw []byte) (*models.Onboarding, error) {
+	now := time.Now()
+
+	var record models.Onboarding
+
+	err := client.Collection(utils.OnboardingCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userId},
+		bson.M{
+			"$setOnInsert": bson.M{
+				"_id":            primitive.NewObjectID(),
+				"created_at":     now,
+				"is_active":      true,
+				"username":       username,
+				"status":         OnboardingPending,
+				"wallet_created": false,
+				"land_allocated": false,
+				"seeds_granted":  false,
+				"welcome_sent":   false,
+				"attempts":       0,
+				"event":          raw,
+			},
+			"$set": bson.M{"updated_at": now},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&record)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (o *OnboardingService) markStep(ctx context.Context, userId primitive.ObjectID, field string) error {
+	_, err := o.Client.Collection(utils.OnboardingCollection).UpdateOne(
+		ctx,
+		bson.M{"user_id": userId},
+		bson.M{"$set": bson.M{field: true, "updated_at": time.Now()}},
+	)
+
+	return err
+}
+
+func (o *OnboardingService) markFailed(ctx context.Context, userId primitive.ObjectID, stepErr error) {
+	_, err := o.Client.Collection(utils.OnboardingCollection).UpdateOne(
+		ctx,
+		bson.M{"user_id": userId},
+		bson.M{
+			"$set": bson.M{
+				"status":     OnboardingFailed,
+				"last_error": stepErr.Error(),
+				"updated_at": time.Now(),
+			},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+
+	if err != nil {
+		fmt.Printf("Error recording onboarding failure for %s: %v\n", userId.Hex(), err)
+	}
+}
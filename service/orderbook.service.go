@@ -0,0 +1,751 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const maxBookDepth = 50
+
+// errOrderChanged means a resting order was filled or cancelled between being read and matched.
+var errOrderChanged = errors.New("order changed")
+
+// orderBookLocks runs matching one order at a time per book on this server. Every fill is also
+// conditional on the order's remaining quantity, so a quantity is never filled twice.
+var orderBookLocks sync.Map
+
+var openOrderStatuses = []string{utils.OrderOpen, utils.OrderPartial}
+
+type OrderBookService struct {
+	Client *mongo.Database
+
+	cropService      *CropService
+	walletService    *WalletService
+	warehouseService *WarehouseService
+	marketService    *MarketService
+	eventService     *EventService
+}
+
+func NewOrderBookService(client *mongo.Database) *OrderBookService {
+	return &OrderBookService{
+		Client:           client,
+		cropService:      NewCropService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+		marketService:    NewMarketService(client),
+		eventService:     NewEventService(client),
+	}
+}
+
+// PlaceOrder puts an order on the book of the crop and grade and matches it against the resting
+// orders on the other side, best price first and oldest first at each price. Fills trade at the
+// resting order's price. A buy order holds its cost from the wallet and a sell order reserves its
+// stock, and a buy order also reserves warehouse space for what it buys; what a LIMIT order
+// doesn't fill rests on the book, and what a MARKET order doesn't fill is dropped.
+func (bs *OrderBookService) PlaceOrder(ctx context.Context, userId primitive.ObjectID, cropId primitive.ObjectID, body types.PlaceOrder) (*models.OrderResult, error) {
+	orderType := body.Type
+	if orderType == "" {
+		orderType = utils.OrderLimit
+	}
+
+	price := roundCoins(body.Price)
+	if orderType == utils.OrderMarket {
+		price = 0
+	} else if price <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "price must be positive for LIMIT orders")
+	}
+
+	if _, err := bs.cropService.GetCropById(cropId); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusNotFound, "Crop not found")
+		}
+		return nil, err
+	}
+
+	defer bs.lockBook(cropId, body.Grade)()
+
+	now := time.Now()
+
+	order := models.MarketOrder{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		UserID:    userId,
+		CropID:    cropId,
+		Grade:     body.Grade,
+		Side:      body.Side,
+		Type:      orderType,
+		Price:     price,
+		Quantity:  body.Quantity,
+		Remaining: body.Quantity,
+		Status:    utils.OrderOpen,
+		PlacedAt:  now,
+	}
+
+	if order.Side == utils.OrderSell {
+		reservation, err := bs.warehouseService.ReserveGradedStock(ctx, userId, cropId, order.Grade, order.Quantity, "MARKET_ORDER", order.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		order.ReservationID = reservation.ID
+	} else {
+		if err := bs.warehouseService.ReserveCapacity(ctx, userId, order.Quantity, order.ID.Hex()); err != nil {
+			return nil, err
+		}
+		order.SpaceReserved = true
+
+		cost, err := bs.orderCost(ctx, &order)
+		if err != nil {
+			bs.finishOrder(ctx, &order)
+			return nil, err
+		}
+
+		hold, err := bs.walletService.Hold(ctx, userId, cost, "MARKET_ORDER", order.ID.Hex())
+		if err != nil {
+			bs.finishOrder(ctx, &order)
+			return nil, err
+		}
+		order.HoldID = hold.ID
+	}
+
+	if _, err := bs.Client.Collection(utils.MarketOrdersCollection).InsertOne(ctx, order); err != nil {
+		bs.finishOrder(ctx, &order)
+		return nil, err
+	}
+
+	trades, err := bs.match(ctx, &order)
+	if err != nil {
+		fmt.Printf("Error matching order %s: %v\n", order.ID.Hex(), err)
+	}
+
+	switch {
+	case order.Remaining > 0 && order.Type == utils.OrderMarket:
+		if err := bs.closeOrder(ctx, &order); err != nil {
+			fmt.Printf("Error closing market order %s: %v\n", order.ID.Hex(), err)
+		}
+	case order.Status == utils.OrderFilled:
+		bs.finishOrder(ctx, &order)
+	}
+
+	return &models.OrderResult{Order: order, Trades: trades}, nil
+}
+
+// CancelOrder takes the user's open order off the book and returns what it still held.
+func (bs *OrderBookService) CancelOrder(ctx context.Context, userId primitive.ObjectID, orderId primitive.ObjectID) (*models.MarketOrder, error) {
+	order, err := bs.getOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID != userId {
+		return nil, NewServiceError(http.StatusNotFound, "Order not found")
+	}
+
+	defer bs.lockBook(order.CropID, order.Grade)()
+
+	if err := bs.closeOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetOrders returns the user's orders, newest first, optionally only those with status.
+func (bs *OrderBookService) GetOrders(ctx context.Context, userId primitive.ObjectID, status string) ([]models.MarketOrder, error) {
+	filter := bson.M{"user_id": userId}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	cursor, err := bs.Client.Collection(utils.MarketOrdersCollection).Find(ctx, filter, options.Find().SetSort(bson.M{"placed_at": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	orders := []models.MarketOrder{}
+	err = cursor.All(ctx, &orders)
+
+	return orders, err
+}
+
+// GetBook returns up to depth price levels on each side of the crop and grade's book.
+func (bs *OrderBookService) GetBook(ctx context.Context, cropId primitive.ObjectID, grade string, depth int) (*models.OrderBook, error) {
+	if !slices.Contains(utils.Grades, grade) {
+		return nil, NewServiceError(http.StatusBadRequest, "grade must be one of %v", utils.Grades)
+	}
+
+	if depth <= 0 || depth > maxBookDepth {
+		return nil, NewServiceError(http.StatusBadRequest, "depth must be between 1 and %d", maxBookDepth)
+	}
+
+	cursor, err := bs.Client.Collection(utils.MarketOrdersCollection).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"crop_id": cropId,
+			"grade":   grade,
+			"type":    utils.OrderLimit,
+			"status":  bson.M{"$in": openOrderStatuses},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"side": "$side", "price": "$price"},
+			"quantity": bson.M{"$sum": "$remaining"},
+			"orders":   bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var levels []struct {
+		Key struct {
+			Side  string  `bson:"side"`
+			Price float64 `bson:"price"`
+		} `bson:"_id"`
+		Quantity int `bson:"quantity"`
+		Orders   int `bson:"orders"`
+	}
+	if err := cursor.All(ctx, &levels); err != nil {
+		return nil, err
+	}
+
+	book := &models.OrderBook{
+		CropID: cropId,
+		Grade:  grade,
+		Bids:   []models.BookLevel{},
+		Asks:   []models.BookLevel{},
+		At:     time.Now(),
+	}
+
+	for _, level := range levels {
+		bookLevel := models.BookLevel{Price: level.Key.Price, Quantity: level.Quantity, Orders: level.Orders}
+
+		if level.Key.Side == utils.OrderBuy {
+			book.Bids = append(book.Bids, bookLevel)
+		} else {
+			book.Asks = append(book.Asks, bookLevel)
+		}
+	}
+
+	sort.Slice(book.Bids, func(i, j int) bool { return book.Bids[i].Price > book.Bids[j].Price })
+	sort.Slice(book.Asks, func(i, j int) bool { return book.Asks[i].Price < book.Asks[j].Price })
+
+	book.Bids = book.Bids[:min(depth, len(book.Bids))]
+	book.Asks = book.Asks[:min(depth, len(book.Asks))]
+
+	var last models.Trade
+
+	err = bs.Client.Collection(utils.TradesCollection).FindOne(
+		ctx,
+		bson.M{"crop_id": cropId.Hex(), "grade": grade},
+		options.FindOne().SetSort(bson.M{"completed_at": -1}),
+	).Decode(&last)
+
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	book.LastPrice = last.PricePerUnit
+
+	return book, nil
+}
+
+// match fills the taker against the best resting orders until it is filled or nothing on the
+// other side crosses its price.
+func (bs *OrderBookService) match(ctx context.Context, taker *models.MarketOrder) ([]models.Trade, error) {
+	trades := []models.Trade{}
+	collection := bs.Client.Collection(utils.MarketOrdersCollection)
+
+	for taker.Remaining > 0 {
+		var maker models.MarketOrder
+
+		err := collection.FindOne(ctx, counterOrders(taker), options.FindOne().SetSort(counterOrderSort(taker))).Decode(&maker)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return trades, err
+		}
+
+		trade, err := bs.fill(ctx, taker, &maker)
+		if errors.Is(err, errOrderChanged) {
+			continue
+		}
+		if err != nil {
+			return trades, err
+		}
+
+		trades = append(trades, *trade)
+	}
+
+	return trades, nil
+}
+
+// fill trades as much as the taker and maker have left at the maker's price. Both orders are
+// claimed and the trade recorded as SETTLING before any stock or money moves, so a fill that is
+// interrupted after that is finished by the market worker.
+func (bs *OrderBookService) fill(ctx context.Context, taker *models.MarketOrder, maker *models.MarketOrder) (*models.Trade, error) {
+	quantity, price, total := fillTerms(taker, maker)
+
+	if err := bs.claimFill(ctx, maker, quantity, total); err != nil {
+		return nil, err
+	}
+
+	if err := bs.claimFill(ctx, taker, quantity, total); err != nil {
+		bs.unclaimFill(ctx, maker, quantity, total)
+		return nil, err
+	}
+
+	buy, sell := taker, maker
+	if taker.Side == utils.OrderSell {
+		buy, sell = maker, taker
+	}
+
+	now := time.Now()
+	tradeId := primitive.NewObjectID()
+
+	trade := models.Trade{
+		BaseModel: models.BaseModel{
+			ID:        tradeId,
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		TradeID:      tradeId.Hex(),
+		SellerID:     sell.UserID.Hex(),
+		BuyerID:      buy.UserID.Hex(),
+		CropID:       taker.CropID.Hex(),
+		Grade:        taker.Grade,
+		BuyOrderID:   buy.ID.Hex(),
+		SellOrderID:  sell.ID.Hex(),
+		MakerOrderID: maker.ID.Hex(),
+		Quantity:     quantity,
+		PricePerUnit: price,
+		TotalAmount:  total,
+		Status:       utils.TradeSettling,
+		ProposedAt:   maker.PlacedAt,
+		AcceptedAt:   now,
+	}
+
+	if _, err := bs.Client.Collection(utils.TradesCollection).InsertOne(ctx, trade); err != nil {
+		bs.unclaimFill(ctx, taker, quantity, total)
+		bs.unclaimFill(ctx, maker, quantity, total)
+		return nil, err
+	}
+
+	if err := bs.settleTrade(ctx, &trade, buy, sell); err != nil {
+		return nil, err
+	}
+
+	if maker.Status == utils.OrderFilled {
+		bs.finishOrder(ctx, maker)
+	}
+
+	return &trade, nil
+}
+
+// settleTrade moves a filled trade's stock and money: the trade's part of the sell order's
+// reservation goes into the space the buy order reserved, its part of the buy order's hold is
+// captured, and the seller is paid. Each part is taken under the trade id, so the market worker
+// can settle a trade a crash left SETTLING again. Only the call that completes the trade moves
+// the market and tells the maker.
+func (bs *OrderBookService) settleTrade(ctx context.Context, trade *models.Trade, buy *models.MarketOrder, sell *models.MarketOrder) error {
+	description := fmt.Sprintf("Order book trade of %d units", trade.Quantity)
+
+	lots, err := bs.warehouseService.ConsumeReservationPart(ctx, sell.ReservationID, trade.Quantity, trade.TradeID)
+	if err != nil {
+		return err
+	}
+
+	if err := bs.walletService.CaptureHoldPart(ctx, buy.HoldID, trade.TotalAmount, "MARKET_PURCHASE", description, trade.TradeID); err != nil {
+		return err
+	}
+
+	// Orders placed before buy orders reserved space fill from free space.
+	reservationRef := ""
+	if buy.SpaceReserved {
+		reservationRef = buy.ID.Hex()
+	}
+
+	for i, lot := range lots {
+		lot.ID = primitive.NewObjectID()
+		lot.UpdatedAt = time.Now()
+		lot.UserID = buy.UserID
+		lot.CurrentPrice = trade.PricePerUnit
+		lot.Source = "TRADE"
+
+		if err := bs.warehouseService.StoreItemOnce(ctx, &lot, fmt.Sprintf("TRADE:%s:%d", trade.TradeID, i), reservationRef); err != nil {
+			return err
+		}
+	}
+
+	if err := bs.walletService.CreditOnce(ctx, sell.UserID, trade.TotalAmount, "MARKET_SALE", description, trade.TradeID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	result, err := bs.Client.Collection(utils.TradesCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": trade.ID, "status": utils.TradeSettling},
+		bson.M{"$set": bson.M{"status": utils.TradeCompleted, "completed_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	trade.Status = utils.TradeCompleted
+	trade.CompletedAt = now
+
+	if result.ModifiedCount == 0 {
+		return nil
+	}
+
+	bs.marketService.shiftMarket(ctx, buy.CropID, trade.Grade, 0, trade.Quantity, "TRADE")
+
+	// Trades recorded before the maker was stored take the order placed first, which was resting.
+	maker := buy
+	if trade.MakerOrderID == sell.ID.Hex() || (trade.MakerOrderID == "" && sell.PlacedAt.Before(buy.PlacedAt)) {
+		maker = sell
+	}
+
+	bs.eventService.PublishQuietly(ctx, maker.UserID, utils.EventOrderFilled, types.OrderFilledPayload{
+		OrderID:   maker.ID.Hex(),
+		CropID:    maker.CropID.Hex(),
+		Grade:     maker.Grade,
+		Side:      maker.Side,
+		Quantity:  trade.Quantity,
+		Price:     trade.PricePerUnit,
+		Remaining: maker.Remaining,
+	})
+
+	return nil
+}
+
+// RecoverTrades finishes order book trades left settling, and returns what their orders still
+// hold once those orders are filled or cancelled.
+func (bs *OrderBookService) RecoverTrades(ctx context.Context) error {
+	cursor, err := bs.Client.Collection(utils.TradesCollection).Find(ctx, bson.M{
+		"status":        utils.TradeSettling,
+		"sell_order_id": bson.M{"$exists": true},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var trades []models.Trade
+	if err := cursor.All(ctx, &trades); err != nil {
+		return err
+	}
+
+	for i := range trades {
+		trade := &trades[i]
+
+		buy, err := bs.getOrderByHex(ctx, trade.BuyOrderID)
+		if err != nil {
+			fmt.Printf("Error loading buy order of trade %s: %v\n", trade.TradeID, err)
+			continue
+		}
+
+		sell, err := bs.getOrderByHex(ctx, trade.SellOrderID)
+		if err != nil {
+			fmt.Printf("Error loading sell order of trade %s: %v\n", trade.TradeID, err)
+			continue
+		}
+
+		if err := bs.settleTrade(ctx, trade, buy, sell); err != nil {
+			fmt.Printf("Error settling trade %s: %v\n", trade.TradeID, err)
+			continue
+		}
+
+		for _, order := range []*models.MarketOrder{buy, sell} {
+			if order.Status == utils.OrderFilled || order.Status == utils.OrderCancelled {
+				bs.finishOrder(ctx, order)
+			}
+		}
+	}
+
+	return nil
+}
+
+// claimFill takes quantity off the order's remaining quantity, provided nothing else changed it.
+func (bs *OrderBookService) claimFill(ctx context.Context, order *models.MarketOrder, quantity int, value float64) error {
+	remaining := order.Remaining - quantity
+
+	status := utils.OrderPartial
+	if remaining == 0 {
+		status = utils.OrderFilled
+	}
+
+	result, err := bs.Client.Collection(utils.MarketOrdersCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": order.ID, "status": bson.M{"$in": openOrderStatuses}, "remaining": order.Remaining},
+		bson.M{
+			"$set": bson.M{"remaining": remaining, "status": status, "updated_at": time.Now()},
+			"$inc": bson.M{"filled": quantity, "filled_value": value},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.ModifiedCount == 0 {
+		return errOrderChanged
+	}
+
+	order.Remaining = remaining
+	order.Status = status
+	order.Filled += quantity
+	order.FilledValue += value
+
+	return nil
+}
+
+// orderCost is what a buy order holds from the wallet: its limit price for every unit, or what a
+// MARKET order would pay for the sell orders on the book now.
+func (bs *OrderBookService) orderCost(ctx context.Context, order *models.MarketOrder) (float64, error) {
+	if order.Type != utils.OrderMarket {
+		return roundCoins(order.Price * float64(order.Quantity)), nil
+	}
+
+	cost, err := bs.marketCost(ctx, order)
+	if err != nil {
+		return 0, err
+	}
+
+	if cost == 0 {
+		return 0, NewServiceError(http.StatusConflict, "No sell orders to buy from")
+	}
+
+	return cost, nil
+}
+
+// unclaimFill gives back a fill taken by claimFill whose trade could not be recorded.
+func (bs *OrderBookService) unclaimFill(ctx context.Context, order *models.MarketOrder, quantity int, value float64) {
+	remaining := order.Remaining + quantity
+
+	status := utils.OrderPartial
+	if remaining == order.Quantity {
+		status = utils.OrderOpen
+	}
+
+	result, err := bs.Client.Collection(utils.MarketOrdersCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": order.ID, "status": order.Status, "remaining": order.Remaining},
+		bson.M{
+			"$set": bson.M{"remaining": remaining, "status": status, "updated_at": time.Now()},
+			"$inc": bson.M{"filled": -quantity, "filled_value": -value},
+		},
+	)
+	if err != nil || result.ModifiedCount == 0 {
+		fmt.Printf("Error giving back fill of %d units to order %s: %v\n", quantity, order.ID.Hex(), err)
+		return
+	}
+
+	order.Remaining = remaining
+	order.Status = status
+	order.Filled -= quantity
+	order.FilledValue -= value
+}
+
+// marketCost is what a MARKET buy order would pay for the sell orders on the book now.
+func (bs *OrderBookService) marketCost(ctx context.Context, order *models.MarketOrder) (float64, error) {
+	cursor, err := bs.Client.Collection(utils.MarketOrdersCollection).Find(ctx, counterOrders(order), options.Find().SetSort(counterOrderSort(order)))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var asks []models.MarketOrder
+
+	for left := order.Quantity; left > 0 && cursor.Next(ctx); {
+		var ask models.MarketOrder
+		if err := cursor.Decode(&ask); err != nil {
+			return 0, err
+		}
+
+		asks = append(asks, ask)
+		left -= ask.Remaining
+	}
+
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+
+	return costOfAsks(order.Quantity, asks), nil
+}
+
+// costOfAsks is what buying quantity units from asks costs, taking them in the order given. Each
+// ask is paid at its own price, rounded per ask like its trade will be.
+func costOfAsks(quantity int, asks []models.MarketOrder) float64 {
+	cost, left := 0.0, quantity
+
+	for _, ask := range asks {
+		if left == 0 {
+			break
+		}
+
+		take := min(left, ask.Remaining)
+		cost += roundCoins(ask.Price * float64(take))
+		left -= take
+	}
+
+	return cost
+}
+
+// closeOrder cancels what is left of an open order.
+func (bs *OrderBookService) closeOrder(ctx context.Context, order *models.MarketOrder) error {
+	err := bs.Client.Collection(utils.MarketOrdersCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": order.ID, "status": bson.M{"$in": openOrderStatuses}},
+		bson.M{"$set": bson.M{"status": utils.OrderCancelled, "updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(order)
+
+	if err == mongo.ErrNoDocuments {
+		return NewServiceError(http.StatusConflict, "Order is no longer open")
+	}
+	if err != nil {
+		return err
+	}
+
+	bs.finishOrder(ctx, order)
+
+	return nil
+}
+
+// finishOrder returns the funds, stock or warehouse space a filled or cancelled order still holds.
+// A buy order that filled below its limit price gets the difference back here. An order with
+// trades still settling needs what it holds, so the market worker finishes it after them.
+func (bs *OrderBookService) finishOrder(ctx context.Context, order *models.MarketOrder) {
+	settling, err := bs.Client.Collection(utils.TradesCollection).CountDocuments(ctx, bson.M{
+		"status": utils.TradeSettling,
+		"$or": bson.A{
+			bson.M{"buy_order_id": order.ID.Hex()},
+			bson.M{"sell_order_id": order.ID.Hex()},
+		},
+	})
+	if err != nil || settling > 0 {
+		if err != nil {
+			fmt.Printf("Error checking trades of order %s: %v\n", order.ID.Hex(), err)
+		}
+		return
+	}
+
+	if !order.HoldID.IsZero() {
+		if err := bs.walletService.ReleaseHold(ctx, order.HoldID); err != nil {
+			fmt.Printf("Error releasing hold of order %s: %v\n", order.ID.Hex(), err)
+		}
+	}
+
+	if !order.ReservationID.IsZero() {
+		if err := bs.warehouseService.ReleaseReservation(ctx, order.ReservationID); err != nil {
+			fmt.Printf("Error releasing stock of order %s: %v\n", order.ID.Hex(), err)
+		}
+	}
+
+	if order.SpaceReserved {
+		if err := bs.warehouseService.ReleaseCapacity(ctx, order.UserID, order.ID.Hex()); err != nil {
+			fmt.Printf("Error releasing warehouse space of order %s: %v\n", order.ID.Hex(), err)
+		}
+	}
+}
+
+func (bs *OrderBookService) getOrder(ctx context.Context, orderId primitive.ObjectID) (*models.MarketOrder, error) {
+	var order models.MarketOrder
+
+	err := bs.Client.Collection(utils.MarketOrdersCollection).FindOne(ctx, bson.M{"_id": orderId}).Decode(&order)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Order not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (bs *OrderBookService) getOrderByHex(ctx context.Context, orderId string) (*models.MarketOrder, error) {
+	objectId, err := primitive.ObjectIDFromHex(orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.getOrder(ctx, objectId)
+}
+
+// lockBook locks the crop and grade's book and returns the unlock function.
+func (bs *OrderBookService) lockBook(cropId primitive.ObjectID, grade string) func() {
+	value, _ := orderBookLocks.LoadOrStore(cropId.Hex()+":"+grade, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+
+	mu.Lock()
+
+	return mu.Unlock
+}
+
+// counterOrders filters the resting orders the order can trade with. Nobody trades with themselves.
+func counterOrders(order *models.MarketOrder) bson.M {
+	filter := bson.M{
+		"crop_id": order.CropID,
+		"grade":   order.Grade,
+		"type":    utils.OrderLimit,
+		"status":  bson.M{"$in": openOrderStatuses},
+		"user_id": bson.M{"$ne": order.UserID},
+	}
+
+	if order.Side == utils.OrderBuy {
+		filter["side"] = utils.OrderSell
+		if order.Type == utils.OrderLimit {
+			filter["price"] = bson.M{"$lte": order.Price}
+		}
+	} else {
+		filter["side"] = utils.OrderBuy
+		if order.Type == utils.OrderLimit {
+			filter["price"] = bson.M{"$gte": order.Price}
+		}
+	}
+
+	return filter
+}
+
+// counterOrderSort puts the best price for the order first, then the oldest order.
+func counterOrderSort(order *models.MarketOrder) bson.D {
+	direction := 1
+	if order.Side == utils.OrderSell {
+		direction = -1
+	}
+
+	return bson.D{{Key: "price", Value: direction}, {Key: "placed_at", Value: 1}, {Key: "_id", Value: 1}}
+}
+
+// fillTerms is what the taker and maker trade: as much as both have left, at the maker's price.
+func fillTerms(taker *models.MarketOrder, maker *models.MarketOrder) (quantity int, price float64, total float64) {
+	quantity = min(taker.Remaining, maker.Remaining)
+	price = maker.Price
+
+	return quantity, price, roundCoins(price * float64(quantity))
+}
+
+// roundCoins rounds an amount of money to whole cents.
+func roundCoins(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
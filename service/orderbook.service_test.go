@@ -0,0 +1,187 @@
+package service
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testOrder(side string, orderType string, price float64, remaining int, placedAt time.Time) models.MarketOrder {
+	return models.MarketOrder{
+		BaseModel: models.BaseModel{ID: primitive.NewObjectID()},
+		UserID:    primitive.NewObjectID(),
+		Side:      side,
+		Type:      orderType,
+		Price:     price,
+		Quantity:  remaining,
+		Remaining: remaining,
+		PlacedAt:  placedAt,
+	}
+}
+
+// sortLikeBook orders resting orders the way counterOrderSort has the database return them.
+func sortLikeBook(taker *models.MarketOrder, orders []models.MarketOrder) {
+	keys := counterOrderSort(taker)
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		for _, key := range keys {
+			var c int
+			switch key.Key {
+			case "price":
+				c = cmp.Compare(orders[i].Price, orders[j].Price)
+			case "placed_at":
+				c = orders[i].PlacedAt.Compare(orders[j].PlacedAt)
+			case "_id":
+				c = cmp.Compare(orders[i].ID.Hex(), orders[j].ID.Hex())
+			}
+
+			if c != 0 {
+				return c*key.Value.(int) < 0
+			}
+		}
+		return false
+	})
+}
+
+func TestCounterOrders(t *testing.T) {
+	tests := []struct {
+		name      string
+		side      string
+		orderType string
+		wantSide  string
+		wantPrice any
+	}{
+		{"limit buy crosses asks at or below its price", utils.OrderBuy, utils.OrderLimit, utils.OrderSell, bson.M{"$lte": 5.0}},
+		{"limit sell crosses bids at or above its price", utils.OrderSell, utils.OrderLimit, utils.OrderBuy, bson.M{"$gte": 5.0}},
+		{"market buy takes any ask", utils.OrderBuy, utils.OrderMarket, utils.OrderSell, nil},
+		{"market sell takes any bid", utils.OrderSell, utils.OrderMarket, utils.OrderBuy, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			order := testOrder(test.side, test.orderType, 5, 10, time.Now())
+			filter := counterOrders(&order)
+
+			if filter["side"] != test.wantSide {
+				t.Errorf("side = %v, want %v", filter["side"], test.wantSide)
+			}
+			if !reflect.DeepEqual(filter["price"], test.wantPrice) {
+				t.Errorf("price = %v, want %v", filter["price"], test.wantPrice)
+			}
+			if filter["type"] != utils.OrderLimit {
+				t.Errorf("type = %v, want only resting LIMIT orders", filter["type"])
+			}
+			if !reflect.DeepEqual(filter["user_id"], bson.M{"$ne": order.UserID}) {
+				t.Errorf("user_id = %v, want the order's own user left out", filter["user_id"])
+			}
+		})
+	}
+}
+
+func TestCounterOrderSortPriceTimePriority(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		taker  string
+		prices []float64 // resting orders, placed one second apart in this order
+		want   []int     // indexes into prices, best first
+	}{
+		{"buy takes the cheapest ask first", utils.OrderBuy, []float64{6, 4, 5}, []int{1, 2, 0}},
+		{"sell takes the highest bid first", utils.OrderSell, []float64{4, 6, 5}, []int{1, 2, 0}},
+		{"buy takes the oldest ask at a price", utils.OrderBuy, []float64{5, 4, 5, 4}, []int{1, 3, 0, 2}},
+		{"sell takes the oldest bid at a price", utils.OrderSell, []float64{5, 6, 6, 5}, []int{1, 2, 0, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taker := testOrder(test.taker, utils.OrderMarket, 0, 10, start)
+
+			side := utils.OrderSell
+			if test.taker == utils.OrderSell {
+				side = utils.OrderBuy
+			}
+
+			resting := make([]models.MarketOrder, len(test.prices))
+			for i, price := range test.prices {
+				resting[i] = testOrder(side, utils.OrderLimit, price, 1, start.Add(time.Duration(i)*time.Second))
+			}
+
+			sorted := slices.Clone(resting)
+			sortLikeBook(&taker, sorted)
+
+			for i, index := range test.want {
+				if sorted[i].ID != resting[index].ID {
+					t.Fatalf("position %d holds the order priced %v placed at %v, want order %d", i, sorted[i].Price, sorted[i].PlacedAt, index)
+				}
+			}
+		})
+	}
+}
+
+func TestFillTerms(t *testing.T) {
+	tests := []struct {
+		name         string
+		taker, maker int
+		makerPrice   float64
+		wantQuantity int
+		wantTotal    float64
+	}{
+		{"taker larger than maker fills the maker", 10, 4, 2.5, 4, 10},
+		{"maker larger than taker fills the taker", 3, 8, 2.5, 3, 7.5},
+		{"equal sizes fill both", 6, 6, 1.1, 6, 6.6},
+		{"total is rounded to cents", 3, 3, 0.333, 3, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taker := testOrder(utils.OrderBuy, utils.OrderLimit, 99, test.taker, time.Now())
+			maker := testOrder(utils.OrderSell, utils.OrderLimit, test.makerPrice, test.maker, time.Now())
+
+			quantity, price, total := fillTerms(&taker, &maker)
+
+			if quantity != test.wantQuantity || price != test.makerPrice || total != test.wantTotal {
+				t.Fatalf("got %d at %v for %v, want %d at %v for %v", quantity, price, total, test.wantQuantity, test.makerPrice, test.wantTotal)
+			}
+		})
+	}
+}
+
+func TestCostOfAsks(t *testing.T) {
+	asks := func(levels ...float64) []models.MarketOrder {
+		orders := []models.MarketOrder{}
+		for i := 0; i < len(levels); i += 2 {
+			orders = append(orders, testOrder(utils.OrderSell, utils.OrderLimit, levels[i], int(levels[i+1]), time.Now()))
+		}
+		return orders
+	}
+
+	tests := []struct {
+		name     string
+		quantity int
+		asks     []models.MarketOrder
+		want     float64
+	}{
+		{"one ask covers the order", 5, asks(2, 10), 10},
+		{"order walks up the book", 12, asks(2, 10, 3, 10), 26},
+		{"book shorter than the order holds what is there", 30, asks(2, 10, 3, 10), 50},
+		{"each ask is rounded on its own", 2, asks(0.333, 1, 0.333, 1), 0.66},
+		{"empty book costs nothing", 5, asks(), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if cost := costOfAsks(test.quantity, test.asks); cost != test.want {
+				t.Fatalf("cost = %v, want %v", cost, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/outbreak"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutbreakService struct {
+	Client *mongo.Database
+
+	engine        *outbreak.Engine
+	cropService   *CropService
+	careService   *CareService
+	walletService *WalletService
+	eventService  *EventService
+}
+
+func NewOutbreakService(client *mongo.Database) *OutbreakService {
+	return &OutbreakService{
+		Client:        client,
+		engine:        outbreak.NewEngine(outbreak.ConfigFromEnv()),
+		cropService:   NewCropService(client),
+		careService:   NewCareService(client),
+		walletService: NewWalletService(client),
+		eventService:  NewEventService(client),
+	}
+}
+
+// InfectedPositions returns the land positions of every untreated infection, by owner.
+func (ob *OutbreakService) InfectedPositions(ctx context.Context) (map[primitive.ObjectID]map[int]bool, error) {
+	cursor, err := ob.Client.Collection(utils.PlantedCropsCollection).Find(
+		ctx,
+		bson.M{
+			"is_active":            true,
+			"is_harvested":         false,
+			"infection":            bson.M{"$exists": true},
+			"infection.treated_at": bson.M{"$exists": false},
+		},
+		options.Find().SetProjection(bson.M{"land_unit_ids": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var infected []models.PlantedCrop
+	if err := cursor.All(ctx, &infected); err != nil {
+		return nil, err
+	}
+
+	units, err := ob.getLandUnits(ctx, infected)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[primitive.ObjectID]map[int]bool)
+	for _, unit := range units {
+		if positions[unit.OwnerID] == nil {
+			positions[unit.OwnerID] = make(map[int]bool)
+		}
+		positions[unit.OwnerID][unit.Position] = true
+	}
+
+	return positions, nil
+}
+
+// SpreadOutbreaks rolls for an outbreak on each healthy planting, counting infected land next to
+// any of its land units. A treated planting is healthy again and can be reinfected. Plantings
+// whose crop is missing from crops are skipped.
+func (ob *OutbreakService) SpreadOutbreaks(ctx context.Context, plantings []models.PlantedCrop, crops map[primitive.ObjectID]*models.Crop, infected map[primitive.ObjectID]map[int]bool) error {
+	healthy := make([]models.PlantedCrop, 0, len(plantings))
+	for _, planting := range plantings {
+		treated := planting.Infection == nil || !planting.Infection.TreatedAt.IsZero()
+		if treated && crops[planting.CropID] != nil {
+			healthy = append(healthy, planting)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	units, err := ob.getLandUnits(ctx, healthy)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, planting := range healthy {
+		neighbours := 0
+
+		for _, unitId := range planting.LandUnitIDs {
+			unit, ok := units[unitId]
+			if !ok {
+				continue
+			}
+
+			for _, position := range neighbourPositions(unit.Position) {
+				if infected[unit.OwnerID][position] {
+					neighbours++
+				}
+			}
+		}
+
+		crop := crops[planting.CropID]
+
+		if kind := ob.engine.Roll(crop, planting, neighbours, now); kind != "" {
+			if err := ob.infect(ctx, planting, crop, kind, neighbours, now); err != nil {
+				fmt.Printf("Error infecting planting %s: %v\n", planting.ID.Hex(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ob *OutbreakService) infect(ctx context.Context, planting models.PlantedCrop, crop *models.Crop, kind string, neighbours int, now time.Time) error {
+	infection := ob.engine.NewInfection(kind, now, neighbours)
+
+	// A reinfection replaces the treated infection it was rolled against, keeping its damage.
+	filter := bson.M{"_id": planting.ID, "is_harvested": false, "infection": bson.M{"$exists": false}}
+	if planting.Infection != nil {
+		infection.PriorDamage = planting.Infection.Damage
+		filter = bson.M{"_id": planting.ID, "is_harvested": false, "infection.treated_at": planting.Infection.TreatedAt}
+	}
+
+	result, err := ob.Client.Collection(utils.PlantedCropsCollection).UpdateOne(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"infection": infection, "updated_at": now}},
+	)
+	if err != nil || result.ModifiedCount == 0 {
+		return err
+	}
+
+	ob.careService.recordActivity(ctx, &planting, &models.PlantingActivity{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		PlantingID:    planting.ID,
+		UserID:        planting.UserID,
+		CropID:        planting.CropID,
+		ActivityType:  kind,
+		Description:   fmt.Sprintf("Outbreak of %s on %s, destroying %.0f%% of the yield per hour until treated", strings.ToLower(kind), crop.Name, infection.DamagePerHour*100),
+		QualityFactor: careQualityOf(&planting),
+		Timestamp:     now,
+	})
+
+	ob.eventService.PublishQuietly(ctx, planting.UserID, utils.EventOutbreak, types.OutbreakPayload{
+		PlantingID:         planting.ID.Hex(),
+		CropID:             planting.CropID.Hex(),
+		Kind:               kind,
+		InfectedNeighbours: neighbours,
+	})
+
+	return nil
+}
+
+// Treat stops an infection on the user's planting from doing further damage. Like care actions
+// the cost is held until the treatment is recorded.
+func (ob *OutbreakService) Treat(ctx context.Context, userId primitive.ObjectID, plantingId primitive.ObjectID) (*models.CareResult, error) {
+	planting, err := ob.careService.getPlanting(ctx, userId, plantingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if planting.IsHarvested {
+		return nil, NewServiceError(http.StatusBadRequest, "Planting is already harvested")
+	}
+
+	if planting.Infection == nil || !planting.Infection.TreatedAt.IsZero() {
+		return nil, NewServiceError(http.StatusBadRequest, "Planting is not infected")
+	}
+
+	crop, err := ob.cropService.GetCropById(planting.CropID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	damage := outbreak.PlantingDamage(*planting, now)
+
+	activity := models.PlantingActivity{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		PlantingID:    planting.ID,
+		UserID:        userId,
+		CropID:        planting.CropID,
+		ActivityType:  "TREAT",
+		Description:   fmt.Sprintf("Treated %s for %s after losing %.0f%% of the yield", crop.Name, strings.ToLower(planting.Infection.Kind), damage*100),
+		Cost:          ob.engine.TreatmentCost(crop, *planting),
+		QualityFactor: careQualityOf(planting),
+		Timestamp:     now,
+	}
+
+	var hold *models.WalletHold
+	if activity.Cost > 0 {
+		hold, err = ob.walletService.Hold(ctx, userId, activity.Cost, "CROP_TREATMENT", activity.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var updated models.PlantedCrop
+
+	err = ob.Client.Collection(utils.PlantedCropsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"_id":                   planting.ID,
+			"is_harvested":          false,
+			"infection.infected_at": bson.M{"$exists": true},
+			"infection.treated_at":  bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{
+			"infection.treated_at": now,
+			"infection.damage":     damage,
+			"updated_at":           now,
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+
+	if err != nil {
+		if hold != nil {
+			if releaseErr := ob.walletService.ReleaseHold(ctx, hold.ID); releaseErr != nil {
+				fmt.Printf("Error releasing treatment hold %s: %v\n", hold.ID.Hex(), releaseErr)
+			}
+		}
+
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusConflict, "Planting changed, try again")
+		}
+		return nil, err
+	}
+
+	if hold != nil {
+		if err := ob.walletService.CaptureHold(ctx, hold.ID, "CROP_TREATMENT", activity.Description); err != nil {
+			// As with care, a claimed capture is finished by the wallet worker; otherwise nothing
+			// was paid and the treatment is taken back.
+			if current, getErr := ob.walletService.GetHold(ctx, hold.ID); getErr != nil || current.Status != utils.EscrowCaptured {
+				ob.revertTreatment(ctx, planting.ID, now)
+
+				if releaseErr := ob.walletService.ReleaseHold(ctx, hold.ID); releaseErr != nil {
+					fmt.Printf("Error releasing treatment hold %s: %v\n", hold.ID.Hex(), releaseErr)
+				}
+				return nil, err
+			}
+		}
+	}
+
+	ob.careService.recordActivity(ctx, planting, &activity)
+
+	return &models.CareResult{Planting: &updated, Activity: activity}, nil
+}
+
+// revertTreatment takes back a treatment recorded at treatedAt whose cost could not be paid.
+func (ob *OutbreakService) revertTreatment(ctx context.Context, plantingId primitive.ObjectID, treatedAt time.Time) {
+	_, err := ob.Client.Collection(utils.PlantedCropsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": plantingId, "infection.treated_at": treatedAt},
+		bson.M{
+			"$unset": bson.M{"infection.treated_at": "", "infection.damage": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error reverting treatment of planting %s: %v\n", plantingId.Hex(), err)
+	}
+}
+
+// getLandUnits loads the land units of the plantings, by hex id.
+func (ob *OutbreakService) getLandUnits(ctx context.Context, plantings []models.PlantedCrop) (map[string]models.LandUnit, error) {
+	var ids []string
+	for _, planting := range plantings {
+		ids = append(ids, planting.LandUnitIDs...)
+	}
+
+	units := make(map[string]models.LandUnit, len(ids))
+	if len(ids) == 0 {
+		return units, nil
+	}
+
+	objectIds, err := utils.ConvertObjectIdsFromStringIds(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := ob.Client.Collection(utils.LandUnitsCollection).Find(ctx, bson.M{"_id": bson.M{"$in": objectIds}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []models.LandUnit
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	for _, unit := range found {
+		units[unit.ID.Hex()] = unit
+	}
+
+	return units, nil
+}
+
+// neighbourPositions returns the positions sharing an edge with position on its owner's land grid.
+func neighbourPositions(position int) []int {
+	x, y := GridCell(position)
+	neighbours := []int{GridPosition(x, y-1), GridPosition(x, y+1)}
+
+	if x > 0 {
+		neighbours = append(neighbours, GridPosition(x-1, y))
+	}
+
+	if x < utils.LandGridWidth-1 {
+		neighbours = append(neighbours, GridPosition(x+1, y))
+	}
+
+	return neighbours
+}
+
+// validateSusceptibility checks a crop's outbreak susceptibilities.
+func validateSusceptibility(susceptibility map[string]float64) error {
+	for kind, value := range susceptibility {
+		if kind != outbreak.Pest && kind != outbreak.Disease {
+			return NewServiceError(http.StatusBadRequest, "unknown outbreak kind %q", kind)
+		}
+
+		if value < 0 || value > 10 {
+			return NewServiceError(http.StatusBadRequest, "susceptibility to %s must be between 0 and 10", kind)
+		}
+	}
+
+	return nil
+}
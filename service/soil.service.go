@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// Yield multiplier on perfectly fertile soil; default soil gives 1.0 and exhausted soil 0.6.
+	soilFactorBase  = 0.6
+	soilFactorRange = 0.5
+
+	rotationBonus       = 1.05
+	monocropPenalty     = 0.1
+	monocropMaxPenalty  = 0.3
+	defaultNutrientDraw = -10.0
+)
+
+var nutrients = []string{utils.NutrientNitrogen, utils.NutrientPhosphorus, utils.NutrientPotassium}
+
+type SoilService struct {
+	Client *mongo.Database
+}
+
+func NewSoilService(client *mongo.Database) *SoilService {
+	return &SoilService{Client: client}
+}
+
+// PlantOn applies the crop to the soil of the land units it is being planted on and returns the
+// yield multiplier: each unit's fertility and rotation, averaged over the units. Planting the same
+// crop again costs 10% per earlier planting in a row, up to 30%; switching crops earns 5%.
+func (ss *SoilService) PlantOn(ctx context.Context, units []models.LandUnit, crop *models.Crop) (float64, error) {
+	if len(units) == 0 {
+		return 1, nil
+	}
+
+	now := time.Now()
+	total := 0.0
+	writes := make([]mongo.WriteModel, 0, len(units))
+
+	for _, unit := range units {
+		soil := CurrentSoil(unit, now)
+		factor := soilFactor(soil)
+
+		switch {
+		case soil.LastCropID == crop.ID:
+			factor *= 1 - math.Min(monocropMaxPenalty, monocropPenalty*float64(soil.RotationStreak))
+			soil.RotationStreak++
+		case !soil.LastCropID.IsZero():
+			factor *= rotationBonus
+			soil.RotationStreak = 1
+		default:
+			soil.RotationStreak = 1
+		}
+
+		total += factor
+
+		for _, nutrient := range nutrients {
+			change := defaultNutrientDraw
+			if len(crop.SoilImpact) > 0 {
+				change = crop.SoilImpact[nutrient]
+			}
+			setNutrient(&soil, nutrient, nutrientOf(soil, nutrient)+change)
+		}
+
+		soil.LastCropID = crop.ID
+		soil.FallowSince = time.Time{}
+
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": unit.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"soil": soil, "updated_at": now}}))
+	}
+
+	if _, err := ss.Client.Collection(utils.LandUnitsCollection).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return 0, err
+	}
+
+	return total / float64(len(units)), nil
+}
+
+// RestoreSoil puts back the soil the land units had before PlantOn, for a planting that was never
+// created.
+func (ss *SoilService) RestoreSoil(ctx context.Context, units []models.LandUnit) error {
+	if len(units) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(units))
+
+	for _, unit := range units {
+		update := bson.M{"$unset": bson.M{"soil": ""}, "$set": bson.M{"updated_at": now}}
+		if unit.Soil != nil {
+			update = bson.M{"$set": bson.M{"soil": *unit.Soil, "updated_at": now}}
+		}
+
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": unit.ID}).SetUpdate(update))
+	}
+
+	_, err := ss.Client.Collection(utils.LandUnitsCollection).BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+
+	return err
+}
+
+// MarkFallow starts fallow recovery on land units that were just freed.
+func (ss *SoilService) MarkFallow(ctx context.Context, landUnitIDs []string) error {
+	landObjectIds, err := utils.ConvertObjectIdsFromStringIds(landUnitIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = ss.Client.Collection(utils.LandUnitsCollection).UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": landObjectIds}, "soil": bson.M{"$exists": true}},
+		bson.M{"$set": bson.M{"soil.fallow_since": time.Now()}},
+	)
+
+	return err
+}
+
+// CurrentSoil returns the unit's soil at now, including what it recovered while lying fallow.
+// Units never planted on have default soil.
+func CurrentSoil(unit models.LandUnit, now time.Time) models.Soil {
+	if unit.Soil == nil {
+		return models.Soil{
+			Nitrogen:   utils.DefaultSoilNutrient,
+			Phosphorus: utils.DefaultSoilNutrient,
+			Potassium:  utils.DefaultSoilNutrient,
+		}
+	}
+
+	soil := *unit.Soil
+
+	if !soil.FallowSince.IsZero() && now.After(soil.FallowSince) {
+		recovered := now.Sub(soil.FallowSince).Hours() / 24 * utils.FallowRecoveryPerDay
+
+		for _, nutrient := range nutrients {
+			setNutrient(&soil, nutrient, nutrientOf(soil, nutrient)+recovered)
+		}
+	}
+
+	return soil
+}
+
+// soilFactor scales yield with the soil's mean fertility.
+func soilFactor(soil models.Soil) float64 {
+	fertility := (soil.Nitrogen + soil.Phosphorus + soil.Potassium) / (3 * utils.MaxSoilNutrient)
+
+	return soilFactorBase + soilFactorRange*fertility
+}
+
+func nutrientOf(soil models.Soil, nutrient string) float64 {
+	switch nutrient {
+	case utils.NutrientNitrogen:
+		return soil.Nitrogen
+	case utils.NutrientPhosphorus:
+		return soil.Phosphorus
+	case utils.NutrientPotassium:
+		return soil.Potassium
+	}
+
+	return 0
+}
+
+func setNutrient(soil *models.Soil, nutrient string, value float64) {
+	value = math.Max(0, math.Min(utils.MaxSoilNutrient, value))
+
+	switch nutrient {
+	case utils.NutrientNitrogen:
+		soil.Nitrogen = value
+	case utils.NutrientPhosphorus:
+		soil.Phosphorus = value
+	case utils.NutrientPotassium:
+		soil.Potassium = value
+	}
+}
+
+// validateSoilImpact checks a crop's soil impact.
+func validateSoilImpact(impact map[string]float64) error {
+	for nutrient, change := range impact {
+		if nutrient != utils.NutrientNitrogen && nutrient != utils.NutrientPhosphorus && nutrient != utils.NutrientPotassium {
+			return NewServiceError(http.StatusBadRequest, "unknown soil nutrient %q", nutrient)
+		}
+
+		if change < -utils.MaxSoilNutrient || change > utils.MaxSoilNutrient {
+			return NewServiceError(http.StatusBadRequest, "soil impact on %s must be between -100 and 100", nutrient)
+		}
+	}
+
+	return nil
+}
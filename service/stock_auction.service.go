@@ -0,0 +1,554 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StockAuctionService struct {
+	Client *mongo.Database
+
+	cropService      *CropService
+	walletService    *WalletService
+	warehouseService *WarehouseService
+	marketService    *MarketService
+	eventService     *EventService
+}
+
+func NewStockAuctionService(client *mongo.Database) *StockAuctionService {
+	return &StockAuctionService{
+		Client:           client,
+		cropService:      NewCropService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+		marketService:    NewMarketService(client),
+		eventService:     NewEventService(client),
+	}
+}
+
+// CreateAuction reserves a lot of the seller's stock and auctions it to the highest bidder.
+func (sa *StockAuctionService) CreateAuction(ctx context.Context, sellerId primitive.ObjectID, body types.CreateStockAuction) (*models.StockAuction, error) {
+	cropId, err := primitive.ObjectIDFromHex(body.CropID)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, "invalid crop id")
+	}
+
+	crop, err := sa.cropService.GetCropById(cropId)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusNotFound, "Crop not found")
+		}
+		return nil, err
+	}
+
+	startPrice := roundCoins(body.StartPrice)
+	if startPrice <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "start_price must be at least 0.01")
+	}
+
+	now := time.Now()
+
+	auction := models.StockAuction{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		SellerID:     sellerId,
+		CropID:       cropId,
+		Quantity:     body.Quantity,
+		StartPrice:   startPrice,
+		ReservePrice: roundCoins(body.ReservePrice),
+		Status:       utils.AuctionOpen,
+		EndsAt:       now.Add(time.Duration(body.DurationMinutes) * time.Minute),
+	}
+
+	var reservation *models.StockReservation
+	if body.Grade != "" {
+		reservation, err = sa.warehouseService.ReserveGradedStock(ctx, sellerId, cropId, body.Grade, body.Quantity, "STOCK_AUCTION", auction.ID.Hex())
+	} else {
+		reservation, err = sa.warehouseService.ReserveStock(ctx, sellerId, cropId, body.Quantity, "STOCK_AUCTION", auction.ID.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	auction.ReservationID = reservation.ID
+	auction.QualityFactor = averageQuality(reservation.Lots)
+	auction.Grade = body.Grade
+	if auction.Grade == "" {
+		auction.Grade = utils.QualityGrade(auction.QualityFactor, crop.GradeThresholds)
+	}
+
+	if _, err := sa.Client.Collection(utils.StockAuctionsCollection).InsertOne(ctx, auction); err != nil {
+		sa.releaseStock(ctx, &auction)
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+// GetAuctions returns the stock auctions with the given status, ending soonest first.
+func (sa *StockAuctionService) GetAuctions(ctx context.Context, status string) ([]models.StockAuction, error) {
+	cursor, err := sa.Client.Collection(utils.StockAuctionsCollection).Find(
+		ctx,
+		bson.M{"status": status},
+		options.Find().SetSort(bson.M{"ends_at": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	auctions := []models.StockAuction{}
+	err = cursor.All(ctx, &auctions)
+
+	return auctions, err
+}
+
+// GetBids returns an auction's full bid history, newest first.
+func (sa *StockAuctionService) GetBids(ctx context.Context, auctionId primitive.ObjectID) ([]models.StockBid, error) {
+	if _, err := sa.getAuction(ctx, auctionId); err != nil {
+		return nil, err
+	}
+
+	cursor, err := sa.Client.Collection(utils.StockBidsCollection).Find(
+		ctx,
+		bson.M{"auction_id": auctionId},
+		options.Find().SetSort(bson.M{"placed_at": -1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	bids := []models.StockBid{}
+	err = cursor.All(ctx, &bids)
+
+	return bids, err
+}
+
+// CancelAuction lets the seller take down an open auction nobody has bid on and returns the stock.
+func (sa *StockAuctionService) CancelAuction(ctx context.Context, sellerId primitive.ObjectID, auctionId primitive.ObjectID) (*models.StockAuction, error) {
+	auction, err := sa.getAuction(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case auction.SellerID != sellerId:
+		return nil, NewServiceError(http.StatusForbidden, "Only the seller can cancel an auction")
+	case auction.Status != utils.AuctionOpen:
+		return nil, NewServiceError(http.StatusConflict, "Auction is no longer open")
+	case auction.Bids > 0:
+		return nil, NewServiceError(http.StatusConflict, "Auctions with bids can't be cancelled")
+	}
+
+	err = sa.Client.Collection(utils.StockAuctionsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": auction.ID, "status": utils.AuctionOpen, "bids": 0},
+		bson.M{"$set": bson.M{"status": utils.AuctionCancelled, "closed_at": time.Now(), "updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(auction)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusConflict, "Auction changed, try again")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sa.releaseStock(ctx, auction)
+
+	return auction, nil
+}
+
+// PlaceBid bids amount for the whole lot. Like lease auctions, the amount is held from the
+// bidder's wallet, the beaten bid gets its funds back and a late bid pushes the end back.
+func (sa *StockAuctionService) PlaceBid(ctx context.Context, bidderId primitive.ObjectID, auctionId primitive.ObjectID, amount float64) (*models.StockBid, error) {
+	auction, err := sa.getAuction(ctx, auctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if auction.Status != utils.AuctionOpen || !auction.EndsAt.After(now) {
+		return nil, NewServiceError(http.StatusConflict, "Auction is closed")
+	}
+
+	if auction.SellerID == bidderId {
+		return nil, NewServiceError(http.StatusBadRequest, "You can't bid on your own auction")
+	}
+
+	minimum := auction.StartPrice
+	if auction.Bids > 0 {
+		minimum = roundCoins(auction.HighestBid * (1 + auctionMinIncrement))
+	}
+
+	amount = roundCoins(amount)
+	if amount < minimum {
+		return nil, NewServiceError(http.StatusBadRequest, "Bid must be at least %.2f", minimum)
+	}
+
+	// The winner can't be asked for room later, so a bid needs it now.
+	warehouse, err := sa.warehouseService.GetUserWarehouse(ctx, bidderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if warehouse.TotalCapacity > 0 && warehouse.UsedCapacity+auction.Quantity > warehouse.TotalCapacity {
+		return nil, NewServiceError(http.StatusBadRequest, "Not enough warehouse space")
+	}
+
+	bid := models.StockBid{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		AuctionID: auction.ID,
+		BidderID:  bidderId,
+		Amount:    amount,
+		Status:    utils.BidActive,
+		PlacedAt:  now,
+	}
+
+	hold, err := sa.walletService.Hold(ctx, bidderId, amount, "STOCK_BID", bid.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	bid.HoldID = hold.ID
+
+	update := bson.M{
+		"$set": bson.M{
+			"highest_bid":       amount,
+			"highest_bidder_id": bidderId,
+			"highest_bid_id":    bid.ID,
+			"highest_hold_id":   hold.ID,
+			"reserve_met":       amount >= auction.ReservePrice,
+			"updated_at":        now,
+		},
+		"$inc": bson.M{"bids": 1},
+	}
+
+	if auction.EndsAt.Sub(now) < auctionSnipeWindow {
+		update["$set"].(bson.M)["ends_at"] = now.Add(auctionSnipeWindow)
+		update["$inc"].(bson.M)["extensions"] = 1
+	}
+
+	// Matching the bid count means no other bid got in since the auction was read.
+	result, err := sa.Client.Collection(utils.StockAuctionsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": auction.ID, "status": utils.AuctionOpen, "bids": auction.Bids, "ends_at": bson.M{"$gt": now}},
+		update,
+	)
+	if err == nil && result.ModifiedCount == 0 {
+		err = NewServiceError(http.StatusConflict, "Another bid came in or the auction closed, try again")
+	}
+	if err != nil {
+		if releaseErr := sa.walletService.ReleaseHold(ctx, hold.ID); releaseErr != nil {
+			fmt.Printf("Error releasing bid hold %s: %v\n", hold.ID.Hex(), releaseErr)
+		}
+		return nil, err
+	}
+
+	if _, err := sa.Client.Collection(utils.StockBidsCollection).InsertOne(ctx, bid); err != nil {
+		fmt.Printf("Error recording bid %s on stock auction %s: %v\n", bid.ID.Hex(), auction.ID.Hex(), err)
+	}
+
+	if auction.Bids > 0 {
+		sa.refundBid(ctx, auction.HighestBidID, auction.HighestHoldID)
+
+		if auction.HighestBidderID != bidderId {
+			sa.eventService.PublishQuietly(ctx, auction.HighestBidderID, utils.EventOutbid, types.AuctionPayload{
+				AuctionID: auction.ID.Hex(),
+				Amount:    amount,
+			})
+		}
+	}
+
+	return &bid, nil
+}
+
+// CloseAuctions settles every stock auction that has ended, and any a previous run left settling.
+func (sa *StockAuctionService) CloseAuctions(ctx context.Context) error {
+	cursor, err := sa.Client.Collection(utils.StockAuctionsCollection).Find(ctx, bson.M{"$or": []bson.M{
+		{"status": utils.AuctionOpen, "ends_at": bson.M{"$lte": time.Now()}},
+		{"status": utils.AuctionSettling},
+	}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var auctions []models.StockAuction
+	if err := cursor.All(ctx, &auctions); err != nil {
+		return err
+	}
+
+	for i := range auctions {
+		auction := &auctions[i]
+
+		if auction.Status == utils.AuctionOpen {
+			// A late bid may have pushed the end back since the auction was read.
+			err := sa.Client.Collection(utils.StockAuctionsCollection).FindOneAndUpdate(
+				ctx,
+				bson.M{"_id": auction.ID, "status": utils.AuctionOpen, "ends_at": bson.M{"$lte": time.Now()}},
+				bson.M{"$set": bson.M{"status": utils.AuctionSettling, "updated_at": time.Now()}},
+				options.FindOneAndUpdate().SetReturnDocument(options.After),
+			).Decode(auction)
+
+			if err == mongo.ErrNoDocuments {
+				continue
+			}
+			if err != nil {
+				fmt.Printf("Error closing stock auction %s: %v\n", auction.ID.Hex(), err)
+				continue
+			}
+		}
+
+		if err := sa.settle(ctx, auction); err != nil {
+			fmt.Printf("Error settling stock auction %s: %v\n", auction.ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// settle sells a closed auction's lot to its highest bidder: the stock moves to their warehouse,
+// their held bid is taken and paid to the seller, and the price per unit goes into the price
+// history. Without a bid meeting the reserve the lot goes back to the seller. The lot is taken
+// from the reservation and stored under keys of the auction, and the seller is credited once per
+// auction, so the auction worker retries a settlement that failed; stock the winner has no room
+// for yet stays with the reservation part and is stored on a later try.
+func (sa *StockAuctionService) settle(ctx context.Context, auction *models.StockAuction) error {
+	if auction.Bids == 0 || auction.HighestBid < auction.ReservePrice {
+		if err := sa.warehouseService.ReleaseReservation(ctx, auction.ReservationID); err != nil {
+			return err
+		}
+
+		if auction.Bids > 0 {
+			sa.refundBid(ctx, auction.HighestBidID, auction.HighestHoldID)
+		}
+
+		if _, err := sa.finish(ctx, auction, utils.AuctionUnsold, ""); err != nil {
+			return err
+		}
+
+		return sa.refundLosingBids(ctx, auction)
+	}
+
+	now := time.Now()
+	key := auction.ID.Hex()
+
+	lots, err := sa.warehouseService.ConsumeReservationPart(ctx, auction.ReservationID, auction.Quantity, "AUCTION:"+key)
+	if err != nil {
+		return err
+	}
+
+	for i, lot := range lots {
+		lot.ID = primitive.NewObjectID()
+		lot.UpdatedAt = now
+		lot.UserID = auction.HighestBidderID
+		lot.CurrentPrice = roundCoins(auction.HighestBid / float64(auction.Quantity))
+		lot.Source = "AUCTION"
+
+		if err := sa.warehouseService.StoreItemOnce(ctx, &lot, fmt.Sprintf("AUCTION:%s:%d", key, i), ""); err != nil {
+			return err
+		}
+	}
+
+	description := fmt.Sprintf("Auction of %d units", auction.Quantity)
+
+	if err := sa.walletService.CaptureHold(ctx, auction.HighestHoldID, "AUCTION_PURCHASE", description); err != nil {
+		return err
+	}
+
+	if err := sa.walletService.CreditOnce(ctx, auction.SellerID, auction.HighestBid, "AUCTION_SALE", description, key); err != nil {
+		return err
+	}
+
+	tradeId := primitive.NewObjectID()
+
+	awarded, err := sa.finish(ctx, auction, utils.AuctionAwarded, tradeId.Hex())
+	if err != nil {
+		return err
+	}
+
+	// Only the call that awarded the auction records the sale.
+	if awarded {
+		sa.recordSale(ctx, auction, tradeId, now)
+
+		sa.eventService.PublishQuietly(ctx, auction.HighestBidderID, utils.EventAuctionWon, types.AuctionPayload{
+			AuctionID: auction.ID.Hex(),
+			Amount:    auction.HighestBid,
+			TradeID:   tradeId.Hex(),
+		})
+	}
+
+	if _, err := sa.Client.Collection(utils.StockBidsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": auction.HighestBidID},
+		bson.M{"$set": bson.M{"status": utils.BidWon, "updated_at": now}},
+	); err != nil {
+		fmt.Printf("Error marking winning bid %s: %v\n", auction.HighestBidID.Hex(), err)
+	}
+
+	return sa.refundLosingBids(ctx, auction)
+}
+
+// recordSale writes the trade of an awarded auction and its price per unit to the price history.
+func (sa *StockAuctionService) recordSale(ctx context.Context, auction *models.StockAuction, tradeId primitive.ObjectID, now time.Time) {
+	pricePerUnit := roundCoins(auction.HighestBid / float64(auction.Quantity))
+
+	trade := models.Trade{
+		BaseModel: models.BaseModel{
+			ID:        tradeId,
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		TradeID:      tradeId.Hex(),
+		SellerID:     auction.SellerID.Hex(),
+		BuyerID:      auction.HighestBidderID.Hex(),
+		CropID:       auction.CropID.Hex(),
+		Grade:        auction.Grade,
+		AuctionID:    auction.ID.Hex(),
+		Quantity:     auction.Quantity,
+		PricePerUnit: pricePerUnit,
+		TotalAmount:  auction.HighestBid,
+		Status:       utils.TradeCompleted,
+		ProposedAt:   auction.CreatedAt,
+		AcceptedAt:   now,
+		CompletedAt:  now,
+	}
+
+	if _, err := sa.Client.Collection(utils.TradesCollection).InsertOne(ctx, trade); err != nil {
+		fmt.Printf("Error recording trade %s: %v\n", trade.TradeID, err)
+	}
+
+	history := models.PriceHistory{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		CropID:    auction.CropID.Hex(),
+		Grade:     auction.Grade,
+		Price:     pricePerUnit,
+		Timestamp: now,
+		Reason:    "AUCTION",
+	}
+
+	if _, err := sa.Client.Collection(utils.PriceHistoryCollection).InsertOne(ctx, history); err != nil {
+		fmt.Printf("Error recording price history for %s: %v\n", auction.CropID.Hex(), err)
+	}
+
+	sa.marketService.shiftMarket(ctx, auction.CropID, auction.Grade, 0, auction.Quantity, "AUCTION")
+}
+
+// refundLosingBids refunds bids whose refund was interrupted when they were outbid.
+func (sa *StockAuctionService) refundLosingBids(ctx context.Context, auction *models.StockAuction) error {
+	filter := bson.M{"auction_id": auction.ID, "status": utils.BidActive}
+	if auction.Status == utils.AuctionAwarded {
+		filter["_id"] = bson.M{"$ne": auction.HighestBidID}
+	}
+
+	cursor, err := sa.Client.Collection(utils.StockBidsCollection).Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var losing []models.StockBid
+	if err := cursor.All(ctx, &losing); err != nil {
+		return err
+	}
+
+	for _, bid := range losing {
+		sa.refundBid(ctx, bid.ID, bid.HoldID)
+	}
+
+	return nil
+}
+
+// finish moves a settling auction to its final status and reports whether this call did.
+func (sa *StockAuctionService) finish(ctx context.Context, auction *models.StockAuction, status string, tradeId string) (bool, error) {
+	now := time.Now()
+
+	set := bson.M{"status": status, "closed_at": now, "updated_at": now}
+	if tradeId != "" {
+		set["trade_id"] = tradeId
+	}
+
+	result, err := sa.Client.Collection(utils.StockAuctionsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": auction.ID, "status": utils.AuctionSettling},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// A repeated settle finds the auction already finished by the earlier call.
+	auction.Status = status
+	if result.ModifiedCount == 0 {
+		return false, nil
+	}
+
+	auction.TradeID = tradeId
+	auction.ClosedAt = now
+
+	return true, nil
+}
+
+// refundBid gives an outbid bidder their held funds back.
+func (sa *StockAuctionService) refundBid(ctx context.Context, bidId primitive.ObjectID, holdId primitive.ObjectID) {
+	if err := sa.walletService.ReleaseHold(ctx, holdId); err != nil {
+		fmt.Printf("Error refunding bid %s: %v\n", bidId.Hex(), err)
+		return
+	}
+
+	_, err := sa.Client.Collection(utils.StockBidsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": bidId, "status": utils.BidActive},
+		bson.M{"$set": bson.M{"status": utils.BidOutbid, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		fmt.Printf("Error marking bid %s outbid: %v\n", bidId.Hex(), err)
+	}
+}
+
+func (sa *StockAuctionService) releaseStock(ctx context.Context, auction *models.StockAuction) {
+	if err := sa.warehouseService.ReleaseReservation(ctx, auction.ReservationID); err != nil {
+		fmt.Printf("Error returning stock of auction %s: %v\n", auction.ID.Hex(), err)
+	}
+}
+
+func (sa *StockAuctionService) getAuction(ctx context.Context, auctionId primitive.ObjectID) (*models.StockAuction, error) {
+	var auction models.StockAuction
+
+	err := sa.Client.Collection(utils.StockAuctionsCollection).FindOne(ctx, bson.M{"_id": auctionId}).Decode(&auction)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Auction not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &auction, nil
+}
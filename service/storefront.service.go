@@ -0,0 +1,587 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultStorePageSize = 20
+	storeRecoveryAge     = time.Minute // Longer than a purchase takes to settle
+)
+
+type StorefrontService struct {
+	Client *mongo.Database
+
+	config           utils.StorefrontConfig
+	cropService      *CropService
+	walletService    *WalletService
+	warehouseService *WarehouseService
+	marketService    *MarketService
+	eventService     *EventService
+}
+
+func NewStorefrontService(client *mongo.Database) *StorefrontService {
+	return &StorefrontService{
+		Client:           client,
+		config:           utils.StorefrontConfigFromEnv(),
+		cropService:      NewCropService(client),
+		walletService:    NewWalletService(client),
+		warehouseService: NewWarehouseService(client),
+		marketService:    NewMarketService(client),
+		eventService:     NewEventService(client),
+	}
+}
+
+// CreateListing reserves the seller's stock, charges the listing fee and lists the stock at a fixed
+// price per unit. The fee goes to the system wallet.
+func (ss *StorefrontService) CreateListing(ctx context.Context, sellerId primitive.ObjectID, body types.ListInStore) (*models.StoreListing, error) {
+	cropId, err := primitive.ObjectIDFromHex(body.CropID)
+	if err != nil {
+		return nil, NewServiceError(http.StatusBadRequest, "invalid crop id")
+	}
+
+	crop, err := ss.cropService.GetCropById(cropId)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusNotFound, "Crop not found")
+		}
+		return nil, err
+	}
+
+	days := body.DurationDays
+	if days == 0 || days > ss.config.ListingDays {
+		days = ss.config.ListingDays
+	}
+
+	price := roundCoins(body.PricePerUnit)
+	if price <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "price_per_unit must be at least 0.01")
+	}
+
+	now := time.Now()
+
+	listing := models.StoreListing{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		SellerID:     sellerId,
+		CropID:       cropId,
+		Quantity:     body.Quantity,
+		Remaining:    body.Quantity,
+		PricePerUnit: price,
+		ListingFee:   ss.config.ListingFee,
+		Status:       utils.StoreActive,
+		ExpiresAt:    now.AddDate(0, 0, days),
+	}
+
+	var reservation *models.StockReservation
+	if body.Grade != "" {
+		reservation, err = ss.warehouseService.ReserveGradedStock(ctx, sellerId, cropId, body.Grade, body.Quantity, "STORE_LISTING", listing.ID.Hex())
+	} else {
+		reservation, err = ss.warehouseService.ReserveStock(ctx, sellerId, cropId, body.Quantity, "STORE_LISTING", listing.ID.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	listing.ReservationID = reservation.ID
+	listing.QualityFactor = averageQuality(reservation.Lots)
+	listing.Grade = body.Grade
+	if listing.Grade == "" {
+		listing.Grade = utils.QualityGrade(listing.QualityFactor, crop.GradeThresholds)
+	}
+
+	description := fmt.Sprintf("Storefront listing of %d units", listing.Quantity)
+
+	// The fee is paid before the listing goes up, so a crash never leaves a listing nobody paid for.
+	if listing.ListingFee > 0 {
+		if err := ss.walletService.DebitOnce(ctx, sellerId, listing.ListingFee, "STORE_LISTING_FEE", description, listing.ID.Hex()); err != nil {
+			ss.releaseStock(ctx, &listing)
+			return nil, err
+		}
+	}
+
+	if _, err := ss.Client.Collection(utils.StoreListingsCollection).InsertOne(ctx, listing); err != nil {
+		if listing.ListingFee > 0 {
+			if refundErr := ss.walletService.CreditOnce(ctx, sellerId, listing.ListingFee, "STORE_LISTING_REFUND", description, listing.ID.Hex()); refundErr != nil {
+				fmt.Printf("Error refunding listing fee of %s: %v\n", listing.ID.Hex(), refundErr)
+			}
+		}
+		ss.releaseStock(ctx, &listing)
+		return nil, err
+	}
+
+	if listing.ListingFee > 0 {
+		if err := ss.walletService.CreditSystemOnce(ctx, listing.ListingFee, "STORE_LISTING_FEE", description, listing.ID.Hex()); err != nil {
+			fmt.Printf("Error collecting listing fee of %s: %v\n", listing.ID.Hex(), err)
+		}
+	}
+
+	return &listing, nil
+}
+
+// GetListings returns a page of active listings matching the query.
+func (ss *StorefrontService) GetListings(ctx context.Context, query types.StoreQuery) ([]models.StoreListing, int64, error) {
+	filter := bson.M{"status": utils.StoreActive, "expires_at": bson.M{"$gt": time.Now()}}
+
+	if query.CropID != "" {
+		cropId, err := primitive.ObjectIDFromHex(query.CropID)
+		if err != nil {
+			return nil, 0, NewServiceError(http.StatusBadRequest, "invalid crop id")
+		}
+		filter["crop_id"] = cropId
+	}
+
+	if query.SellerID != "" {
+		sellerId, err := primitive.ObjectIDFromHex(query.SellerID)
+		if err != nil {
+			return nil, 0, NewServiceError(http.StatusBadRequest, "invalid seller id")
+		}
+		filter["seller_id"] = sellerId
+	}
+
+	if query.Grade != "" {
+		filter["grade"] = query.Grade
+	}
+
+	price := bson.M{}
+	if query.MinPrice > 0 {
+		price["$gte"] = query.MinPrice
+	}
+	if query.MaxPrice > 0 {
+		price["$lte"] = query.MaxPrice
+	}
+	if len(price) > 0 {
+		filter["price_per_unit"] = price
+	}
+
+	if query.MinQuality > 0 {
+		filter["quality_factor"] = bson.M{"$gte": query.MinQuality}
+	}
+
+	sort := bson.D{{Key: "created_at", Value: -1}}
+	switch query.Sort {
+	case "price":
+		sort = bson.D{{Key: "price_per_unit", Value: 1}, {Key: "created_at", Value: 1}}
+	case "-price":
+		sort = bson.D{{Key: "price_per_unit", Value: -1}, {Key: "created_at", Value: 1}}
+	case "quality":
+		sort = bson.D{{Key: "quality_factor", Value: 1}, {Key: "created_at", Value: 1}}
+	case "-quality":
+		sort = bson.D{{Key: "quality_factor", Value: -1}, {Key: "created_at", Value: 1}}
+	}
+
+	page, limit := max(query.Page, 1), query.Limit
+	if limit == 0 {
+		limit = defaultStorePageSize
+	}
+
+	collection := ss.Client.Collection(utils.StoreListingsCollection)
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := collection.Find(
+		ctx,
+		filter,
+		options.Find().SetSort(sort).SetSkip(int64((page-1)*limit)).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	listings := []models.StoreListing{}
+	err = cursor.All(ctx, &listings)
+
+	return listings, total, err
+}
+
+// CancelListing takes the seller's active listing down and puts the unsold stock back in their
+// warehouse. The listing fee is not refunded.
+func (ss *StorefrontService) CancelListing(ctx context.Context, sellerId primitive.ObjectID, listingId primitive.ObjectID) (*models.StoreListing, error) {
+	var listing models.StoreListing
+
+	err := ss.Client.Collection(utils.StoreListingsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": listingId, "seller_id": sellerId, "status": utils.StoreActive},
+		bson.M{"$set": bson.M{"status": utils.StoreCancelled, "updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&listing)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Active listing not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ss.releaseStock(ctx, &listing)
+
+	return &listing, nil
+}
+
+// Buy buys quantity from an active listing at once. The buyer pays the full price, the
+// marketplace keeps its commission and the seller gets the rest. The units are claimed and the
+// trade recorded as SETTLING before any stock or money moves, so a purchase that is interrupted
+// after that is finished or given up by the store worker.
+func (ss *StorefrontService) Buy(ctx context.Context, buyerId primitive.ObjectID, listingId primitive.ObjectID, quantity int) (*models.Trade, error) {
+	listing, err := ss.getListing(ctx, listingId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	switch {
+	case listing.Status != utils.StoreActive || !listing.ExpiresAt.After(now):
+		return nil, NewServiceError(http.StatusConflict, "Listing is no longer for sale")
+	case listing.SellerID == buyerId:
+		return nil, NewServiceError(http.StatusBadRequest, "You can't buy your own listing")
+	case quantity > listing.Remaining:
+		return nil, NewServiceError(http.StatusBadRequest, "Only %d units are left", listing.Remaining)
+	}
+
+	total := roundCoins(listing.PricePerUnit * float64(quantity))
+	commission := roundCoins(total * ss.config.CommissionRate)
+
+	remaining := listing.Remaining - quantity
+	status := utils.StoreActive
+	if remaining == 0 {
+		status = utils.StoreSoldOut
+	}
+
+	collection := ss.Client.Collection(utils.StoreListingsCollection)
+
+	// Claiming the units first keeps two buyers from paying for the same stock.
+	result, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": listing.ID, "status": utils.StoreActive, "remaining": listing.Remaining, "expires_at": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{"remaining": remaining, "status": status, "updated_at": now}},
+	)
+	if err == nil && result.ModifiedCount == 0 {
+		err = NewServiceError(http.StatusConflict, "Listing changed, try again")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tradeId := primitive.NewObjectID()
+
+	trade := models.Trade{
+		BaseModel: models.BaseModel{
+			ID:        tradeId,
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		TradeID:      tradeId.Hex(),
+		SellerID:     listing.SellerID.Hex(),
+		BuyerID:      buyerId.Hex(),
+		CropID:       listing.CropID.Hex(),
+		Grade:        listing.Grade,
+		ListingID:    listing.ID.Hex(),
+		Quantity:     quantity,
+		PricePerUnit: listing.PricePerUnit,
+		TotalAmount:  total,
+		Commission:   commission,
+		Status:       utils.TradeSettling,
+		ProposedAt:   listing.CreatedAt,
+		AcceptedAt:   now,
+	}
+
+	if _, err := ss.Client.Collection(utils.TradesCollection).InsertOne(ctx, trade); err != nil {
+		ss.unclaim(ctx, listing.ID, quantity)
+		return nil, err
+	}
+
+	if err := ss.settleTrade(ctx, &trade, listing); err != nil {
+		return nil, err
+	}
+
+	return &trade, nil
+}
+
+// settleTrade pays for a storefront trade and moves its stock: the buyer's warehouse space and
+// payment are reserved, the seller's lots go into that space, and the payment goes to the seller
+// and the marketplace. The space and the hold are found again by the trade id, the lots are taken
+// off the listing and stored under keys of the trade, and the seller and the commission are
+// credited once per trade, so the store worker can call it again after a crash. If the buyer can't
+// pay or store the stock, or the listing's stock went back to the seller, the trade is given up
+// instead. Only the call that completes the trade moves the market and tells the seller.
+func (ss *StorefrontService) settleTrade(ctx context.Context, trade *models.Trade, listing *models.StoreListing) error {
+	buyerId, err := primitive.ObjectIDFromHex(trade.BuyerID)
+	if err != nil {
+		return err
+	}
+
+	description := fmt.Sprintf("Storefront purchase of %d units", trade.Quantity)
+
+	if err := ss.warehouseService.ReserveCapacity(ctx, buyerId, trade.Quantity, trade.TradeID); err != nil {
+		return ss.abortTrade(ctx, trade, err)
+	}
+
+	hold, err := ss.walletService.GetHoldByReference(ctx, trade.TradeID)
+	if err == mongo.ErrNoDocuments {
+		hold, err = ss.walletService.Hold(ctx, buyerId, trade.TotalAmount, "STORE_PURCHASE", trade.TradeID)
+		if err != nil {
+			return ss.abortTrade(ctx, trade, err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	switch hold.Status {
+	case utils.EscrowReleased:
+		return ss.abortTrade(ctx, trade, NewServiceError(http.StatusBadRequest, "Not enough balance"))
+	case utils.EscrowPending:
+		// The wallet worker decides whether a hold a crash left pending went through.
+		return NewServiceError(http.StatusConflict, "payment is pending, try again")
+	}
+
+	lots, err := ss.warehouseService.ConsumeReservationPart(ctx, listing.ReservationID, trade.Quantity, trade.TradeID)
+	if serviceErr, ok := err.(*ServiceError); ok && serviceErr.Status == http.StatusConflict {
+		// The listing was cancelled or expired meanwhile and its stock already went back.
+		return ss.abortTrade(ctx, trade, NewServiceError(http.StatusConflict, "Listing is no longer for sale"))
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ss.walletService.CaptureHold(ctx, hold.ID, "STORE_PURCHASE", description); err != nil {
+		return err
+	}
+
+	for i, lot := range lots {
+		lot.ID = primitive.NewObjectID()
+		lot.UpdatedAt = time.Now()
+		lot.UserID = buyerId
+		lot.CurrentPrice = trade.PricePerUnit
+		lot.Source = "STORE"
+
+		if err := ss.warehouseService.StoreItemOnce(ctx, &lot, fmt.Sprintf("STORE:%s:%d", trade.TradeID, i), trade.TradeID); err != nil {
+			return err
+		}
+	}
+
+	if err := ss.warehouseService.ReleaseCapacity(ctx, buyerId, trade.TradeID); err != nil {
+		return err
+	}
+
+	if proceeds := trade.TotalAmount - trade.Commission; proceeds > 0 {
+		if err := ss.walletService.CreditOnce(ctx, listing.SellerID, proceeds, "STORE_SALE", description, trade.TradeID); err != nil {
+			return err
+		}
+	}
+
+	if trade.Commission > 0 {
+		if err := ss.walletService.CreditSystemOnce(ctx, trade.Commission, "MARKET_COMMISSION", description, trade.TradeID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+
+	result, err := ss.Client.Collection(utils.TradesCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": trade.ID, "status": utils.TradeSettling},
+		bson.M{"$set": bson.M{"status": utils.TradeCompleted, "completed_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	trade.Status = utils.TradeCompleted
+	trade.CompletedAt = now
+
+	if result.ModifiedCount == 0 {
+		return nil
+	}
+
+	ss.marketService.shiftMarket(ctx, listing.CropID, listing.Grade, 0, trade.Quantity, "TRADE")
+
+	ss.eventService.PublishQuietly(ctx, listing.SellerID, utils.EventStoreSale, types.StoreSalePayload{
+		ListingID:   listing.ID.Hex(),
+		CropID:      listing.CropID.Hex(),
+		BuyerID:     trade.BuyerID,
+		Quantity:    trade.Quantity,
+		TotalAmount: trade.TotalAmount,
+		Commission:  trade.Commission,
+	})
+
+	return nil
+}
+
+// abortTrade gives up a storefront trade whose stock has not moved: the buyer's payment and space
+// go back, and so do the listing's units. It returns cause, or the error that kept the trade from
+// being given up.
+func (ss *StorefrontService) abortTrade(ctx context.Context, trade *models.Trade, cause error) error {
+	buyerId, err := primitive.ObjectIDFromHex(trade.BuyerID)
+	if err != nil {
+		return err
+	}
+
+	hold, err := ss.walletService.GetHoldByReference(ctx, trade.TradeID)
+	if err == nil {
+		err = ss.walletService.ReleaseHold(ctx, hold.ID)
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	if err := ss.warehouseService.ReleaseCapacity(ctx, buyerId, trade.TradeID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	result, err := ss.Client.Collection(utils.TradesCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": trade.ID, "status": utils.TradeSettling},
+		bson.M{"$set": bson.M{"status": utils.TradeFailed, "reason": cause.Error(), "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	trade.Status = utils.TradeFailed
+	trade.Reason = cause.Error()
+
+	if result.ModifiedCount == 1 {
+		listingId, err := primitive.ObjectIDFromHex(trade.ListingID)
+		if err != nil {
+			return err
+		}
+
+		ss.unclaim(ctx, listingId, trade.Quantity)
+	}
+
+	return cause
+}
+
+// RecoverTrades finishes storefront purchases left settling by a crash, or gives them up if they
+// can no longer be paid for. Only trades older than a purchase takes are picked up.
+func (ss *StorefrontService) RecoverTrades(ctx context.Context) error {
+	cursor, err := ss.Client.Collection(utils.TradesCollection).Find(ctx, bson.M{
+		"status":      utils.TradeSettling,
+		"listing_id":  bson.M{"$exists": true},
+		"accepted_at": bson.M{"$lt": time.Now().Add(-storeRecoveryAge)},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var trades []models.Trade
+	if err := cursor.All(ctx, &trades); err != nil {
+		return err
+	}
+
+	for i := range trades {
+		trade := &trades[i]
+
+		listingId, err := primitive.ObjectIDFromHex(trade.ListingID)
+		if err != nil {
+			fmt.Printf("Error reading listing of trade %s: %v\n", trade.TradeID, err)
+			continue
+		}
+
+		listing, err := ss.getListing(ctx, listingId)
+		if err != nil {
+			fmt.Printf("Error loading listing of trade %s: %v\n", trade.TradeID, err)
+			continue
+		}
+
+		if err := ss.settleTrade(ctx, trade, listing); err != nil && trade.Status != utils.TradeFailed {
+			fmt.Printf("Error settling storefront trade %s: %v\n", trade.TradeID, err)
+		}
+	}
+
+	return nil
+}
+
+// ExpireListings ends active listings past their expiry and puts their unsold stock back.
+func (ss *StorefrontService) ExpireListings(ctx context.Context) error {
+	collection := ss.Client.Collection(utils.StoreListingsCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{"status": utils.StoreActive, "expires_at": bson.M{"$lte": time.Now()}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var listings []models.StoreListing
+	if err := cursor.All(ctx, &listings); err != nil {
+		return err
+	}
+
+	for i := range listings {
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"_id": listings[i].ID, "status": utils.StoreActive},
+			bson.M{"$set": bson.M{"status": utils.StoreExpired, "updated_at": time.Now()}},
+		)
+		if err != nil {
+			fmt.Printf("Error expiring storefront listing %s: %v\n", listings[i].ID.Hex(), err)
+			continue
+		}
+
+		if result.ModifiedCount == 1 {
+			ss.releaseStock(ctx, &listings[i])
+		}
+	}
+
+	return nil
+}
+
+// unclaim gives back units claimed by a purchase that could not be paid for.
+func (ss *StorefrontService) unclaim(ctx context.Context, listingId primitive.ObjectID, quantity int) {
+	_, err := ss.Client.Collection(utils.StoreListingsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": listingId, "status": bson.M{"$in": []string{utils.StoreActive, utils.StoreSoldOut}}},
+		bson.M{
+			"$inc": bson.M{"remaining": quantity},
+			"$set": bson.M{"status": utils.StoreActive, "updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error returning units to storefront listing %s: %v\n", listingId.Hex(), err)
+	}
+}
+
+func (ss *StorefrontService) releaseStock(ctx context.Context, listing *models.StoreListing) {
+	if err := ss.warehouseService.ReleaseReservation(ctx, listing.ReservationID); err != nil {
+		fmt.Printf("Error returning stock of storefront listing %s: %v\n", listing.ID.Hex(), err)
+	}
+}
+
+func (ss *StorefrontService) getListing(ctx context.Context, listingId primitive.ObjectID) (*models.StoreListing, error) {
+	var listing models.StoreListing
+
+	err := ss.Client.Collection(utils.StoreListingsCollection).FindOne(ctx, bson.M{"_id": listingId}).Decode(&listing)
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "Listing not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &listing, nil
+}
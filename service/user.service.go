@@ -2,24 +2,131 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/hrutik1235/farming-server/kafkaconn"
 	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
 	"github.com/hrutik1235/farming-server/utils"
+	"github.com/segmentio/kafka-go"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserService struct {
 	Client *mongo.Database
+
+	walletService *WalletService
+	eventService  *EventService
 }
 
 func NewUserService(client *mongo.Database) *UserService {
 	return &UserService{
-		Client: client,
+		Client:        client,
+		walletService: NewWalletService(client),
+		eventService:  NewEventService(client),
+	}
+}
+
+// RegisterUser saves a new user, records their onboarding and publishes UserRegistered for the
+// onboarding worker. When a user with the same username and email already exists it is returned
+// with existed set instead, and their onboarding is recorded and published again if it never
+// completed. A failed publish is not an error: the worker picks up onboarding records nobody
+// consumed.
+func (u *UserService) RegisterUser(ctx context.Context, body types.RegisterUser) (user *models.User, existed bool, err error) {
+	prevuser, _ := u.FindUserByCriteria(bson.M{
+		"username": body.Username,
+		"email":    body.Email,
+	})
+
+	if prevuser != nil {
+		if err := u.resumeOnboarding(ctx, prevuser); err != nil {
+			return nil, false, err
+		}
+
+		return prevuser, true, nil
+	}
+
+	user = &models.User{
+		Username:      body.Username,
+		DisplayName:   body.Name,
+		Email:         body.Email,
+		ServerAddress: utils.ServerAddress(),
+	}
+
+	savedUser, err := user.Save(u.Client.Collection(utils.UsersCollection))
+	if err != nil {
+		return nil, false, err
+	}
+
+	user.ID = savedUser.InsertedID.(primitive.ObjectID)
+
+	if err := u.startOnboarding(ctx, user); err != nil {
+		return nil, false, err
+	}
+
+	return user, false, nil
+}
+
+// resumeOnboarding starts the onboarding of an existing user again unless it completed, for a
+// registration retried after its onboarding record or event was lost.
+func (u *UserService) resumeOnboarding(ctx context.Context, user *models.User) error {
+	var record models.Onboarding
+
+	err := u.Client.Collection(utils.OnboardingCollection).FindOne(ctx, bson.M{"user_id": user.ID}).Decode(&record)
+	if err == nil && record.Status == OnboardingCompleted {
+		return nil
+	}
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	return u.startOnboarding(ctx, user)
+}
+
+// startOnboarding records the user's onboarding with its UserRegistered event, then publishes the
+// event. Wallet, land, seeds and the welcome notification are handled by the onboarding worker.
+func (u *UserService) startOnboarding(ctx context.Context, user *models.User) error {
+	event, err := json.Marshal(types.UserRegisteredEvent{
+		UserID:       user.ID.Hex(),
+		Username:     user.Username,
+		Email:        user.Email,
+		Name:         user.DisplayName,
+		RegisteredAt: time.Now(),
+	})
+	if err != nil {
+		return err
 	}
+
+	record, err := upsertOnboardingRecord(ctx, u.Client, user.ID, user.Username, event)
+	if err != nil {
+		return err
+	}
+
+	kconfig := kafkaconn.NewKafka(utils.KafkaBrokers())
+
+	defer kconfig.Close()
+
+	if err := kconfig.CreateTopic(utils.RegisterTopic); err != nil {
+		fmt.Printf("Error publishing registration of %s, left to the onboarding worker: %v\n", user.ID.Hex(), err)
+		return nil
+	}
+
+	message := kafka.Message{
+		Key:   []byte(user.ID.Hex()),
+		Value: record.Event,
+	}
+
+	if err := kconfig.WriteMessage(ctx, utils.RegisterTopic, message); err != nil {
+		fmt.Printf("Error publishing registration of %s, left to the onboarding worker: %v\n", user.ID.Hex(), err)
+	}
+
+	return nil
 }
 
 func (u *UserService) GetUserLand(userId primitive.ObjectID) (*models.Land, error) {
@@ -52,6 +159,14 @@ func (u *UserService) GetUserLandUnits(userId primitive.ObjectID) ([]models.Land
 	if err != nil {
 		return nil, err
 	}
+
+	// Show the soil as it is now, including fallow recovery.
+	now := time.Now()
+	for i := range landUnits {
+		soil := CurrentSoil(landUnits[i], now)
+		landUnits[i].Soil = &soil
+	}
+
 	return landUnits, nil
 }
 
@@ -80,6 +195,46 @@ func (u *UserService) GetUserById(userId primitive.ObjectID) (*models.User, erro
 	return &user, err
 }
 
+// MarkOnline counts a newly opened realtime session and flags the user online.
+func (u *UserService) MarkOnline(ctx context.Context, userId primitive.ObjectID) error {
+	now := time.Now()
+
+	result, err := u.Client.Collection(utils.UsersCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": userId},
+		bson.M{
+			"$inc": bson.M{"connections": 1},
+			"$set": bson.M{"is_online": true, "last_login": now, "updated_at": now},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return NewServiceError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	return nil
+}
+
+// MarkOffline counts a closed realtime session and flags the user offline once none are left.
+func (u *UserService) MarkOffline(ctx context.Context, userId primitive.ObjectID) error {
+	collection := u.Client.Collection(utils.UsersCollection)
+
+	if _, err := collection.UpdateOne(ctx, bson.M{"_id": userId}, bson.M{"$inc": bson.M{"connections": -1}}); err != nil {
+		return err
+	}
+
+	_, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": userId, "connections": bson.M{"$lte": 0}},
+		bson.M{"$set": bson.M{"is_online": false, "connections": 0, "updated_at": time.Now()}},
+	)
+
+	return err
+}
+
 func (u *UserService) GetUserWallet(userId primitive.ObjectID) (*models.Wallet, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -101,91 +256,102 @@ func (u *UserService) DeductPlantingCost(userId primitive.ObjectID, cost float64
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	collection := u.Client.Collection(utils.WalletsCollection)
+	if _, err := u.walletService.Debit(ctx, userId, cost, "PLANTING_COST", "Planting cost", ""); err != nil {
+		fmt.Printf("Error updating wallet: %v\n", err)
+		return err
+	}
 
-	// First check if user has sufficient balance
-	var wallet models.Wallet
+	fmt.Printf("Successfully deducted %.2f from user %s\n", cost, userId.Hex())
+	return nil
+}
 
-	err := collection.FindOne(ctx, bson.M{"user_id": userId}).Decode(&wallet)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return fmt.Errorf("wallet not found for user")
-		}
-		fmt.Printf("Error finding wallet: %v\n", err)
+// RefundPlantingCost pays back the cost of a planting that could not be created.
+func (u *UserService) RefundPlantingCost(userId primitive.ObjectID, cost float64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := u.walletService.Credit(ctx, userId, cost, "PLANTING_REFUND", "Planting cost refunded", ""); err != nil {
+		fmt.Printf("Error refunding planting cost: %v\n", err)
 		return err
 	}
 
-	fmt.Println(" ===== WALLET: ", wallet)
+	return nil
+}
 
-	if wallet.Balance < cost {
-		return fmt.Errorf("insufficient balance: have %.2f, need %.2f", wallet.Balance, cost)
-	}
+// EnsureWallet creates the user's wallet with the starter balance unless one already exists.
+func (u *UserService) EnsureWallet(ctx context.Context, userId primitive.ObjectID) error {
+	now := time.Now()
 
-	result, err := collection.UpdateOne(
+	result, err := u.Client.Collection(utils.WalletsCollection).UpdateOne(
 		ctx,
-		bson.M{
-			"user_id": userId,
-			"balance": bson.M{"$gte": cost},
-		},
-		bson.M{
-			"$inc": bson.M{
-				"balance":     -cost,
-				"total_spent": cost,
-			},
-			"$set": bson.M{
-				"last_updated": time.Now(),
+		bson.M{"user_id": userId},
+		bson.M{"$setOnInsert": models.Wallet{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
 			},
-		},
+			UserID:      userId,
+			Balance:     utils.StarterWalletBalance,
+			LastUpdated: now,
+		}},
+		options.Update().SetUpsert(true),
 	)
 
-	if err != nil {
-		fmt.Printf("Error updating wallet: %v\n", err)
-		return err
+	// Losing a concurrent upsert to the unique user_id index means the wallet is there.
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
 	}
 
-	if result.MatchedCount == 0 {
-		return fmt.Errorf("unable to deduct cost: insufficient balance or wallet not found")
+	if err != nil {
+		return err
 	}
 
-	if result.ModifiedCount == 0 {
-		return fmt.Errorf("wallet was matched but not modified")
+	if result.UpsertedCount == 1 {
+		u.eventService.PublishQuietly(ctx, userId, utils.EventWalletChanged, types.WalletChangedPayload{
+			Balance: utils.StarterWalletBalance,
+			Delta:   utils.StarterWalletBalance,
+			Reason:  "STARTER_BALANCE",
+		})
 	}
 
-	fmt.Printf("Successfully deducted %.2f from user %s\n", cost, userId.Hex())
 	return nil
 }
 
-func (u *UserService) AllocateLandToUser(userId primitive.ObjectID, username string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	userLand, err := u.GetUserLand(userId)
-
-	_, walletErr := u.Client.Collection(utils.WalletsCollection).InsertOne(ctx, models.Wallet{UserID: userId, Balance: 100})
-
-	if walletErr != nil {
-		return walletErr
-	}
-
-	if err == nil && userLand != nil {
-		return nil
-	}
-
-	landUnits := make([]interface{}, 100)
-
-	for i := 0; i < 100; i++ {
-		landUnit := models.LandUnit{
-			Land:        fmt.Sprintf("%s_land", username),
-			OwnerID:     userId,
-			SizeUnits:   1,
-			IsLeased:    false,
-			Position:    i + 1,
-			IsAvailable: true,
+// EnsureStarterLand upserts each starter land position the user does not own yet, so a run
+// interrupted halfway through or a redelivered register event never grants the same position twice.
+func (u *UserService) EnsureStarterLand(ctx context.Context, userId primitive.ObjectID, username string) error {
+	collection := u.Client.Collection(utils.LandUnitsCollection)
+
+	for i := 1; i <= utils.InitialLandUnitSize; i++ {
+		now := time.Now()
+
+		_, err := collection.UpdateOne(
+			ctx,
+			bson.M{"owner_id": userId, "position": i},
+			bson.M{"$setOnInsert": models.LandUnit{
+				BaseModel: models.BaseModel{
+					ID:        primitive.NewObjectID(),
+					CreatedAt: now,
+					UpdatedAt: now,
+					IsActive:  true,
+				},
+				Land:        fmt.Sprintf("%s_land", username),
+				OwnerID:     userId,
+				SizeUnits:   1,
+				IsLeased:    false,
+				Position:    i,
+				IsAvailable: true,
+			}},
+			options.Update().SetUpsert(true),
+		)
+
+		// Another server upserting the same position at once loses on the unique index.
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
 		}
-		landUnits[i] = landUnit
 	}
 
-	_, lUnitErr := u.Client.Collection(utils.LandUnitsCollection).InsertMany(ctx, landUnits)
-
-	return lUnitErr
+	return nil
 }
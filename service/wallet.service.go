@@ -0,0 +1,660 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// Keyed wallet changes remembered per wallet
+	walletKeyHistory = 1000
+
+	// Age at which RecoverHolds takes over a hold left halfway
+	holdRecoveryAge = 2 * time.Minute
+)
+
+// errWalletGuard reports that a keyed wallet change was not applied because its guard did not match.
+var errWalletGuard = errors.New("wallet guard did not match")
+
+type WalletService struct {
+	Client       *mongo.Database
+	eventService *EventService
+}
+
+func NewWalletService(client *mongo.Database) *WalletService {
+	return &WalletService{
+		Client:       client,
+		eventService: NewEventService(client),
+	}
+}
+
+// Debit takes amount from the user's wallet if the balance covers it, records the transaction
+// and publishes the new balance.
+func (ws *WalletService) Debit(ctx context.Context, userId primitive.ObjectID, amount float64, category string, description string, referenceId string) (*models.Wallet, error) {
+	if amount <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "invalid amount: must be positive")
+	}
+
+	var wallet models.Wallet
+
+	err := ws.Client.Collection(utils.WalletsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userId, "balance": bson.M{"$gte": amount}},
+		bson.M{
+			"$inc": bson.M{"balance": -amount, "total_spent": amount},
+			"$set": bson.M{"last_updated": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&wallet)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusBadRequest, "Not enough balance")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ws.recordTransaction(ctx, &wallet, "EXPENSE", amount, -amount, category, description, referenceId)
+
+	return &wallet, nil
+}
+
+// Credit adds amount to the user's wallet, records the transaction and publishes the new balance.
+func (ws *WalletService) Credit(ctx context.Context, userId primitive.ObjectID, amount float64, category string, description string, referenceId string) (*models.Wallet, error) {
+	if amount <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "invalid amount: must be positive")
+	}
+
+	var wallet models.Wallet
+
+	err := ws.Client.Collection(utils.WalletsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userId},
+		bson.M{
+			"$inc": bson.M{"balance": amount, "total_earnings": amount},
+			"$set": bson.M{"last_updated": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&wallet)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, NewServiceError(http.StatusNotFound, "wallet not found for user")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ws.recordTransaction(ctx, &wallet, "INCOME", amount, amount, category, description, referenceId)
+
+	return &wallet, nil
+}
+
+// CreditSystem pays amount into the marketplace's system wallet, creating it on first use.
+func (ws *WalletService) CreditSystem(ctx context.Context, amount float64, category string, description string, referenceId string) error {
+	if amount <= 0 {
+		return NewServiceError(http.StatusBadRequest, "invalid amount: must be positive")
+	}
+
+	now := time.Now()
+
+	var wallet models.Wallet
+
+	err := ws.Client.Collection(utils.WalletsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": utils.SystemUserID},
+		bson.M{
+			"$inc": bson.M{"balance": amount, "total_earnings": amount},
+			"$set": bson.M{"last_updated": now},
+			"$setOnInsert": bson.M{
+				"_id":          primitive.NewObjectID(),
+				"created_at":   now,
+				"updated_at":   now,
+				"is_active":    true,
+				"held_balance": 0,
+				"total_spent":  0,
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&wallet)
+
+	if err != nil {
+		return err
+	}
+
+	ws.recordTransaction(ctx, &wallet, "INCOME", amount, amount, category, description, referenceId)
+
+	return nil
+}
+
+// CreditOnce is Credit keyed by category and referenceId: crediting the same reference again is a
+// no-op, so settlements can retry a payment until it succeeds without paying twice.
+func (ws *WalletService) CreditOnce(ctx context.Context, userId primitive.ObjectID, amount float64, category string, description string, referenceId string) error {
+	if amount <= 0 {
+		return NewServiceError(http.StatusBadRequest, "invalid amount: must be positive")
+	}
+
+	wallet, applied, err := ws.applyOnce(ctx, userId, category+":"+referenceId, bson.M{}, bson.M{"balance": amount, "total_earnings": amount})
+	if err != nil {
+		return err
+	}
+
+	if applied {
+		ws.recordTransaction(ctx, wallet, "INCOME", amount, amount, category, description, referenceId)
+	}
+
+	return nil
+}
+
+// DebitOnce is Debit keyed by category and referenceId: debiting the same reference again is a
+// no-op, so a settlement can retry a payment without charging twice.
+func (ws *WalletService) DebitOnce(ctx context.Context, userId primitive.ObjectID, amount float64, category string, description string, referenceId string) error {
+	if amount <= 0 {
+		return NewServiceError(http.StatusBadRequest, "invalid amount: must be positive")
+	}
+
+	wallet, applied, err := ws.applyOnce(ctx, userId, category+":"+referenceId, bson.M{"balance": bson.M{"$gte": amount}}, bson.M{"balance": -amount, "total_spent": amount})
+	if err == errWalletGuard {
+		return NewServiceError(http.StatusBadRequest, "Not enough balance")
+	}
+	if err != nil {
+		return err
+	}
+
+	if applied {
+		ws.recordTransaction(ctx, wallet, "EXPENSE", amount, -amount, category, description, referenceId)
+	}
+
+	return nil
+}
+
+// CreditSystemOnce is CreditSystem keyed by category and referenceId, like CreditOnce.
+func (ws *WalletService) CreditSystemOnce(ctx context.Context, amount float64, category string, description string, referenceId string) error {
+	now := time.Now()
+
+	_, err := ws.Client.Collection(utils.WalletsCollection).UpdateOne(
+		ctx,
+		bson.M{"user_id": utils.SystemUserID},
+		bson.M{"$setOnInsert": bson.M{
+			"_id":            primitive.NewObjectID(),
+			"created_at":     now,
+			"updated_at":     now,
+			"is_active":      true,
+			"balance":        0,
+			"held_balance":   0,
+			"total_earnings": 0,
+			"total_spent":    0,
+			"last_updated":   now,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	return ws.CreditOnce(ctx, utils.SystemUserID, amount, category, description, referenceId)
+}
+
+// Hold moves amount from the spendable balance into held_balance and returns the hold, which is
+// later either released back or captured as spent. The hold is recorded as PENDING before the
+// money moves, so a crash in between leaves a record RecoverHolds can put right.
+func (ws *WalletService) Hold(ctx context.Context, userId primitive.ObjectID, amount float64, reason string, referenceId string) (*models.WalletHold, error) {
+	if amount <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "invalid amount: must be positive")
+	}
+
+	now := time.Now()
+
+	hold := models.WalletHold{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		UserID:      userId,
+		Amount:      amount,
+		Status:      utils.EscrowPending,
+		Reason:      reason,
+		ReferenceID: referenceId,
+	}
+
+	holds := ws.Client.Collection(utils.WalletHoldsCollection)
+
+	if _, err := holds.InsertOne(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	wallet, _, err := ws.applyOnce(
+		ctx,
+		userId,
+		holdKey(hold.ID, utils.EscrowHeld),
+		bson.M{"balance": bson.M{"$gte": amount}},
+		bson.M{"balance": -amount, "held_balance": amount},
+	)
+
+	if err == errWalletGuard {
+		// Nothing moved, so the hold is over as it stands.
+		holds.UpdateOne(ctx, bson.M{"_id": hold.ID}, bson.M{"$set": bson.M{"status": utils.EscrowReleased, "settled": true, "updated_at": time.Now()}})
+		return nil, NewServiceError(http.StatusBadRequest, "Not enough balance")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := holds.UpdateOne(
+		ctx,
+		bson.M{"_id": hold.ID, "status": utils.EscrowPending},
+		bson.M{"$set": bson.M{"status": utils.EscrowHeld, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.MatchedCount == 0 {
+		// RecoverHolds gave the hold up in between, maybe before the money moved; make sure it
+		// comes back.
+		hold.Status, hold.Abandoned = utils.EscrowReleased, true
+
+		if err := ws.settleHold(ctx, &hold, reason, ""); err != nil {
+			fmt.Printf("Error releasing abandoned hold %s: %v\n", hold.ID.Hex(), err)
+		}
+
+		return nil, NewServiceError(http.StatusConflict, "hold timed out, try again")
+	}
+
+	hold.Status = utils.EscrowHeld
+
+	ws.eventService.PublishQuietly(ctx, userId, utils.EventWalletChanged, types.WalletChangedPayload{
+		Balance: wallet.Balance,
+		Delta:   -amount,
+		Reason:  reason + "_HOLD",
+	})
+
+	return &hold, nil
+}
+
+// ReleaseHold returns a held amount to the spendable balance. Releasing a hold again finishes a
+// release that was interrupted and is otherwise a no-op, so callers can retry safely.
+func (ws *WalletService) ReleaseHold(ctx context.Context, holdId primitive.ObjectID) error {
+	hold, err := ws.claimHold(ctx, holdId, utils.EscrowReleased)
+	if err != nil || hold == nil {
+		return err
+	}
+
+	return ws.settleHold(ctx, hold, hold.Reason, "")
+}
+
+// CaptureHold spends a held amount for good. Capturing a hold again finishes a capture that was
+// interrupted and is otherwise a no-op.
+func (ws *WalletService) CaptureHold(ctx context.Context, holdId primitive.ObjectID, category string, description string) error {
+	hold, err := ws.claimHold(ctx, holdId, utils.EscrowCaptured)
+	if err != nil || hold == nil {
+		return err
+	}
+
+	return ws.settleHold(ctx, hold, category, description)
+}
+
+// CaptureHoldPart spends amount of a held hold for good and leaves the rest held, so one hold can
+// pay for several fills. Each part is keyed, so capturing the same key again is a no-op. It fails
+// if the hold no longer covers amount.
+func (ws *WalletService) CaptureHoldPart(ctx context.Context, holdId primitive.ObjectID, amount float64, category string, description string, key string) error {
+	if amount <= 0 {
+		return NewServiceError(http.StatusBadRequest, "invalid amount: must be positive")
+	}
+
+	part := models.HoldPart{Key: key, Amount: amount}
+
+	var hold models.WalletHold
+
+	// Sums of rounded amounts may drift by a fraction of a cent from the held total.
+	err := ws.Client.Collection(utils.WalletHoldsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"_id":       holdId,
+			"status":    utils.EscrowHeld,
+			"amount":    bson.M{"$gte": amount - 0.001},
+			"parts.key": bson.M{"$ne": key},
+		},
+		bson.M{
+			"$inc":  bson.M{"amount": -amount},
+			"$push": bson.M{"parts": part},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	).Decode(&hold)
+
+	if err == mongo.ErrNoDocuments {
+		existing, err := ws.GetHold(ctx, holdId)
+		if err != nil {
+			return err
+		}
+
+		index := slices.IndexFunc(existing.Parts, func(p models.HoldPart) bool { return p.Key == key })
+		if index < 0 {
+			return NewServiceError(http.StatusConflict, "hold does not cover %.2f", amount)
+		}
+
+		if existing.Parts[index].Applied {
+			return nil
+		}
+
+		hold, part = *existing, existing.Parts[index]
+	} else if err != nil {
+		return err
+	}
+
+	return ws.applyHoldPart(ctx, &hold, part, category, description)
+}
+
+// GetHold returns a wallet hold by id.
+func (ws *WalletService) GetHold(ctx context.Context, holdId primitive.ObjectID) (*models.WalletHold, error) {
+	var hold models.WalletHold
+
+	err := ws.Client.Collection(utils.WalletHoldsCollection).FindOne(ctx, bson.M{"_id": holdId}).Decode(&hold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// GetHoldByReference returns the hold placed for referenceId, if any.
+func (ws *WalletService) GetHoldByReference(ctx context.Context, referenceId string) (*models.WalletHold, error) {
+	var hold models.WalletHold
+
+	err := ws.Client.Collection(utils.WalletHoldsCollection).FindOne(ctx, bson.M{"reference_id": referenceId}).Decode(&hold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// StaleHolds returns the holds placed for reason that have been HELD longer than a caller takes to
+// record them, so the caller can release those a crash left unrecorded.
+func (ws *WalletService) StaleHolds(ctx context.Context, reason string) ([]models.WalletHold, error) {
+	cursor, err := ws.Client.Collection(utils.WalletHoldsCollection).Find(ctx, bson.M{
+		"reason":     reason,
+		"status":     utils.EscrowHeld,
+		"created_at": bson.M{"$lt": time.Now().Add(-holdRecoveryAge)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var holds []models.WalletHold
+	err = cursor.All(ctx, &holds)
+
+	return holds, err
+}
+
+// RecoverHolds finishes holds left halfway by a crash: PENDING holds whose caller never got them
+// are released, and released or captured holds and captured parts whose money did not move yet
+// are moved.
+func (ws *WalletService) RecoverHolds(ctx context.Context) error {
+	holds := ws.Client.Collection(utils.WalletHoldsCollection)
+	stale := time.Now().Add(-holdRecoveryAge)
+
+	cursor, err := holds.Find(ctx, bson.M{
+		"updated_at": bson.M{"$lt": stale},
+		"$or": bson.A{
+			bson.M{"status": utils.EscrowPending},
+			bson.M{"status": bson.M{"$in": bson.A{utils.EscrowReleased, utils.EscrowCaptured}}, "settled": false},
+			bson.M{"status": utils.EscrowHeld, "parts.applied": false},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var stuck []models.WalletHold
+	if err := cursor.All(ctx, &stuck); err != nil {
+		return err
+	}
+
+	for _, hold := range stuck {
+		var err error
+
+		switch hold.Status {
+		case utils.EscrowPending:
+			result, updateErr := holds.UpdateOne(
+				ctx,
+				bson.M{"_id": hold.ID, "status": utils.EscrowPending},
+				bson.M{"$set": bson.M{"status": utils.EscrowReleased, "abandoned": true, "updated_at": time.Now()}},
+			)
+			if updateErr != nil || result.MatchedCount == 0 {
+				err = updateErr
+				break
+			}
+
+			hold.Status, hold.Abandoned = utils.EscrowReleased, true
+			err = ws.settleHold(ctx, &hold, hold.Reason, "")
+		case utils.EscrowHeld:
+			for _, part := range hold.Parts {
+				if part.Applied {
+					continue
+				}
+
+				if err = ws.applyHoldPart(ctx, &hold, part, hold.Reason, "Captured part of hold "+hold.ID.Hex()); err != nil {
+					break
+				}
+			}
+		default:
+			err = ws.settleHold(ctx, &hold, hold.Reason, "Settled hold "+hold.ID.Hex())
+		}
+
+		if err != nil {
+			fmt.Printf("Error recovering wallet hold %s: %v\n", hold.ID.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+// claimHold moves a HELD hold to status and returns it. A hold already in status that has not
+// settled yet is returned too, so an interrupted release or capture is finished; otherwise it
+// returns nil.
+func (ws *WalletService) claimHold(ctx context.Context, holdId primitive.ObjectID, status string) (*models.WalletHold, error) {
+	var hold models.WalletHold
+
+	err := ws.Client.Collection(utils.WalletHoldsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": holdId, "$or": bson.A{
+			bson.M{"status": utils.EscrowHeld},
+			bson.M{"status": status, "settled": false},
+		}},
+		bson.M{"$set": bson.M{"status": status, "settled": false, "updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&hold)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// settleHold moves the money of a released or captured hold out of held_balance, once, and marks
+// the hold settled. Parts captured before are moved first.
+func (ws *WalletService) settleHold(ctx context.Context, hold *models.WalletHold, category string, description string) error {
+	for _, part := range hold.Parts {
+		if part.Applied {
+			continue
+		}
+
+		if err := ws.applyHoldPart(ctx, hold, part, category, description); err != nil {
+			return err
+		}
+	}
+
+	spend := hold.Status == utils.EscrowCaptured
+
+	inc := bson.M{"held_balance": -hold.Amount, "balance": hold.Amount}
+	if spend {
+		inc = bson.M{"held_balance": -hold.Amount, "total_spent": hold.Amount}
+	}
+
+	// An abandoned hold only has money to move if the Hold call got as far as moving it.
+	guard := bson.M{}
+	if hold.Abandoned {
+		guard = bson.M{"applied_keys": holdKey(hold.ID, utils.EscrowHeld)}
+	}
+
+	// A hold captured piece by piece may have nothing left.
+	if hold.Amount > 0 {
+		wallet, applied, err := ws.applyOnce(ctx, hold.UserID, holdKey(hold.ID, hold.Status), guard, inc)
+
+		switch {
+		case err == errWalletGuard:
+		case err != nil:
+			return err
+		case applied && spend:
+			// The balance already dropped when the hold was placed, so only the ledger changes here.
+			ws.recordTransaction(ctx, wallet, "EXPENSE", hold.Amount, 0, category, description, hold.ReferenceID)
+		case applied:
+			ws.eventService.PublishQuietly(ctx, hold.UserID, utils.EventWalletChanged, types.WalletChangedPayload{
+				Balance: wallet.Balance,
+				Delta:   hold.Amount,
+				Reason:  hold.Reason + "_RELEASE",
+			})
+		}
+	}
+
+	_, err := ws.Client.Collection(utils.WalletHoldsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": hold.ID, "status": hold.Status},
+		bson.M{"$set": bson.M{"settled": true, "updated_at": time.Now()}},
+	)
+
+	return err
+}
+
+// applyHoldPart moves a captured part of a hold out of held_balance, once, and marks it applied.
+func (ws *WalletService) applyHoldPart(ctx context.Context, hold *models.WalletHold, part models.HoldPart, category string, description string) error {
+	wallet, applied, err := ws.applyOnce(
+		ctx,
+		hold.UserID,
+		holdKey(hold.ID, "PART:"+part.Key),
+		bson.M{},
+		bson.M{"held_balance": -part.Amount, "total_spent": part.Amount},
+	)
+	if err != nil {
+		return err
+	}
+
+	if applied {
+		ws.recordTransaction(ctx, wallet, "EXPENSE", part.Amount, 0, category, description, hold.ReferenceID)
+	}
+
+	_, err = ws.Client.Collection(utils.WalletHoldsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": hold.ID, "parts.key": part.Key},
+		bson.M{"$set": bson.M{"parts.$.applied": true}},
+	)
+
+	return err
+}
+
+// applyOnce increments the user's wallet by inc if guard matches and no change under key was
+// applied yet, and reports whether this call applied it. It fails with errWalletGuard when guard
+// does not match. Only the latest walletKeyHistory keys are remembered, which is plenty for a
+// retry to find its own.
+func (ws *WalletService) applyOnce(ctx context.Context, userId primitive.ObjectID, key string, guard bson.M, inc bson.M) (*models.Wallet, bool, error) {
+	collection := ws.Client.Collection(utils.WalletsCollection)
+
+	var wallet models.Wallet
+
+	err := collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userId, "$and": bson.A{bson.M{"applied_keys": bson.M{"$ne": key}}, guard}},
+		bson.M{
+			"$inc":  inc,
+			"$set":  bson.M{"last_updated": time.Now()},
+			"$push": bson.M{"applied_keys": bson.M{"$each": bson.A{key}, "$slice": -walletKeyHistory}},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&wallet)
+
+	if err == nil {
+		return &wallet, true, nil
+	}
+
+	if err != mongo.ErrNoDocuments {
+		return nil, false, err
+	}
+
+	err = collection.FindOne(ctx, bson.M{"user_id": userId}).Decode(&wallet)
+	if err == mongo.ErrNoDocuments {
+		return nil, false, NewServiceError(http.StatusNotFound, "wallet not found for user")
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if slices.Contains(wallet.AppliedKeys, key) {
+		return &wallet, false, nil
+	}
+
+	return nil, false, errWalletGuard
+}
+
+// holdKey is the wallet key of a hold's move into status.
+func holdKey(holdId primitive.ObjectID, status string) string {
+	return "HOLD:" + holdId.Hex() + ":" + status
+}
+
+func (ws *WalletService) recordTransaction(ctx context.Context, wallet *models.Wallet, txType string, amount float64, balanceDelta float64, category string, description string, referenceId string) {
+	now := time.Now()
+
+	transaction := models.Transaction{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		UserID:       wallet.UserID.Hex(),
+		Type:         txType,
+		Amount:       amount,
+		Description:  description,
+		Category:     category,
+		ReferenceID:  referenceId,
+		Timestamp:    now,
+		BalanceAfter: wallet.Balance,
+	}
+	transaction.TransactionID = transaction.ID.Hex()
+
+	if _, err := ws.Client.Collection(utils.TransactionsCollection).InsertOne(ctx, transaction); err != nil {
+		// The balance already moved; a missing ledger row must not undo it.
+		fmt.Printf("Error recording transaction for %s: %v\n", wallet.UserID.Hex(), err)
+	}
+
+	if balanceDelta == 0 {
+		return
+	}
+
+	ws.eventService.PublishQuietly(ctx, wallet.UserID, utils.EventWalletChanged, types.WalletChangedPayload{
+		Balance: wallet.Balance,
+		Delta:   balanceDelta,
+		Reason:  category,
+	})
+}
@@ -0,0 +1,709 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultWarehouseCapacity = 1000
+
+	// Keyed deliveries remembered per warehouse
+	warehouseKeyHistory = 1000
+)
+
+type WarehouseService struct {
+	Client       *mongo.Database
+	eventService *EventService
+}
+
+func NewWarehouseService(client *mongo.Database) *WarehouseService {
+	return &WarehouseService{
+		Client:       client,
+		eventService: NewEventService(client),
+	}
+}
+
+// GetUserWarehouse returns the user's warehouse, or an empty one if nothing was harvested yet.
+func (ws *WarehouseService) GetUserWarehouse(ctx context.Context, userId primitive.ObjectID) (*models.Warehouse, error) {
+	var warehouse models.Warehouse
+
+	err := ws.Client.Collection(utils.WarehouseCollection).FindOne(ctx, bson.M{"user_id": userId}).Decode(&warehouse)
+
+	if err == mongo.ErrNoDocuments {
+		return &models.Warehouse{UserID: userId, Items: []models.WarehouseItem{}}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ws.gradeItems(ctx, warehouse.Items)
+
+	return &warehouse, nil
+}
+
+// GetHeldCropIDs returns the ids of the crops the user has unexpired stock of.
+func (ws *WarehouseService) GetHeldCropIDs(ctx context.Context, userId primitive.ObjectID) ([]string, error) {
+	warehouse, err := ws.GetUserWarehouse(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	seen := map[primitive.ObjectID]bool{}
+	cropIds := []string{}
+
+	for _, item := range warehouse.Items {
+		if item.Quantity <= 0 || item.IsExpired || !item.ExpiresAt.After(now) || seen[item.CropID] {
+			continue
+		}
+
+		seen[item.CropID] = true
+		cropIds = append(cropIds, item.CropID.Hex())
+	}
+
+	return cropIds, nil
+}
+
+// StoreItem adds a stack to the user's warehouse, creating the warehouse on first use.
+func (ws *WarehouseService) StoreItem(warehouseItem *models.WarehouseItem) error {
+	collection := ws.Client.Collection(utils.WarehouseCollection)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if warehouseItem.Grade == "" {
+		warehouseItem.Grade = utils.QualityGrade(warehouseItem.QualityFactor, ws.gradeThresholds(ctx, warehouseItem.CropID))
+	}
+
+	defaultCapacity := defaultWarehouseCapacity
+	usedCapacity := warehouseItem.Quantity
+	totalCapacity := defaultCapacity
+
+	var warehouse models.Warehouse
+
+	err := collection.FindOne(ctx, bson.M{"user_id": warehouseItem.UserID}).Decode(&warehouse)
+
+	if err == mongo.ErrNoDocuments {
+		newWarehouse := models.Warehouse{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			UserID:        warehouseItem.UserID,
+			TotalCapacity: defaultCapacity,
+			UsedCapacity:  warehouseItem.Quantity,
+			Items:         []models.WarehouseItem{*warehouseItem},
+		}
+
+		_, err := collection.InsertOne(ctx, newWarehouse)
+
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		if warehouse.UsedCapacity+warehouse.ReservedCapacity+warehouseItem.Quantity > warehouse.TotalCapacity {
+			return fmt.Errorf("warehouse is full")
+		}
+
+		update := bson.M{
+			"$push": bson.M{"items": warehouseItem},
+			"$inc":  bson.M{"used_capacity": warehouseItem.Quantity},
+			"$set":  bson.M{"updated_at": time.Now()},
+		}
+
+		_, err = collection.UpdateOne(ctx, bson.M{"user_id": warehouseItem.UserID}, update)
+
+		if err != nil {
+			return fmt.Errorf("failed to update warehouse: %v", err)
+		}
+
+		usedCapacity += warehouse.UsedCapacity
+		totalCapacity = warehouse.TotalCapacity
+	}
+
+	ws.eventService.PublishQuietly(ctx, warehouseItem.UserID, utils.EventWarehouseChanged, types.WarehouseChangedPayload{
+		CropID:        warehouseItem.CropID.Hex(),
+		QuantityDelta: warehouseItem.Quantity,
+		UsedCapacity:  usedCapacity,
+		TotalCapacity: totalCapacity,
+		Source:        warehouseItem.Source,
+	})
+
+	return nil
+}
+
+// StoreItemOnce adds a stack to the user's warehouse unless a stack was stored under key already,
+// so a settlement can retry a delivery until it lands without delivering twice. With a capacity
+// reservation the stack fills reserved space; without one it needs free space.
+func (ws *WarehouseService) StoreItemOnce(ctx context.Context, warehouseItem *models.WarehouseItem, key string, reservationRef string) error {
+	if warehouseItem.Grade == "" {
+		warehouseItem.Grade = utils.QualityGrade(warehouseItem.QualityFactor, ws.gradeThresholds(ctx, warehouseItem.CropID))
+	}
+
+	if err := ws.ensureWarehouse(ctx, warehouseItem.UserID); err != nil {
+		return err
+	}
+
+	collection := ws.Client.Collection(utils.WarehouseCollection)
+
+	filter := bson.M{"user_id": warehouseItem.UserID, "applied_keys": bson.M{"$ne": key}}
+	inc := bson.M{"used_capacity": warehouseItem.Quantity}
+
+	if reservationRef != "" {
+		field := "capacity_reservations." + reservationRef
+		filter[field] = bson.M{"$gte": warehouseItem.Quantity}
+		inc["reserved_capacity"] = -warehouseItem.Quantity
+		inc[field] = -warehouseItem.Quantity
+	} else {
+		filter["$expr"] = hasFreeCapacity(warehouseItem.Quantity)
+	}
+
+	var warehouse models.Warehouse
+
+	err := collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{
+			"$inc": inc,
+			"$push": bson.M{
+				"items":        warehouseItem,
+				"applied_keys": bson.M{"$each": bson.A{key}, "$slice": -warehouseKeyHistory},
+			},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&warehouse)
+
+	if err == mongo.ErrNoDocuments {
+		if err := collection.FindOne(ctx, bson.M{"user_id": warehouseItem.UserID}).Decode(&warehouse); err != nil {
+			return err
+		}
+
+		if slices.Contains(warehouse.AppliedKeys, key) {
+			return nil
+		}
+
+		if reservationRef != "" {
+			return NewServiceError(http.StatusConflict, "reserved space does not cover %d units", warehouseItem.Quantity)
+		}
+
+		return NewServiceError(http.StatusBadRequest, "warehouse is full")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ws.eventService.PublishQuietly(ctx, warehouseItem.UserID, utils.EventWarehouseChanged, types.WarehouseChangedPayload{
+		CropID:        warehouseItem.CropID.Hex(),
+		QuantityDelta: warehouseItem.Quantity,
+		UsedCapacity:  warehouse.UsedCapacity,
+		TotalCapacity: warehouse.TotalCapacity,
+		Source:        warehouseItem.Source,
+	})
+
+	return nil
+}
+
+// ReserveCapacity sets quantity units of free warehouse space aside under referenceId, so stock
+// bought for later delivery is sure to fit. Reserving the same reference again is a no-op.
+func (ws *WarehouseService) ReserveCapacity(ctx context.Context, userId primitive.ObjectID, quantity int, referenceId string) error {
+	if quantity <= 0 {
+		return NewServiceError(http.StatusBadRequest, "quantity must be positive")
+	}
+
+	if err := ws.ensureWarehouse(ctx, userId); err != nil {
+		return err
+	}
+
+	collection := ws.Client.Collection(utils.WarehouseCollection)
+	field := "capacity_reservations." + referenceId
+
+	result, err := collection.UpdateOne(
+		ctx,
+		bson.M{"user_id": userId, field: bson.M{"$exists": false}, "$expr": hasFreeCapacity(quantity)},
+		bson.M{
+			"$inc": bson.M{"reserved_capacity": quantity},
+			"$set": bson.M{field: quantity, "updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 1 {
+		return nil
+	}
+
+	var warehouse models.Warehouse
+	if err := collection.FindOne(ctx, bson.M{"user_id": userId}).Decode(&warehouse); err != nil {
+		return err
+	}
+
+	if _, ok := warehouse.CapacityReservations[referenceId]; ok {
+		return nil
+	}
+
+	return NewServiceError(http.StatusBadRequest, "warehouse is full")
+}
+
+// ReleaseCapacity frees whatever space is still reserved under referenceId. Releasing it again is a no-op.
+func (ws *WarehouseService) ReleaseCapacity(ctx context.Context, userId primitive.ObjectID, referenceId string) error {
+	collection := ws.Client.Collection(utils.WarehouseCollection)
+	field := "capacity_reservations." + referenceId
+
+	// Deliveries may shrink the reservation in between, so retry on a changed amount.
+	for attempt := 0; attempt < 3; attempt++ {
+		var warehouse models.Warehouse
+
+		err := collection.FindOne(ctx, bson.M{"user_id": userId}).Decode(&warehouse)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		quantity, ok := warehouse.CapacityReservations[referenceId]
+		if !ok {
+			return nil
+		}
+
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"user_id": userId, field: quantity},
+			bson.M{
+				"$inc":   bson.M{"reserved_capacity": -quantity},
+				"$unset": bson.M{field: ""},
+				"$set":   bson.M{"updated_at": time.Now()},
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		if result.MatchedCount == 1 {
+			return nil
+		}
+	}
+
+	return NewServiceError(http.StatusConflict, "warehouse is busy, try again")
+}
+
+// ensureWarehouse creates the user's empty warehouse if there is none yet.
+func (ws *WarehouseService) ensureWarehouse(ctx context.Context, userId primitive.ObjectID) error {
+	now := time.Now()
+
+	_, err := ws.Client.Collection(utils.WarehouseCollection).UpdateOne(
+		ctx,
+		bson.M{"user_id": userId},
+		bson.M{"$setOnInsert": bson.M{
+			"_id":               primitive.NewObjectID(),
+			"created_at":        now,
+			"updated_at":        now,
+			"total_capacity":    defaultWarehouseCapacity,
+			"used_capacity":     0,
+			"reserved_capacity": 0,
+		}},
+		options.Update().SetUpsert(true),
+	)
+
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+
+	return err
+}
+
+// hasFreeCapacity is an $expr matching warehouses with room for quantity more units.
+func hasFreeCapacity(quantity int) bson.M {
+	return bson.M{"$lte": bson.A{
+		bson.M{"$add": bson.A{"$used_capacity", bson.M{"$ifNull": bson.A{"$reserved_capacity", 0}}, quantity}},
+		"$total_capacity",
+	}}
+}
+
+// ReserveStock takes quantity of the crop out of the user's warehouse, oldest stacks first, and
+// keeps it in a reservation until it is consumed by a sale or released back.
+func (ws *WarehouseService) ReserveStock(ctx context.Context, userId primitive.ObjectID, cropId primitive.ObjectID, quantity int, reason string, referenceId string) (*models.StockReservation, error) {
+	return ws.reserveStock(ctx, userId, cropId, quantity, func(item models.WarehouseItem) bool {
+		return item.CropID == cropId
+	}, reason, referenceId)
+}
+
+// ReserveGradedStock is ReserveStock taking only stacks of the given quality grade.
+func (ws *WarehouseService) ReserveGradedStock(ctx context.Context, userId primitive.ObjectID, cropId primitive.ObjectID, grade string, quantity int, reason string, referenceId string) (*models.StockReservation, error) {
+	thresholds := ws.gradeThresholds(ctx, cropId)
+
+	return ws.reserveStock(ctx, userId, cropId, quantity, func(item models.WarehouseItem) bool {
+		return item.CropID == cropId && stackGrade(item, thresholds) == grade
+	}, reason, referenceId)
+}
+
+// ReserveQualityStock is ReserveStock taking only stacks of at least minQuality.
+func (ws *WarehouseService) ReserveQualityStock(ctx context.Context, userId primitive.ObjectID, cropId primitive.ObjectID, minQuality float64, quantity int, reason string, referenceId string) (*models.StockReservation, error) {
+	return ws.reserveStock(ctx, userId, cropId, quantity, func(item models.WarehouseItem) bool {
+		return item.CropID == cropId && item.QualityFactor >= minQuality
+	}, reason, referenceId)
+}
+
+func (ws *WarehouseService) reserveStock(ctx context.Context, userId primitive.ObjectID, cropId primitive.ObjectID, quantity int, match func(models.WarehouseItem) bool, reason string, referenceId string) (*models.StockReservation, error) {
+	if quantity <= 0 {
+		return nil, NewServiceError(http.StatusBadRequest, "quantity must be positive")
+	}
+
+	collection := ws.Client.Collection(utils.WarehouseCollection)
+
+	// The items array is rewritten as a whole, so retry when another write got in between.
+	for attempt := 0; attempt < 3; attempt++ {
+		var warehouse models.Warehouse
+
+		err := collection.FindOne(ctx, bson.M{"user_id": userId}).Decode(&warehouse)
+		if err == mongo.ErrNoDocuments {
+			return nil, NewServiceError(http.StatusBadRequest, "Not enough stock")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		remaining, lots, ok := takeStock(warehouse.Items, match, quantity, time.Now())
+		if !ok {
+			return nil, NewServiceError(http.StatusBadRequest, "Not enough stock")
+		}
+
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"_id": warehouse.ID, "updated_at": warehouse.UpdatedAt},
+			bson.M{
+				"$set": bson.M{"items": remaining, "updated_at": time.Now()},
+				"$inc": bson.M{"used_capacity": -quantity},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.MatchedCount == 0 {
+			continue
+		}
+
+		// Lots carry their grade wherever they go next.
+		ws.gradeItems(ctx, lots)
+
+		now := time.Now()
+
+		reservation := models.StockReservation{
+			BaseModel: models.BaseModel{
+				ID:        primitive.NewObjectID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			},
+			UserID:      userId,
+			CropID:      cropId,
+			Quantity:    quantity,
+			Lots:        lots,
+			Status:      utils.EscrowHeld,
+			Reason:      reason,
+			ReferenceID: referenceId,
+		}
+
+		if _, err := ws.Client.Collection(utils.ReservationsCollection).InsertOne(ctx, reservation); err != nil {
+			ws.restoreLots(ctx, userId, lots)
+			return nil, err
+		}
+
+		ws.eventService.PublishQuietly(ctx, userId, utils.EventWarehouseChanged, types.WarehouseChangedPayload{
+			CropID:        cropId.Hex(),
+			QuantityDelta: -quantity,
+			UsedCapacity:  warehouse.UsedCapacity - quantity,
+			TotalCapacity: warehouse.TotalCapacity,
+			Source:        reason,
+		})
+
+		return &reservation, nil
+	}
+
+	return nil, NewServiceError(http.StatusConflict, "warehouse is busy, try again")
+}
+
+// ReleaseReservation puts reserved stock back into the owner's warehouse. Releasing a reservation
+// that is no longer HELD is a no-op.
+func (ws *WarehouseService) ReleaseReservation(ctx context.Context, reservationId primitive.ObjectID) error {
+	reservation, claimed, err := ws.claimReservation(ctx, reservationId, utils.EscrowReleased)
+	if err != nil || !claimed {
+		return err
+	}
+
+	return ws.restoreLots(ctx, reservation.UserID, reservation.Lots)
+}
+
+// ConsumeReservation marks reserved stock as gone for good and returns it, or nil if it was not HELD.
+func (ws *WarehouseService) ConsumeReservation(ctx context.Context, reservationId primitive.ObjectID) (*models.StockReservation, error) {
+	reservation, claimed, err := ws.claimReservation(ctx, reservationId, utils.EscrowConsumed)
+	if err != nil || !claimed {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ConsumeReservationPart takes quantity out of a held reservation for good, oldest lots first, and
+// returns the lots taken. The reservation is CONSUMED once nothing is left in it. Each part is
+// keyed, so taking the same key again returns the lots taken the first time.
+func (ws *WarehouseService) ConsumeReservationPart(ctx context.Context, reservationId primitive.ObjectID, quantity int, key string) ([]models.WarehouseItem, error) {
+	collection := ws.Client.Collection(utils.ReservationsCollection)
+
+	// The lots array is rewritten as a whole, so retry when another write got in between.
+	for attempt := 0; attempt < 3; attempt++ {
+		reservation, err := ws.GetReservation(ctx, reservationId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, part := range reservation.Parts {
+			if part.Key == key {
+				return part.Lots, nil
+			}
+		}
+
+		if reservation.Status != utils.EscrowHeld {
+			return nil, NewServiceError(http.StatusConflict, "reservation is no longer held")
+		}
+
+		remaining, lots, ok := takeStock(reservation.Lots, func(models.WarehouseItem) bool { return true }, quantity, time.Time{})
+		if !ok {
+			return nil, NewServiceError(http.StatusConflict, "reservation holds only %d units", reservation.Quantity)
+		}
+
+		set := bson.M{"lots": remaining, "quantity": reservation.Quantity - quantity, "updated_at": time.Now()}
+		if reservation.Quantity == quantity {
+			set["status"] = utils.EscrowConsumed
+		}
+
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"_id": reservation.ID, "status": utils.EscrowHeld, "quantity": reservation.Quantity, "parts.key": bson.M{"$ne": key}},
+			bson.M{
+				"$set":  set,
+				"$push": bson.M{"parts": models.ReservationPart{Key: key, Lots: lots}},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.MatchedCount == 1 {
+			return lots, nil
+		}
+	}
+
+	return nil, NewServiceError(http.StatusConflict, "reservation is busy, try again")
+}
+
+// GetReservation returns a stock reservation by id.
+func (ws *WarehouseService) GetReservation(ctx context.Context, reservationId primitive.ObjectID) (*models.StockReservation, error) {
+	var reservation models.StockReservation
+
+	err := ws.Client.Collection(utils.ReservationsCollection).FindOne(ctx, bson.M{"_id": reservationId}).Decode(&reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// GetReservationByReference returns the reservation made for referenceId, if any.
+func (ws *WarehouseService) GetReservationByReference(ctx context.Context, referenceId string) (*models.StockReservation, error) {
+	var reservation models.StockReservation
+
+	err := ws.Client.Collection(utils.ReservationsCollection).FindOne(ctx, bson.M{"reference_id": referenceId}).Decode(&reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+func (ws *WarehouseService) claimReservation(ctx context.Context, reservationId primitive.ObjectID, status string) (*models.StockReservation, bool, error) {
+	var reservation models.StockReservation
+
+	err := ws.Client.Collection(utils.ReservationsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": reservationId, "status": utils.EscrowHeld},
+		bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}},
+	).Decode(&reservation)
+
+	if err == mongo.ErrNoDocuments {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &reservation, true, nil
+}
+
+// restoreLots pushes stacks back into the warehouse without a capacity check, since they were stored there before.
+func (ws *WarehouseService) restoreLots(ctx context.Context, userId primitive.ObjectID, lots []models.WarehouseItem) error {
+	quantity := 0
+	for _, lot := range lots {
+		quantity += lot.Quantity
+	}
+
+	var warehouse models.Warehouse
+
+	err := ws.Client.Collection(utils.WarehouseCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"user_id": userId},
+		bson.M{
+			"$push": bson.M{"items": bson.M{"$each": lots}},
+			"$inc":  bson.M{"used_capacity": quantity},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&warehouse)
+
+	if err != nil {
+		return fmt.Errorf("failed to restore warehouse stock: %v", err)
+	}
+
+	if len(lots) > 0 {
+		ws.eventService.PublishQuietly(ctx, userId, utils.EventWarehouseChanged, types.WarehouseChangedPayload{
+			CropID:        lots[0].CropID.Hex(),
+			QuantityDelta: quantity,
+			UsedCapacity:  warehouse.UsedCapacity,
+			TotalCapacity: warehouse.TotalCapacity,
+			Source:        "RESERVATION_RELEASE",
+		})
+	}
+
+	return nil
+}
+
+// takeStock splits items into what stays in the warehouse and the lots that make up quantity of
+// the matching stacks, using the oldest unexpired ones first. ok is false when there is not
+// enough stock.
+func takeStock(items []models.WarehouseItem, match func(models.WarehouseItem) bool, quantity int, now time.Time) (remaining []models.WarehouseItem, lots []models.WarehouseItem, ok bool) {
+	order := make([]int, 0, len(items))
+	for i, item := range items {
+		if match(item) && !item.IsExpired && item.ExpiresAt.After(now) {
+			order = append(order, i)
+		}
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return items[order[a]].StoredAt.Before(items[order[b]].StoredAt)
+	})
+
+	left := make([]int, len(items))
+	for i, item := range items {
+		left[i] = item.Quantity
+	}
+
+	needed := quantity
+	for _, i := range order {
+		if needed == 0 {
+			break
+		}
+
+		take := min(left[i], needed)
+		lot := items[i]
+		lot.Quantity = take
+		lots = append(lots, lot)
+
+		left[i] -= take
+		needed -= take
+	}
+
+	if needed > 0 {
+		return nil, nil, false
+	}
+
+	remaining = []models.WarehouseItem{}
+	for i, item := range items {
+		if left[i] > 0 {
+			item.Quantity = left[i]
+			remaining = append(remaining, item)
+		}
+	}
+
+	return remaining, lots, true
+}
+
+// averageQuality is the quantity-weighted quality of the lots.
+func averageQuality(lots []models.WarehouseItem) float64 {
+	total, quality := 0, 0.0
+
+	for _, lot := range lots {
+		total += lot.Quantity
+		quality += lot.QualityFactor * float64(lot.Quantity)
+	}
+
+	if total == 0 {
+		return 1.0
+	}
+
+	return quality / float64(total)
+}
+
+// gradeThresholds returns the crop's grade thresholds, or nil for the defaults.
+func (ws *WarehouseService) gradeThresholds(ctx context.Context, cropId primitive.ObjectID) map[string]float64 {
+	var crop models.Crop
+
+	err := ws.Client.Collection(utils.CropsCollection).FindOne(
+		ctx,
+		bson.M{"_id": cropId},
+		options.FindOne().SetProjection(bson.M{"grade_thresholds": 1}),
+	).Decode(&crop)
+	if err != nil && err != mongo.ErrNoDocuments {
+		fmt.Printf("Error loading grade thresholds of crop %s: %v\n", cropId.Hex(), err)
+	}
+
+	return crop.GradeThresholds
+}
+
+// gradeItems fills in the grade of stacks stored before stock was graded.
+func (ws *WarehouseService) gradeItems(ctx context.Context, items []models.WarehouseItem) {
+	thresholds := map[primitive.ObjectID]map[string]float64{}
+
+	for i := range items {
+		if items[i].Grade != "" {
+			continue
+		}
+
+		cropThresholds, ok := thresholds[items[i].CropID]
+		if !ok {
+			cropThresholds = ws.gradeThresholds(ctx, items[i].CropID)
+			thresholds[items[i].CropID] = cropThresholds
+		}
+
+		items[i].Grade = utils.QualityGrade(items[i].QualityFactor, cropThresholds)
+	}
+}
+
+func stackGrade(item models.WarehouseItem, thresholds map[string]float64) string {
+	if item.Grade != "" {
+		return item.Grade
+	}
+
+	return utils.QualityGrade(item.QualityFactor, thresholds)
+}
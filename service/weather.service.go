@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/utils"
+	"github.com/hrutik1235/farming-server/world"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const MaxForecastDays = 28
+
+type WeatherService struct {
+	Client *mongo.Database
+
+	worldID     string
+	seed        uint64
+	cropService *CropService
+	careService *CareService
+}
+
+func NewWeatherService(client *mongo.Database) *WeatherService {
+	return &WeatherService{
+		Client:      client,
+		worldID:     utils.ServerID(),
+		seed:        utils.WorldSeed(),
+		cropService: NewCropService(client),
+		careService: NewCareService(client),
+	}
+}
+
+// GetWeather returns the weather of a world day, generating and storing it the first time it is asked for.
+func (ws *WeatherService) GetWeather(ctx context.Context, day int) (*models.WeatherDay, error) {
+	collection := ws.Client.Collection(utils.WeatherCollection)
+	filter := bson.M{"world_id": ws.worldID, "day": day}
+
+	var weather models.WeatherDay
+
+	err := collection.FindOne(ctx, filter).Decode(&weather)
+	if err == nil {
+		return &weather, nil
+	}
+
+	if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	weather = world.Generate(ws.worldID, ws.seed, day)
+
+	now := time.Now()
+	weather.BaseModel = models.BaseModel{
+		ID:        primitive.NewObjectID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		IsActive:  true,
+	}
+
+	// Generation is deterministic, so whichever concurrent caller inserts first stores the same day.
+	_, err = collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": weather}, options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	return &weather, nil
+}
+
+// GetForecast returns today's weather and the following days, with the effect on crop if one is given.
+func (ws *WeatherService) GetForecast(ctx context.Context, days int, crop *models.Crop) ([]models.WeatherForecast, error) {
+	if days <= 0 || days > MaxForecastDays {
+		return nil, NewServiceError(http.StatusBadRequest, "days must be between 1 and %d", MaxForecastDays)
+	}
+
+	today := world.DayOf(time.Now())
+	forecast := make([]models.WeatherForecast, 0, days)
+
+	for day := today; day < today+days; day++ {
+		weather, err := ws.GetWeather(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := models.WeatherForecast{WeatherDay: *weather}
+		if crop != nil {
+			effect := world.CropEffect(crop, *weather)
+			entry.Effect = &effect
+		}
+
+		forecast = append(forecast, entry)
+	}
+
+	return forecast, nil
+}
+
+// ApplyWeather applies a day's weather and season to a growing planting once: the day's share of
+// the remaining growth time runs at the growth effect and the quality effect is added to its
+// quality factor.
+func (ws *WeatherService) ApplyWeather(ctx context.Context, planting models.PlantedCrop, crop *models.Crop, weather *models.WeatherDay) error {
+	if planting.WeatherDay >= weather.Day {
+		return nil
+	}
+
+	now := time.Now()
+	growth := ws.cropService.CalculateCurrentGrowth(planting)
+	remaining := planting.ExpectedHarvestAt.Sub(now)
+
+	if growth >= 1 || remaining <= 0 {
+		return nil
+	}
+
+	effect := world.CropEffect(crop, *weather)
+	expectedHarvestAt := world.ShiftHarvest(planting.ExpectedHarvestAt, now, effect.Growth)
+
+	var updated models.PlantedCrop
+
+	// Guarding on expected_harvest_at keeps a concurrent fertilize from being overwritten.
+	err := ws.Client.Collection(utils.PlantedCropsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"_id":                 planting.ID,
+			"is_harvested":        false,
+			"expected_harvest_at": planting.ExpectedHarvestAt,
+			"$or": []bson.M{
+				{"weather_day": bson.M{"$lt": weather.Day}},
+				{"weather_day": bson.M{"$exists": false}},
+			},
+		},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"growth_base":         growth,
+			"growth_base_at":      now,
+			"expected_harvest_at": expectedHarvestAt,
+			"weather_day":         weather.Day,
+			"quality_factor":      careQualityExpr(effect.Quality),
+			"updated_at":          now,
+		}}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+
+	// Applied already, or changed since it was read; the next tick retries.
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if effect.Growth == 1 && effect.Quality == 0 {
+		return nil
+	}
+
+	ws.careService.recordActivity(ctx, &planting, &models.PlantingActivity{
+		BaseModel: models.BaseModel{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			IsActive:  true,
+		},
+		PlantingID:    planting.ID,
+		UserID:        planting.UserID,
+		CropID:        planting.CropID,
+		ActivityType:  "WEATHER",
+		Description:   fmt.Sprintf("%s %s: %s grows at %.0f%% speed", strings.ToLower(weather.Season), strings.ToLower(weather.Condition), crop.Name, effect.Growth*100),
+		QualityChange: updated.QualityFactor - careQualityOf(&planting),
+		QualityFactor: updated.QualityFactor,
+		Timestamp:     now,
+	})
+
+	return nil
+}
+
+// validateClimate checks a crop's preferred seasons and weather sensitivities.
+func validateClimate(seasons []string, sensitivity map[string]float64) error {
+	for _, season := range seasons {
+		if !slices.Contains(world.Seasons, season) {
+			return NewServiceError(http.StatusBadRequest, "unknown season %q", season)
+		}
+	}
+
+	for condition, value := range sensitivity {
+		if !slices.Contains(world.Conditions, condition) {
+			return NewServiceError(http.StatusBadRequest, "unknown weather condition %q", condition)
+		}
+
+		if value < 0 || value > 3 || math.IsNaN(value) {
+			return NewServiceError(http.StatusBadRequest, "sensitivity to %s must be between 0 and 3", condition)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package tlsconn
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerIdentity returns the name in the verified client certificate of the connection ctx belongs
+// to: the subject common name, or the first DNS name when the CN is empty. ok is false on
+// connections without a verified client certificate.
+func PeerIdentity(ctx context.Context) (identity string, ok bool) {
+	p, found := peer.FromContext(ctx)
+	if !found {
+		return "", false
+	}
+
+	info, isTLS := p.AuthInfo.(credentials.TLSInfo)
+	if !isTLS || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	return certIdentity(info.State.VerifiedChains[0][0])
+}
+
+func certIdentity(cert *x509.Certificate) (string, bool) {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName, true
+	}
+
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0], true
+	}
+
+	return "", false
+}
@@ -0,0 +1,308 @@
+package tlsconn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating CA: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing CA: %v", err)
+	}
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue signs a certificate usable by both servers and clients, returning it and its key as PEM.
+func (ca *testCA) issue(t *testing.T, commonName string, dnsNames ...string) (certPEM []byte, keyPEM []byte) {
+	t.Helper()
+
+	key := newKey(t)
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("issuing certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("encoding key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	return key
+}
+
+type testFiles struct {
+	ca, cert, key string
+}
+
+// writeFiles writes an identity to dir, stamping the files with modTime so a rewrite is noticed.
+func writeFiles(t *testing.T, dir string, caPEM, certPEM, keyPEM []byte, modTime time.Time) testFiles {
+	t.Helper()
+
+	files := testFiles{
+		ca:   filepath.Join(dir, "ca.pem"),
+		cert: filepath.Join(dir, "cert.pem"),
+		key:  filepath.Join(dir, "key.pem"),
+	}
+
+	for path, data := range map[string][]byte{files.ca: caPEM, files.cert: certPEM, files.key: keyPEM} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("stamping %s: %v", path, err)
+		}
+	}
+
+	return files
+}
+
+func newIdentity(t *testing.T, ca *testCA, commonName string) *TLSConfig {
+	t.Helper()
+
+	certPEM, keyPEM := ca.issue(t, commonName, "localhost")
+	files := writeFiles(t, t.TempDir(), ca.pem, certPEM, keyPEM, time.Now())
+
+	config, err := NewTLS(files.ca, files.cert, files.key)
+	if err != nil {
+		t.Fatalf("NewTLS: %v", err)
+	}
+
+	return config
+}
+
+func leafName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	return leaf.Subject.CommonName
+}
+
+// handshake runs a mutual TLS handshake between client and server over an in-memory connection
+// and returns the server's view of the client.
+func handshake(t *testing.T, client *TLSConfig, server *TLSConfig) (credentials.AuthInfo, error) {
+	t.Helper()
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	type result struct {
+		info credentials.AuthInfo
+		err  error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		_, info, err := server.ServerCredentials().ServerHandshake(serverConn)
+		if err != nil {
+			// Unblock the client still waiting for the server's reply.
+			serverConn.Close()
+		}
+		done <- result{info, err}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, _, clientErr := client.ClientCredentials().ClientHandshake(ctx, "localhost", clientConn)
+	if clientErr != nil {
+		clientConn.Close()
+	}
+
+	res := <-done
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return res.info, clientErr
+}
+
+func TestFromFiles(t *testing.T) {
+	config, err := FromFiles("", "", "")
+	if err != nil || config != nil {
+		t.Fatalf("FromFiles with no files = %v, %v; want nil, nil", config, err)
+	}
+
+	if _, err := FromFiles("ca.pem", "", "key.pem"); err == nil {
+		t.Fatal("FromFiles with some files missing succeeded")
+	}
+}
+
+func TestReloadsRotatedCertificate(t *testing.T) {
+	ca := newTestCA(t, "farm-ca")
+	dir := t.TempDir()
+
+	certPEM, keyPEM := ca.issue(t, "farm-a")
+	files := writeFiles(t, dir, ca.pem, certPEM, keyPEM, time.Now().Add(-time.Minute))
+
+	config, err := NewTLS(files.ca, files.cert, files.key)
+	if err != nil {
+		t.Fatalf("NewTLS: %v", err)
+	}
+
+	cert, _ := config.current()
+	if name := leafName(t, cert); name != "farm-a" {
+		t.Fatalf("loaded certificate %q, want farm-a", name)
+	}
+
+	certPEM, keyPEM = ca.issue(t, "farm-b")
+	writeFiles(t, dir, ca.pem, certPEM, keyPEM, time.Now())
+
+	// Within the check interval the loaded certificate is kept.
+	cert, _ = config.current()
+	if name := leafName(t, cert); name != "farm-a" {
+		t.Fatalf("certificate %q before the check interval passed, want farm-a", name)
+	}
+
+	config.checkedAt = time.Time{}
+
+	cert, _ = config.current()
+	if name := leafName(t, cert); name != "farm-b" {
+		t.Fatalf("certificate %q after rotation, want farm-b", name)
+	}
+}
+
+func TestReloadKeepsLastGoodFiles(t *testing.T) {
+	ca := newTestCA(t, "farm-ca")
+	dir := t.TempDir()
+
+	certPEM, keyPEM := ca.issue(t, "farm-a")
+	files := writeFiles(t, dir, ca.pem, certPEM, keyPEM, time.Now().Add(-time.Minute))
+
+	config, err := NewTLS(files.ca, files.cert, files.key)
+	if err != nil {
+		t.Fatalf("NewTLS: %v", err)
+	}
+
+	// A half-written rotation: the new certificate does not match the old key.
+	otherPEM, _ := ca.issue(t, "farm-b")
+	writeFiles(t, dir, ca.pem, otherPEM, keyPEM, time.Now())
+	config.checkedAt = time.Time{}
+
+	cert, pool := config.current()
+	if name := leafName(t, cert); name != "farm-a" {
+		t.Fatalf("certificate %q after a broken rotation, want farm-a", name)
+	}
+	if pool == nil {
+		t.Fatal("CA pool dropped after a broken rotation")
+	}
+}
+
+func TestPeerIdentityFromHandshake(t *testing.T) {
+	ca := newTestCA(t, "farm-ca")
+
+	info, err := handshake(t, newIdentity(t, ca, "farm-a"), newIdentity(t, ca, "farm-b"))
+	if err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+
+	identity, ok := PeerIdentity(ctx)
+	if !ok || identity != "farm-a" {
+		t.Fatalf("PeerIdentity = %q, %v; want farm-a, true", identity, ok)
+	}
+}
+
+func TestHandshakeRejectsForeignClient(t *testing.T) {
+	server := newIdentity(t, newTestCA(t, "farm-ca"), "farm-b")
+	client := newIdentity(t, newTestCA(t, "other-ca"), "farm-a")
+
+	if _, err := handshake(t, client, server); err == nil {
+		t.Fatal("handshake with a certificate from another CA succeeded")
+	}
+}
+
+func TestPeerIdentityWithoutVerifiedCertificate(t *testing.T) {
+	if _, ok := PeerIdentity(context.Background()); ok {
+		t.Fatal("PeerIdentity found an identity without a peer")
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+	if _, ok := PeerIdentity(ctx); ok {
+		t.Fatal("PeerIdentity found an identity without a verified chain")
+	}
+}
+
+func TestCertIdentityFallsBackToDNSName(t *testing.T) {
+	ca := newTestCA(t, "farm-ca")
+	certPEM, _ := ca.issue(t, "", "farm-c.example")
+
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	if identity, ok := certIdentity(cert); !ok || identity != "farm-c.example" {
+		t.Fatalf("certIdentity = %q, %v; want farm-c.example, true", identity, ok)
+	}
+}
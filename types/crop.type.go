@@ -7,8 +7,105 @@ type CreateCrop struct {
 	YieldPerUnit    int     `json:"yield_per_unit" validate:"required" name:"yield_per_unit"`
 	CostPerUnit     float64 `json:"cost_per_unit" validate:"required" name:"cost_per_unit"`
 	Description     string  `json:"description" validate:"required" name:"description"`
+
+	CareSchedule       []CareSchedule     `json:"care_schedule" name:"care_schedule"`
+	PreferredSeasons   []string           `json:"preferred_seasons" name:"preferred_seasons"`
+	WeatherSensitivity map[string]float64 `json:"weather_sensitivity" name:"weather_sensitivity"`
+
+	OutbreakSusceptibility map[string]float64 `json:"outbreak_susceptibility" name:"outbreak_susceptibility"`
+	SoilImpact             map[string]float64 `json:"soil_impact" name:"soil_impact"`
+
+	Companions  []string `json:"companions" name:"companions"`
+	Antagonists []string `json:"antagonists" name:"antagonists"`
+
+	GradeThresholds map[string]float64 `json:"grade_thresholds" name:"grade_thresholds"`
+}
+
+type CareSchedule struct {
+	Action           string  `json:"action"`
+	IntervalHours    float64 `json:"interval_hours"`
+	CooldownHours    float64 `json:"cooldown_hours"`
+	CostPerUnit      float64 `json:"cost_per_unit"`
+	QualityBonus     float64 `json:"quality_bonus"`
+	QualityPenalty   float64 `json:"quality_penalty"`
+	GrowthBoostHours float64 `json:"growth_boost_hours"`
 }
 
+// PlantCrop picks land with exactly one of a number of free units, explicit land unit ids or a
+// region of the caller's land grid.
 type PlantCrop struct {
-	LandUnits int `json:"land_units" validate:"required" name:"land_units"`
+	LandUnits   int         `json:"land_units" validate:"omitempty,gt=0" name:"land_units"`
+	LandUnitIDs []string    `json:"land_unit_ids" name:"land_unit_ids"`
+	Region      *LandRegion `json:"region" name:"region"`
+}
+
+// LandRegion is a rectangle of the land grid; x and y are the zero based column and row of its corner.
+// OwnerID picks the grid of a landowner whose units are leased to the user instead of the user's own.
+type LandRegion struct {
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	OwnerID string `json:"owner_id"`
+}
+
+type SellCrop struct {
+	Quantity int `json:"quantity" validate:"required,gt=0" name:"quantity"`
+}
+
+// ListInStore lists warehouse stock of a crop in the storefront. With a grade only stacks of that
+// grade are listed.
+type ListInStore struct {
+	CropID       string  `json:"crop_id" validate:"required,len=24" name:"crop_id"`
+	Grade        string  `json:"grade" validate:"omitempty,oneof=A B C REJECT" name:"grade"`
+	Quantity     int     `json:"quantity" validate:"required,gt=0" name:"quantity"`
+	PricePerUnit float64 `json:"price_per_unit" validate:"required,gt=0" name:"price_per_unit"`
+	DurationDays int     `json:"duration_days" validate:"gte=0" name:"duration_days"`
+}
+
+type BuyFromStore struct {
+	Quantity int `json:"quantity" validate:"required,gt=0" name:"quantity"`
+}
+
+// StoreQuery filters and sorts storefront listings. Sort is price, -price, quality, -quality or
+// newest (default).
+type StoreQuery struct {
+	CropID     string  `form:"crop_id" validate:"omitempty,len=24" name:"crop_id"`
+	Grade      string  `form:"grade" validate:"omitempty,oneof=A B C REJECT" name:"grade"`
+	SellerID   string  `form:"seller_id" validate:"omitempty,len=24" name:"seller_id"`
+	MinPrice   float64 `form:"min_price" validate:"gte=0" name:"min_price"`
+	MaxPrice   float64 `form:"max_price" validate:"gte=0" name:"max_price"`
+	MinQuality float64 `form:"min_quality" validate:"gte=0" name:"min_quality"`
+	Sort       string  `form:"sort" validate:"omitempty,oneof=price -price quality -quality newest" name:"sort"`
+	Page       int     `form:"page" validate:"gte=0" name:"page"`
+	Limit      int     `form:"limit" validate:"gte=0,lte=100" name:"limit"`
+}
+
+// CreateStockAuction auctions a lot of warehouse stock for duration_minutes. With a grade only
+// stacks of that grade are used. Bids start at start_price and the lot only sells if the highest
+// meets reserve_price.
+type CreateStockAuction struct {
+	CropID          string  `json:"crop_id" validate:"required,len=24" name:"crop_id"`
+	Grade           string  `json:"grade" validate:"omitempty,oneof=A B C REJECT" name:"grade"`
+	Quantity        int     `json:"quantity" validate:"required,gt=0" name:"quantity"`
+	StartPrice      float64 `json:"start_price" validate:"required,gt=0" name:"start_price"`
+	ReservePrice    float64 `json:"reserve_price" validate:"gte=0" name:"reserve_price"`
+	DurationMinutes int     `json:"duration_minutes" validate:"required,gte=5,lte=10080" name:"duration_minutes"`
+}
+
+// OfferForward offers part of a growing planting's expected harvest at a locked price per unit.
+type OfferForward struct {
+	PlantingID   string  `json:"planting_id" validate:"required,len=24" name:"planting_id"`
+	Quantity     int     `json:"quantity" validate:"required,gt=0" name:"quantity"`
+	PricePerUnit float64 `json:"price_per_unit" validate:"required,gt=0" name:"price_per_unit"`
+}
+
+// PlaceOrder puts a buy or sell order on a crop's order book. LIMIT orders need a price and rest on
+// the book until filled; MARKET orders take what the book offers and drop the rest.
+type PlaceOrder struct {
+	Side     string  `json:"side" validate:"required,oneof=BUY SELL" name:"side"`
+	Type     string  `json:"type" validate:"omitempty,oneof=LIMIT MARKET" name:"type"`
+	Grade    string  `json:"grade" validate:"required,oneof=A B C REJECT" name:"grade"`
+	Quantity int     `json:"quantity" validate:"required,gt=0" name:"quantity"`
+	Price    float64 `json:"price" validate:"gte=0" name:"price"`
 }
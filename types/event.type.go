@@ -0,0 +1,119 @@
+package types
+
+import "time"
+
+type UserRegisteredEvent struct {
+	UserID       string    `json:"user_id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+type GrowthUpdatedPayload struct {
+	PlantingID        string    `bson:"planting_id" json:"planting_id"`
+	CropID            string    `bson:"crop_id" json:"crop_id"`
+	GrowthPercentage  float64   `bson:"growth_percentage" json:"growth_percentage"`
+	ExpectedHarvestAt time.Time `bson:"expected_harvest_at" json:"expected_harvest_at"`
+}
+
+type GrowthMilestonePayload struct {
+	PlantingID string `bson:"planting_id" json:"planting_id"`
+	CropID     string `bson:"crop_id" json:"crop_id"`
+	Milestone  int    `bson:"milestone" json:"milestone"` // 25, 50, 75 or 100
+}
+
+type OutbreakPayload struct {
+	PlantingID         string `bson:"planting_id" json:"planting_id"`
+	CropID             string `bson:"crop_id" json:"crop_id"`
+	Kind               string `bson:"kind" json:"kind"` // PEST or DISEASE
+	InfectedNeighbours int    `bson:"infected_neighbours" json:"infected_neighbours"`
+}
+
+type HarvestReadyPayload struct {
+	PlantingID    string `bson:"planting_id" json:"planting_id"`
+	CropID        string `bson:"crop_id" json:"crop_id"`
+	ExpectedYield int    `bson:"expected_yield" json:"expected_yield"`
+}
+
+type WarehouseChangedPayload struct {
+	CropID        string `bson:"crop_id" json:"crop_id"`
+	QuantityDelta int    `bson:"quantity_delta" json:"quantity_delta"`
+	UsedCapacity  int    `bson:"used_capacity" json:"used_capacity"`
+	TotalCapacity int    `bson:"total_capacity" json:"total_capacity"`
+	Source        string `bson:"source" json:"source"`
+}
+
+type WalletChangedPayload struct {
+	Balance float64 `bson:"balance" json:"balance"`
+	Delta   float64 `bson:"delta" json:"delta"`
+	Reason  string  `bson:"reason" json:"reason"`
+}
+
+type PriceChangedPayload struct {
+	CropID        string  `bson:"crop_id" json:"crop_id"`
+	Grade         string  `bson:"grade" json:"grade"`
+	Price         float64 `bson:"price" json:"price"`
+	PreviousPrice float64 `bson:"previous_price" json:"previous_price"`
+	ChangePercent float64 `bson:"change_percent" json:"change_percent"`
+	Reason        string  `bson:"reason" json:"reason"`
+}
+
+type TradeOfferPayload struct {
+	TradeID      string  `bson:"trade_id" json:"trade_id"`
+	FromUserID   string  `bson:"from_user_id" json:"from_user_id"`
+	CropID       string  `bson:"crop_id" json:"crop_id"`
+	Quantity     int     `bson:"quantity" json:"quantity"`
+	PricePerUnit float64 `bson:"price_per_unit" json:"price_per_unit"`
+}
+
+type LeaseRequestPayload struct {
+	LeaseID     string  `bson:"lease_id" json:"lease_id"`
+	RequesterID string  `bson:"requester_id" json:"requester_id"`
+	LandUnits   int     `bson:"land_units" json:"land_units"`
+	Price       float64 `bson:"price" json:"price"`
+	Terms       string  `bson:"terms" json:"terms"`
+}
+
+type OrderFilledPayload struct {
+	OrderID   string  `bson:"order_id" json:"order_id"`
+	CropID    string  `bson:"crop_id" json:"crop_id"`
+	Grade     string  `bson:"grade" json:"grade"`
+	Side      string  `bson:"side" json:"side"`
+	Quantity  int     `bson:"quantity" json:"quantity"`
+	Price     float64 `bson:"price" json:"price"`
+	Remaining int     `bson:"remaining" json:"remaining"`
+}
+
+type StoreSalePayload struct {
+	ListingID   string  `bson:"listing_id" json:"listing_id"`
+	CropID      string  `bson:"crop_id" json:"crop_id"`
+	BuyerID     string  `bson:"buyer_id" json:"buyer_id"`
+	Quantity    int     `bson:"quantity" json:"quantity"`
+	TotalAmount float64 `bson:"total_amount" json:"total_amount"`
+	Commission  float64 `bson:"commission" json:"commission"`
+}
+
+type ContractPayload struct {
+	ContractID string  `bson:"contract_id" json:"contract_id"`
+	CropID     string  `bson:"crop_id" json:"crop_id"`
+	Quantity   int     `bson:"quantity" json:"quantity"`
+	Penalty    float64 `bson:"penalty" json:"penalty"`
+	Reason     string  `bson:"reason" json:"reason"`
+}
+
+type ForwardSettledPayload struct {
+	ContractID string  `bson:"contract_id" json:"contract_id"`
+	CropID     string  `bson:"crop_id" json:"crop_id"`
+	Status     string  `bson:"status" json:"status"`
+	Delivered  int     `bson:"delivered" json:"delivered"`
+	Shortfall  int     `bson:"shortfall" json:"shortfall"`
+	Penalty    float64 `bson:"penalty" json:"penalty"`
+}
+
+type AuctionPayload struct {
+	AuctionID string  `bson:"auction_id" json:"auction_id"`
+	Amount    float64 `bson:"amount" json:"amount"` // The highest bid
+	LeaseID   string  `bson:"lease_id,omitempty" json:"lease_id,omitempty"`
+	TradeID   string  `bson:"trade_id,omitempty" json:"trade_id,omitempty"`
+}
@@ -0,0 +1,27 @@
+package types
+
+type ConnectPeer struct {
+	Address string `json:"address" validate:"required" name:"address"`
+}
+
+type InitiateFederatedTrade struct {
+	PeerID       string  `json:"peer_id" validate:"required" name:"peer_id"`
+	RemoteUserID string  `json:"remote_user_id" validate:"required" name:"remote_user_id"`
+	Side         string  `json:"side" validate:"required,oneof=SELLER BUYER" name:"side"` // The local user's side
+	CropName     string  `json:"crop_name" validate:"required" name:"crop_name"`
+	Quantity     int     `json:"quantity" validate:"required,gt=0" name:"quantity"`
+	PricePerUnit float64 `json:"price_per_unit" validate:"required,gt=0" name:"price_per_unit"`
+}
+
+// FederatedTradeTerms are the terms a coordinating server asks a peer to prepare.
+type FederatedTradeTerms struct {
+	TradeID        string
+	SellerID       string
+	SellerServerID string
+	BuyerID        string
+	BuyerServerID  string
+	CropName       string
+	Quantity       int
+	PricePerUnit   float64
+	QualityFactor  float64
+}
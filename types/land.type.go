@@ -0,0 +1,14 @@
+package types
+
+type BuildStructure struct {
+	Kind string `json:"kind" validate:"required" name:"kind"`
+}
+
+type ExpandLand struct {
+	Units int `json:"units" validate:"required,gt=0" name:"units"`
+}
+
+type ListLand struct {
+	LandUnitIDs []string `json:"land_unit_ids" validate:"required,min=1" name:"land_unit_ids"`
+	Price       float64  `json:"price" validate:"required,gt=0" name:"price"`
+}
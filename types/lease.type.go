@@ -0,0 +1,24 @@
+package types
+
+// RequestLease asks a landowner to lease land units. Terms decide which price applies: lease_price
+// up front for FLAT, daily_rent for RENT and owner_share_percent of each harvest for CROP_SHARE.
+type RequestLease struct {
+	LandUnitIDs       []string `json:"land_unit_ids" validate:"required,min=1" name:"land_unit_ids"`
+	DurationDays      int      `json:"duration_days" validate:"required,gt=0,lte=365" name:"duration_days"`
+	Terms             string   `json:"terms" validate:"omitempty,oneof=FLAT CROP_SHARE RENT" name:"terms"`
+	LeasePrice        float64  `json:"lease_price" validate:"gte=0" name:"lease_price"`
+	DailyRent         float64  `json:"daily_rent" validate:"gte=0" name:"daily_rent"`
+	OwnerSharePercent float64  `json:"owner_share_percent" validate:"gte=0,lt=100" name:"owner_share_percent"`
+}
+
+// CreateLeaseAuction puts a lease on land units up for auction for duration_minutes.
+type CreateLeaseAuction struct {
+	LandUnitIDs     []string `json:"land_unit_ids" validate:"required,min=1" name:"land_unit_ids"`
+	DurationDays    int      `json:"duration_days" validate:"required,gt=0,lte=365" name:"duration_days"`
+	ReservePrice    float64  `json:"reserve_price" validate:"required,gt=0" name:"reserve_price"`
+	DurationMinutes int      `json:"duration_minutes" validate:"required,gte=5,lte=10080" name:"duration_minutes"`
+}
+
+type PlaceBid struct {
+	Amount float64 `json:"amount" validate:"required,gt=0" name:"amount"`
+}
@@ -0,0 +1,33 @@
+package types
+
+import "encoding/json"
+
+// SocketCommand is a message a game client sends over the WebSocket gateway.
+type SocketCommand struct {
+	ID      string          `json:"id"`   // Echoed back on the reply
+	Type    string          `json:"type"` // plant, harvest, sell
+	Payload json.RawMessage `json:"payload"`
+}
+
+// SocketMessage is a message the gateway sends: a command reply, a pushed event or a resync notice.
+type SocketMessage struct {
+	ID     string `json:"id,omitempty"`
+	Type   string `json:"type"` // result, error, event, resync
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Data   any    `json:"data,omitempty"`
+}
+
+type PlantCommand struct {
+	CropID string `json:"crop_id" validate:"required" name:"crop_id"`
+	PlantCrop
+}
+
+type HarvestCommand struct {
+	PlantingID string `json:"planting_id" validate:"required" name:"planting_id"`
+}
+
+type SellCommand struct {
+	CropID   string `json:"crop_id" validate:"required" name:"crop_id"`
+	Quantity int    `json:"quantity" validate:"required,gt=0" name:"quantity"`
+}
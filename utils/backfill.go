@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type collectionBackfill struct {
+	collection string
+	filter     bson.M
+	update     bson.M
+}
+
+// Defaults for fields added after documents were already stored, which the services now filter on.
+var backfills = []collectionBackfill{
+	// Market prices from before grades priced the whole crop, as grade B does now.
+	{MarketPricesCollection, bson.M{"grade": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"grade": GradeB}}},
+}
+
+// Backfill sets the defaults of fields older documents lack. It only touches documents without
+// the field, so running it on every start is cheap once they are done. A failure is logged
+// rather than fatal, like EnsureIndexes.
+func Backfill(ctx context.Context, db *mongo.Database) {
+	for _, backfill := range backfills {
+		result, err := db.Collection(backfill.collection).UpdateMany(ctx, backfill.filter, backfill.update)
+		if err != nil {
+			fmt.Printf("Error backfilling %s: %v\n", backfill.collection, err)
+			continue
+		}
+
+		if result.ModifiedCount > 0 {
+			fmt.Printf("Backfilled %d documents in %s\n", result.ModifiedCount, backfill.collection)
+		}
+	}
+}
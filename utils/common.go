@@ -39,10 +39,167 @@ const (
 	TransactionsCollection    = "transactions"
 	PeerConnectionsCollection = "peer_connections"
 	EventsCollection          = "events"
+	OnboardingCollection      = "onboarding"
+	NotificationsCollection   = "notifications"
+	SeedInventoryCollection   = "seed_inventory"
+	CountersCollection        = "counters"
+	WalletHoldsCollection     = "wallet_holds"
+	ReservationsCollection    = "stock_reservations"
+	DirectoryCollection       = "federated_directory"
+	FederatedTradesCollection = "federated_trades"
+	PriceHistoryCollection    = "price_history"
+	ActivitiesCollection      = "planting_activities"
+	WeatherCollection         = "weather"
+	LandListingsCollection    = "land_listings"
+	LandTransfersCollection   = "land_transfers"
+	LeaseActivitiesCollection = "lease_activities"
+	LeaseAuctionsCollection   = "lease_auctions"
+	LeaseBidsCollection       = "lease_bids"
+	MarketOrdersCollection    = "market_orders"
+	StoreListingsCollection   = "store_listings"
+	ContractsCollection       = "delivery_contracts"
+	StockAuctionsCollection   = "stock_auctions"
+	StockBidsCollection       = "stock_bids"
+	ForwardsCollection        = "forward_contracts"
 )
 
 const (
-	InitialLandUnitSize = 100
+	EscrowPending  = "PENDING" // Hold recorded, money not yet known to have moved
+	EscrowHeld     = "HELD"
+	EscrowReleased = "RELEASED"
+	EscrowCaptured = "CAPTURED"
+	EscrowConsumed = "CONSUMED"
+)
+
+const (
+	CareWater     = "WATER"
+	CareFertilize = "FERTILIZE"
+	CareWeed      = "WEED"
+
+	// Bounds of a growing planting's care quality, which starts at 1.0
+	MinCareQuality = 0.5
+	MaxCareQuality = 1.25
+)
+
+const (
+	NutrientNitrogen   = "nitrogen"
+	NutrientPhosphorus = "phosphorus"
+	NutrientPotassium  = "potassium"
+
+	DefaultSoilNutrient  = 80.0
+	MaxSoilNutrient      = 100.0
+	FallowRecoveryPerDay = 5.0
+)
+
+const (
+	LeasePending    = "PENDING"
+	LeaseActive     = "ACTIVE"
+	LeaseRejected   = "REJECTED"
+	LeaseExpired    = "EXPIRED"
+	LeaseCancelled  = "CANCELLED"
+	LeaseTerminated = "TERMINATED"
+
+	LeaseFlat      = "FLAT"
+	LeaseCropShare = "CROP_SHARE"
+	LeaseRent      = "RENT"
+)
+
+const (
+	ListingOpen      = "OPEN"
+	ListingSettling  = "SETTLING"
+	ListingSold      = "SOLD"
+	ListingCancelled = "CANCELLED"
+)
+
+const (
+	AuctionOpen      = "OPEN"
+	AuctionSettling  = "SETTLING"
+	AuctionAwarded   = "AWARDED"
+	AuctionUnsold    = "UNSOLD"
+	AuctionCancelled = "CANCELLED"
+
+	BidActive = "ACTIVE"
+	BidOutbid = "OUTBID"
+	BidWon    = "WON"
+)
+
+const (
+	OrderBuy    = "BUY"
+	OrderSell   = "SELL"
+	OrderLimit  = "LIMIT"
+	OrderMarket = "MARKET"
+
+	OrderOpen      = "OPEN"
+	OrderPartial   = "PARTIAL"
+	OrderFilled    = "FILLED"
+	OrderCancelled = "CANCELLED"
+
+	TradeSettling  = "SETTLING" // Filled, with stock and money still to move
+	TradeCompleted = "COMPLETED"
+	TradeFailed    = "FAILED" // Given up before the stock moved; what was held went back
+)
+
+const (
+	StoreActive    = "ACTIVE"
+	StoreSoldOut   = "SOLD_OUT"
+	StoreCancelled = "CANCELLED"
+	StoreExpired   = "EXPIRED"
+)
+
+const (
+	ContractOpen       = "OPEN"
+	ContractAccepted   = "ACCEPTED"
+	ContractDelivering = "DELIVERING" // Stock reserved for delivery, reward still to pay
+	ContractFulfilled  = "FULFILLED"
+	ContractFailed     = "FAILED"
+	ContractExpired    = "EXPIRED"
+
+	ForwardOpen      = "OPEN"
+	ForwardActive    = "ACTIVE"
+	ForwardDelivered = "DELIVERED"
+	ForwardShortfall = "SHORTFALL"
+	ForwardCancelled = "CANCELLED"
+	ForwardExpired   = "EXPIRED"
+)
+
+const (
+	// LandGridWidth is the number of columns land positions wrap at: position 1 is the top left cell.
+	LandGridWidth = 10
+
+	// Structures that can be built on a land unit instead of planting it
+	StructureBeehive = "BEEHIVE"
+
+	// Quality change per neighbouring companion or antagonist planting, and the most all of them may change it
+	CompanionQualityBonus     = 0.05
+	AntagonistQualityPenalty  = 0.05
+	MaxAdjacencyQualityChange = 0.15
+
+	InitialLandUnitSize  = 100
+	StarterWalletBalance = 100
+	StarterSeedUnits     = 5
+)
+
+const (
+	EventGrowthUpdated    = "GROWTH_UPDATED"
+	EventGrowthMilestone  = "GROWTH_MILESTONE"
+	EventHarvestReady     = "HARVEST_READY"
+	EventWarehouseChanged = "WAREHOUSE_CHANGED"
+	EventWalletChanged    = "WALLET_CHANGED"
+	EventPriceChanged     = "PRICE_CHANGED"
+	EventTradeOffer       = "TRADE_OFFER"
+	EventLeaseRequest     = "LEASE_REQUEST"
+	EventOutbreak         = "OUTBREAK"
+	EventOutbid           = "OUTBID"
+	EventAuctionWon       = "AUCTION_WON"
+	EventOrderFilled      = "ORDER_FILLED"
+	EventStoreSale        = "STORE_SALE"
+	EventContractFailed   = "CONTRACT_FAILED"
+	EventForwardSettled   = "FORWARD_SETTLED"
+)
+
+const (
+	RegisterTopic         = "register"
+	OnboardingConsumerGID = "onboarding-worker"
 )
 
 func ConvertObjectIdsFromStringIds(ids []string) ([]primitive.ObjectID, error) {
@@ -0,0 +1,48 @@
+package utils
+
+import "os"
+
+const (
+	TradeOffered   = "OFFERED" // Participant only: waiting for the local user to accept
+	TradePreparing = "PREPARING"
+	TradePrepared  = "PREPARED"
+	TradeCommitted = "COMMITTED"
+	TradeAborted   = "ABORTED"
+
+	TradeCoordinator = "COORDINATOR"
+	TradeParticipant = "PARTICIPANT"
+
+	TradeSideSeller = "SELLER"
+	TradeSideBuyer  = "BUYER"
+
+	PeerActive      = "ACTIVE"
+	PeerUnreachable = "UNREACHABLE"
+
+	// Reason a participant gives for not being prepared while its user has not accepted yet
+	TradeAwaitingAcceptance = "awaiting acceptance"
+)
+
+// ServerID identifies this farming server to its peers, from SERVER_ID.
+func ServerID() string {
+	if id := os.Getenv("SERVER_ID"); id != "" {
+		return id
+	}
+
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// ServerAddress is the gRPC address peers use to reach this server, from SERVER_ADDRESS.
+func ServerAddress() string {
+	if address := os.Getenv("SERVER_ADDRESS"); address != "" {
+		return address
+	}
+
+	return "localhost:9000"
+}
+
+// FederationSecret is the shared secret peers present when they call without mutual TLS, from
+// FEDERATION_SECRET. Without it, and without TLS, peer calls are refused.
+func FederationSecret() string {
+	return os.Getenv("FEDERATION_SECRET")
+}
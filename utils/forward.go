@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+)
+
+// ForwardConfig sets the terms of forward contracts on future harvests.
+type ForwardConfig struct {
+	CollateralRate float64 // Share of a contract's value held from the seller, and the most a shortfall can cost them
+	GraceHours     int     // How long after the expected harvest an undelivered contract waits before failing
+}
+
+func DefaultForwardConfig() ForwardConfig {
+	return ForwardConfig{
+		CollateralRate: 0.5,
+		GraceHours:     48,
+	}
+}
+
+// ForwardConfigFromEnv reads FORWARD_COLLATERAL_RATE and FORWARD_GRACE_HOURS over the defaults.
+func ForwardConfigFromEnv() ForwardConfig {
+	config := DefaultForwardConfig()
+
+	if value, err := strconv.ParseFloat(os.Getenv("FORWARD_COLLATERAL_RATE"), 64); err == nil && value > 0 {
+		config.CollateralRate = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("FORWARD_GRACE_HOURS")); err == nil && value >= 0 {
+		config.GraceHours = value
+	}
+
+	return config
+}
@@ -0,0 +1,40 @@
+package utils
+
+// Quality grades, best first. Stock is graded by its quality factor so that orders for a grade
+// only ever match stacks of that grade.
+const (
+	GradeA      = "A"
+	GradeB      = "B"
+	GradeC      = "C"
+	GradeReject = "REJECT"
+)
+
+// Grades lists the quality grades, best first.
+var Grades = []string{GradeA, GradeB, GradeC, GradeReject}
+
+// DefaultGradeThresholds are the lowest quality factors of grades A, B and C for crops that don't
+// set their own. Anything below C is REJECT.
+var DefaultGradeThresholds = map[string]float64{GradeA: 0.9, GradeB: 0.7, GradeC: 0.4}
+
+// GradePriceFactors scale a crop's base price to the starting market price of each grade. Grade B
+// is the standard grade and trades at the base price.
+var GradePriceFactors = map[string]float64{GradeA: 1.25, GradeB: 1, GradeC: 0.75, GradeReject: 0.3}
+
+// QualityGrade returns the grade of a quality factor under a crop's thresholds. Empty thresholds
+// mean the defaults.
+func QualityGrade(quality float64, thresholds map[string]float64) string {
+	if len(thresholds) == 0 {
+		thresholds = DefaultGradeThresholds
+	}
+
+	switch {
+	case quality >= thresholds[GradeA]:
+		return GradeA
+	case quality >= thresholds[GradeB]:
+		return GradeB
+	case quality >= thresholds[GradeC]:
+		return GradeC
+	default:
+		return GradeReject
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type collectionIndex struct {
+	collection string
+	model      mongo.IndexModel
+}
+
+// Unique indexes the services rely on: keys that upserts from several servers at once must not
+// insert twice, and numbers that must never be handed out twice.
+var indexes = []collectionIndex{
+	{LandUnitsCollection, mongo.IndexModel{
+		Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "position", Value: 1}},
+		Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"position": bson.M{"$gt": 0}}),
+	}},
+	{PeerConnectionsCollection, mongo.IndexModel{
+		Keys:    bson.D{{Key: "peer_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}},
+	{WarehouseCollection, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}},
+	{WalletsCollection, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}},
+	{WeatherCollection, mongo.IndexModel{
+		Keys:    bson.D{{Key: "world_id", Value: 1}, {Key: "day", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}},
+	{ContractsCollection, mongo.IndexModel{
+		Keys:    bson.D{{Key: "round", Value: 1}, {Key: "slot", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}},
+	{EventsCollection, mongo.IndexModel{
+		Keys:    bson.D{{Key: "sequence", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}},
+}
+
+// EnsureIndexes creates the unique indexes the services rely on. A failure is logged rather than
+// fatal, since an index that already exists with other options, or data that breaks it, must not
+// stop the server.
+func EnsureIndexes(ctx context.Context, db *mongo.Database) {
+	for _, index := range indexes {
+		if _, err := db.Collection(index.collection).Indexes().CreateOne(ctx, index.model); err != nil {
+			fmt.Printf("Error creating index on %s: %v\n", index.collection, err)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+// KafkaBrokers reads the comma separated KAFKA_BROKERS env, defaulting to a local broker.
+func KafkaBrokers() []string {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		return []string{"localhost:9092"}
+	}
+
+	return strings.Split(brokers, ",")
+}
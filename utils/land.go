@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"math"
+	"os"
+	"strconv"
+)
+
+// LandConfig prices land beyond the starter plot and limits how much can be bought.
+type LandConfig struct {
+	BasePrice   float64 // Price of the first unit beyond the starter plot
+	PriceGrowth float64 // Each further unit held multiplies the price by this
+	MaxUnits    int     // Most land units one player may hold
+	Supply      int     // Land units the server sells in total
+}
+
+func DefaultLandConfig() LandConfig {
+	return LandConfig{
+		BasePrice:   50,
+		PriceGrowth: 1.02,
+		MaxUnits:    300,
+		Supply:      10000,
+	}
+}
+
+// LandConfigFromEnv reads LAND_BASE_PRICE, LAND_PRICE_GROWTH, LAND_MAX_UNITS and LAND_SUPPLY over the defaults.
+func LandConfigFromEnv() LandConfig {
+	config := DefaultLandConfig()
+
+	if value, err := strconv.ParseFloat(os.Getenv("LAND_BASE_PRICE"), 64); err == nil && value >= 0 {
+		config.BasePrice = value
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv("LAND_PRICE_GROWTH"), 64); err == nil && value >= 1 {
+		config.PriceGrowth = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("LAND_MAX_UNITS")); err == nil && value >= InitialLandUnitSize {
+		config.MaxUnits = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("LAND_SUPPLY")); err == nil && value >= 0 {
+		config.Supply = value
+	}
+
+	return config
+}
+
+// Price is what buying units more costs a player holding owned units, rounded to cents.
+func (c LandConfig) Price(owned int, units int) float64 {
+	total := 0.0
+
+	for held := owned; held < owned+units; held++ {
+		total += c.BasePrice * math.Pow(c.PriceGrowth, float64(max(0, held-InitialLandUnitSize)))
+	}
+
+	return math.Round(total*100) / 100
+}
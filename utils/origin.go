@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+// AllowedOrigins reads the comma separated ALLOWED_ORIGINS env: the browser origins, like
+// https://farm.example.com, that may open WebSocket sessions besides the server's own.
+func AllowedOrigins() []string {
+	origins := []string{}
+
+	for _, origin := range strings.Split(os.Getenv("ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, strings.TrimSuffix(origin, "/"))
+		}
+	}
+
+	return origins
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SystemUserID owns the marketplace's system wallet, which collects listing fees and commissions.
+var SystemUserID, _ = primitive.ObjectIDFromHex("000000000000000000000001")
+
+// StorefrontConfig prices fixed-price storefront listings.
+type StorefrontConfig struct {
+	ListingFee     float64 // Paid by the seller for each listing, not refunded
+	CommissionRate float64 // Fraction of each sale kept by the marketplace
+	ListingDays    int     // How long a listing stays up unless it asks for less
+}
+
+func DefaultStorefrontConfig() StorefrontConfig {
+	return StorefrontConfig{
+		ListingFee:     2,
+		CommissionRate: 0.05,
+		ListingDays:    7,
+	}
+}
+
+// StorefrontConfigFromEnv reads STOREFRONT_LISTING_FEE, STOREFRONT_COMMISSION_RATE and STOREFRONT_LISTING_DAYS over the defaults.
+func StorefrontConfigFromEnv() StorefrontConfig {
+	config := DefaultStorefrontConfig()
+
+	if value, err := strconv.ParseFloat(os.Getenv("STOREFRONT_LISTING_FEE"), 64); err == nil && value >= 0 {
+		config.ListingFee = value
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv("STOREFRONT_COMMISSION_RATE"), 64); err == nil && value >= 0 && value < 1 {
+		config.CommissionRate = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("STOREFRONT_LISTING_DAYS")); err == nil && value > 0 {
+		config.ListingDays = value
+	}
+
+	return config
+}
@@ -0,0 +1,9 @@
+package utils
+
+import "os"
+
+// TLSFiles reads the CA, certificate and key paths used for gRPC mutual TLS from GRPC_TLS_CA,
+// GRPC_TLS_CERT and GRPC_TLS_KEY. Empty paths mean TLS is off.
+func TLSFiles() (caFile string, certFile string, keyFile string) {
+	return os.Getenv("GRPC_TLS_CA"), os.Getenv("GRPC_TLS_CERT"), os.Getenv("GRPC_TLS_KEY")
+}
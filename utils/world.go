@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"hash/fnv"
+	"os"
+	"strconv"
+)
+
+// WorldSeed seeds this world's weather, from WORLD_SEED or else derived from the server id.
+func WorldSeed() uint64 {
+	if seed, err := strconv.ParseUint(os.Getenv("WORLD_SEED"), 10, 64); err == nil {
+		return seed
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(ServerID()))
+
+	return hash.Sum64()
+}
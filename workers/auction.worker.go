@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const auctionCloseInterval = 15 * time.Second
+
+// AuctionWorker closes lease and stock auctions that have ended and awards them to the highest bidder.
+type AuctionWorker struct {
+	leaseAuctionService *service.LeaseAuctionService
+	stockAuctionService *service.StockAuctionService
+}
+
+func NewAuctionWorker(dbClient *mongo.Database) *AuctionWorker {
+	return &AuctionWorker{
+		leaseAuctionService: service.NewLeaseAuctionService(dbClient),
+		stockAuctionService: service.NewStockAuctionService(dbClient),
+	}
+}
+
+func (w *AuctionWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(auctionCloseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.leaseAuctionService.CloseAuctions(ctx); err != nil {
+				fmt.Printf("Error closing lease auctions: %v\n", err)
+			}
+
+			if err := w.stockAuctionService.CloseAuctions(ctx); err != nil {
+				fmt.Printf("Error closing stock auctions: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const contractInterval = time.Minute
+
+// ContractWorker posts each round's NPC delivery contracts, finishes interrupted deliveries and
+// fails contracts not delivered in time.
+type ContractWorker struct {
+	contractService *service.ContractService
+}
+
+func NewContractWorker(dbClient *mongo.Database) *ContractWorker {
+	return &ContractWorker{
+		contractService: service.NewContractService(dbClient),
+	}
+}
+
+func (w *ContractWorker) Start(ctx context.Context) {
+	w.tick(ctx)
+
+	ticker := time.NewTicker(contractInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *ContractWorker) tick(ctx context.Context) {
+	if err := w.contractService.SettleContracts(ctx); err != nil {
+		fmt.Printf("Error settling contracts: %v\n", err)
+	}
+
+	if err := w.contractService.PostContracts(ctx); err != nil {
+		fmt.Printf("Error posting contracts: %v\n", err)
+	}
+}
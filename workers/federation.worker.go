@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const federationRecoveryInterval = 30 * time.Second
+
+// FederationWorker drives interrupted cross-server trades to completion.
+type FederationWorker struct {
+	federationService *service.FederationService
+}
+
+func NewFederationWorker(dbClient *mongo.Database) *FederationWorker {
+	return &FederationWorker{
+		federationService: service.NewFederationService(dbClient),
+	}
+}
+
+func (w *FederationWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(federationRecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.federationService.RecoverTrades(ctx); err != nil {
+				fmt.Printf("Error recovering federated trades: %v\n", err)
+			}
+		}
+	}
+}
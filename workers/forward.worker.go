@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const forwardInterval = time.Minute
+
+// ForwardWorker expires unsold forward offers, settles contracts whose harvest never came and
+// retries settlement payments that failed.
+type ForwardWorker struct {
+	forwardService *service.ForwardService
+}
+
+func NewForwardWorker(dbClient *mongo.Database) *ForwardWorker {
+	return &ForwardWorker{
+		forwardService: service.NewForwardService(dbClient),
+	}
+}
+
+func (w *ForwardWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(forwardInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.forwardService.SettleOverdue(ctx); err != nil {
+				fmt.Printf("Error settling overdue forward contracts: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/types"
+	"github.com/hrutik1235/farming-server/utils"
+	"github.com/hrutik1235/farming-server/world"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	growthTickInterval = 30 * time.Second
+	growthBatchSize    = 500
+)
+
+// GrowthWorker periodically stores the growth of active plantings, pushes it to connected players,
+// publishes GROWTH_MILESTONE at 25/50/75/100% and HARVEST_READY once a planting is fully grown.
+// It also lowers the quality of plantings whose care windows closed unattended, applies each day's
+// weather to growing plantings and rolls for pest and disease outbreaks.
+type GrowthWorker struct {
+	cropService     *service.CropService
+	careService     *service.CareService
+	weatherService  *service.WeatherService
+	outbreakService *service.OutbreakService
+	eventService    *service.EventService
+}
+
+func NewGrowthWorker(dbClient *mongo.Database) *GrowthWorker {
+	return &GrowthWorker{
+		cropService:     service.NewCropService(dbClient),
+		careService:     service.NewCareService(dbClient),
+		weatherService:  service.NewWeatherService(dbClient),
+		outbreakService: service.NewOutbreakService(dbClient),
+		eventService:    service.NewEventService(dbClient),
+	}
+}
+
+func (w *GrowthWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(growthTickInterval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick walks the growing plantings in batches, storing each batch's growth in one bulk write.
+func (w *GrowthWorker) tick(ctx context.Context) {
+	afterID := primitive.NilObjectID
+
+	weather, err := w.weatherService.GetWeather(ctx, world.DayOf(time.Now()))
+	if err != nil {
+		fmt.Printf("Error loading today's weather: %v\n", err)
+	}
+
+	// Outbreaks found during this tick spread from the next one.
+	infected, err := w.outbreakService.InfectedPositions(ctx)
+	if err != nil {
+		fmt.Printf("Error loading infected land: %v\n", err)
+	}
+
+	for {
+		plantings, err := w.cropService.GetGrowingPlantingsPage(ctx, afterID, growthBatchSize)
+		if err != nil {
+			fmt.Printf("Error loading growing plantings: %v\n", err)
+			return
+		}
+
+		growth := make(map[primitive.ObjectID]float64, len(plantings))
+		crops := make(map[primitive.ObjectID]*models.Crop)
+
+		growing := make([]models.PlantedCrop, 0, len(plantings))
+
+		for _, planting := range plantings {
+			growth[planting.ID] = w.cropService.CalculateCurrentGrowth(planting)
+			w.advance(ctx, planting, growth[planting.ID])
+
+			if growth[planting.ID] < 1 {
+				w.tend(ctx, planting, weather, crops)
+				growing = append(growing, planting)
+			}
+		}
+
+		if infected != nil {
+			if err := w.outbreakService.SpreadOutbreaks(ctx, growing, crops, infected); err != nil {
+				fmt.Printf("Error rolling outbreaks: %v\n", err)
+			}
+		}
+
+		if err := w.cropService.UpdateGrowthBatch(ctx, growth); err != nil {
+			fmt.Printf("Error storing growth: %v\n", err)
+		}
+
+		if len(plantings) < growthBatchSize {
+			return
+		}
+
+		afterID = plantings[len(plantings)-1].ID
+	}
+}
+
+// advance pushes the planting's progress, announces newly reached milestones and flags the
+// planting ready once fully grown.
+func (w *GrowthWorker) advance(ctx context.Context, planting models.PlantedCrop, growth float64) {
+	if w.eventService.HasSubscribers(planting.UserID) {
+		w.eventService.Broadcast(planting.UserID, utils.EventGrowthUpdated, types.GrowthUpdatedPayload{
+			PlantingID:        planting.ID.Hex(),
+			CropID:            planting.CropID.Hex(),
+			GrowthPercentage:  growth,
+			ExpectedHarvestAt: planting.ExpectedHarvestAt,
+		})
+	}
+
+	// Every milestone passed since the last one recorded is announced, in order, even if the
+	// worker skipped past several at once.
+	if milestone := growthMilestone(growth); milestone > planting.GrowthMilestone {
+		previous, claimed, err := w.cropService.ClaimGrowthMilestone(ctx, planting.ID, milestone)
+		if err != nil {
+			fmt.Printf("Error recording milestone for planting %s: %v\n", planting.ID.Hex(), err)
+		} else if claimed {
+			for _, step := range growthMilestones {
+				if step <= previous || step > milestone {
+					continue
+				}
+
+				w.eventService.PublishQuietly(ctx, planting.UserID, utils.EventGrowthMilestone, types.GrowthMilestonePayload{
+					PlantingID: planting.ID.Hex(),
+					CropID:     planting.CropID.Hex(),
+					Milestone:  step,
+				})
+			}
+		}
+	}
+
+	if growth < 1 || planting.IsReady || !planting.HarvestReadyAt.IsZero() {
+		return
+	}
+
+	marked, err := w.cropService.MarkHarvestReady(ctx, planting.ID)
+	if err != nil {
+		fmt.Printf("Error marking planting %s ready: %v\n", planting.ID.Hex(), err)
+		return
+	}
+
+	if marked {
+		w.eventService.PublishQuietly(ctx, planting.UserID, utils.EventHarvestReady, types.HarvestReadyPayload{
+			PlantingID:    planting.ID.Hex(),
+			CropID:        planting.CropID.Hex(),
+			ExpectedYield: planting.ExpectedYield,
+		})
+	}
+}
+
+// tend applies today's weather and penalises missed care windows, loading each crop once per batch.
+func (w *GrowthWorker) tend(ctx context.Context, planting models.PlantedCrop, weather *models.WeatherDay, crops map[primitive.ObjectID]*models.Crop) {
+	crop, ok := crops[planting.CropID]
+	if !ok {
+		var err error
+		if crop, err = w.cropService.GetCropById(planting.CropID); err != nil {
+			fmt.Printf("Error loading crop %s: %v\n", planting.CropID.Hex(), err)
+			return
+		}
+		crops[planting.CropID] = crop
+	}
+
+	if weather != nil {
+		if err := w.weatherService.ApplyWeather(ctx, planting, crop, weather); err != nil {
+			fmt.Printf("Error applying weather to planting %s: %v\n", planting.ID.Hex(), err)
+		}
+	}
+
+	if len(planting.Care) > 0 {
+		if err := w.careService.SettleMissedCare(ctx, planting, crop); err != nil {
+			fmt.Printf("Error settling care for planting %s: %v\n", planting.ID.Hex(), err)
+		}
+	}
+}
+
+// growthMilestones are the percentages of growth announced with GROWTH_MILESTONE.
+var growthMilestones = []int{25, 50, 75, 100}
+
+// growthMilestone returns the highest of 25, 50, 75 and 100 percent that growth has reached, or 0.
+func growthMilestone(growth float64) int {
+	milestone := 0
+
+	for _, step := range growthMilestones {
+		if growth*100 >= float64(step) {
+			milestone = step
+		}
+	}
+
+	return milestone
+}
@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const landRecoveryInterval = 30 * time.Second
+
+// LandWorker finishes land sales that were interrupted after the buyer claimed them.
+type LandWorker struct {
+	listingService *service.ListingService
+}
+
+func NewLandWorker(dbClient *mongo.Database) *LandWorker {
+	return &LandWorker{
+		listingService: service.NewListingService(dbClient),
+	}
+}
+
+func (w *LandWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(landRecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.listingService.RecoverSales(ctx); err != nil {
+				fmt.Printf("Error recovering land sales: %v\n", err)
+			}
+		}
+	}
+}
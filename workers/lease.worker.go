@@ -0,0 +1,48 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const leaseInterval = time.Minute
+
+// LeaseWorker charges daily rent, ends leases whose term is over and retries landowner payments
+// that failed.
+type LeaseWorker struct {
+	leaseService *service.LeaseService
+}
+
+func NewLeaseWorker(dbClient *mongo.Database) *LeaseWorker {
+	return &LeaseWorker{
+		leaseService: service.NewLeaseService(dbClient),
+	}
+}
+
+func (w *LeaseWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(leaseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.leaseService.ChargeRent(ctx); err != nil {
+				fmt.Printf("Error charging rent: %v\n", err)
+			}
+
+			if err := w.leaseService.ExpireLeases(ctx); err != nil {
+				fmt.Printf("Error expiring leases: %v\n", err)
+			}
+
+			if err := w.leaseService.RetryPayouts(ctx); err != nil {
+				fmt.Printf("Error retrying landowner payments: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const marketRecoveryInterval = 30 * time.Second
+
+// MarketWorker finishes order book trades that were interrupted after their orders were filled.
+type MarketWorker struct {
+	orderBookService *service.OrderBookService
+}
+
+func NewMarketWorker(dbClient *mongo.Database) *MarketWorker {
+	return &MarketWorker{
+		orderBookService: service.NewOrderBookService(dbClient),
+	}
+}
+
+func (w *MarketWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(marketRecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.orderBookService.RecoverTrades(ctx); err != nil {
+				fmt.Printf("Error recovering order book trades: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/kafkaconn"
+	"github.com/hrutik1235/farming-server/service"
+	"github.com/hrutik1235/farming-server/utils"
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	onboardingMaxAttempts   = 5
+	onboardingRetryInterval = time.Minute
+	onboardingStepTimeout   = 30 * time.Second
+	onboardingMaxBackoff    = 30 // Seconds between retries of an event with no onboarding record
+)
+
+// OnboardingWorker consumes UserRegistered events from the register topic and sets up new players.
+type OnboardingWorker struct {
+	kafka   *kafkaconn.KafkaConfig
+	service *service.OnboardingService
+}
+
+func NewOnboardingWorker(kconfig *kafkaconn.KafkaConfig, dbClient *mongo.Database) *OnboardingWorker {
+	return &OnboardingWorker{
+		kafka:   kconfig,
+		service: service.NewOnboardingService(dbClient),
+	}
+}
+
+// Start blocks consuming the register topic until ctx is cancelled, retrying failed onboardings,
+// and those whose event never arrived, in the background.
+func (w *OnboardingWorker) Start(ctx context.Context) error {
+	if err := w.kafka.CreateTopic(utils.RegisterTopic); err != nil {
+		return err
+	}
+
+	reader := w.kafka.NewReader(utils.RegisterTopic, utils.OnboardingConsumerGID)
+
+	go w.retryFailed(ctx)
+
+	for {
+		message, err := reader.FetchMessage(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Once the onboarding record keeps the event, later failures are retried from there and the
+		// offset can move on. Until then the offset stays put and the event is retried here, so an
+		// error before the record was written never loses it.
+		for attempt := 1; ; attempt++ {
+			if err := w.handle(ctx, message); err == nil || w.service.IsRecorded(ctx, message.Value) {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(min(time.Duration(attempt), onboardingMaxBackoff) * time.Second):
+			}
+		}
+
+		if err := reader.CommitMessages(ctx, message); err != nil {
+			fmt.Printf("Error committing register offset %d: %v\n", message.Offset, err)
+		}
+	}
+}
+
+func (w *OnboardingWorker) handle(ctx context.Context, message kafka.Message) error {
+	stepCtx, cancel := context.WithTimeout(ctx, onboardingStepTimeout)
+	defer cancel()
+
+	err := w.service.Onboard(stepCtx, message.Value)
+	if err != nil {
+		fmt.Printf("Onboarding failed for %s: %v\n", string(message.Key), err)
+	}
+
+	return err
+}
+
+func (w *OnboardingWorker) retryFailed(ctx context.Context) {
+	ticker := time.NewTicker(onboardingRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		records, err := w.service.GetFailedOnboardings(ctx, onboardingMaxAttempts, onboardingRetryInterval)
+		if err != nil {
+			fmt.Printf("Error loading failed onboardings: %v\n", err)
+			continue
+		}
+
+		for _, record := range records {
+			w.handle(ctx, kafka.Message{Key: []byte(record.UserID.Hex()), Value: record.Event})
+		}
+	}
+}
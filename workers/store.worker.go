@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const storeExpiryInterval = time.Minute
+
+// StoreWorker expires storefront listings, returning their unsold stock to the seller, and
+// finishes purchases interrupted after their units were claimed.
+type StoreWorker struct {
+	storefrontService *service.StorefrontService
+}
+
+func NewStoreWorker(dbClient *mongo.Database) *StoreWorker {
+	return &StoreWorker{
+		storefrontService: service.NewStorefrontService(dbClient),
+	}
+}
+
+func (w *StoreWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(storeExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.storefrontService.ExpireListings(ctx); err != nil {
+				fmt.Printf("Error expiring storefront listings: %v\n", err)
+			}
+
+			if err := w.storefrontService.RecoverTrades(ctx); err != nil {
+				fmt.Printf("Error recovering storefront trades: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hrutik1235/farming-server/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const walletRecoveryInterval = time.Minute
+
+// WalletWorker finishes wallet holds a crash left halfway, so no money stays held without an owner.
+type WalletWorker struct {
+	walletService *service.WalletService
+}
+
+func NewWalletWorker(dbClient *mongo.Database) *WalletWorker {
+	return &WalletWorker{
+		walletService: service.NewWalletService(dbClient),
+	}
+}
+
+func (w *WalletWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(walletRecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.walletService.RecoverHolds(ctx); err != nil {
+				fmt.Printf("Error recovering wallet holds: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package world simulates the seasons and daily weather of a farming world. Weather is generated
+// from the world seed and the day number alone, so every server with the same seed sees the same
+// forecast and a day can be regenerated at any time.
+package world
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/hrutik1235/farming-server/models"
+)
+
+const (
+	Spring = "SPRING"
+	Summer = "SUMMER"
+	Autumn = "AUTUMN"
+	Winter = "WINTER"
+
+	Sunny   = "SUNNY"
+	Cloudy  = "CLOUDY"
+	Rain    = "RAIN"
+	Storm   = "STORM"
+	Drought = "DROUGHT"
+	Frost   = "FROST"
+
+	// SeasonLengthDays is how many days each season lasts; a year is four seasons.
+	SeasonLengthDays = 7
+)
+
+// Epoch is day 0 of every world, the first day of spring.
+var Epoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var Seasons = []string{Spring, Summer, Autumn, Winter}
+
+var Conditions = []string{Sunny, Cloudy, Rain, Storm, Drought, Frost}
+
+// climate is the chance of each condition per season, in the order of Conditions, with the
+// season's mean temperature.
+var climate = map[string]struct {
+	weights     []float64
+	temperature float64
+}{
+	Spring: {weights: []float64{30, 25, 30, 10, 0, 5}, temperature: 14},
+	Summer: {weights: []float64{45, 15, 15, 10, 15, 0}, temperature: 26},
+	Autumn: {weights: []float64{20, 35, 30, 10, 0, 5}, temperature: 12},
+	Winter: {weights: []float64{15, 35, 15, 5, 0, 30}, temperature: 2},
+}
+
+// conditionEffects are the effects on a crop with a sensitivity of 1.
+var conditionEffects = map[string]models.WeatherEffect{
+	Sunny:   {Growth: 1.1, Quality: 0},
+	Cloudy:  {Growth: 1.0, Quality: 0},
+	Rain:    {Growth: 1.05, Quality: 0.01},
+	Storm:   {Growth: 0.8, Quality: -0.03},
+	Drought: {Growth: 0.7, Quality: -0.03},
+	Frost:   {Growth: 0.6, Quality: -0.04},
+}
+
+// DayOf returns the world day t falls on.
+func DayOf(t time.Time) int {
+	return int(math.Floor(t.Sub(Epoch).Hours() / 24))
+}
+
+// DateOf returns the start of a world day.
+func DateOf(day int) time.Time {
+	return Epoch.AddDate(0, 0, day)
+}
+
+// SeasonOf returns the season of a world day.
+func SeasonOf(day int) string {
+	seasons := len(Seasons)
+	index := int(math.Floor(float64(day) / SeasonLengthDays))
+
+	return Seasons[(index%seasons+seasons)%seasons]
+}
+
+// Generate returns the weather of a world day. The same seed and day always give the same weather.
+func Generate(worldID string, seed uint64, day int) models.WeatherDay {
+	random := rand.New(rand.NewPCG(seed, uint64(int64(day))))
+
+	season := SeasonOf(day)
+	seasonClimate := climate[season]
+
+	condition := Conditions[pick(random, seasonClimate.weights)]
+
+	temperature := seasonClimate.temperature + random.NormFloat64()*4
+	rainfall := 0.0
+
+	switch condition {
+	case Rain:
+		rainfall = 5 + random.Float64()*15
+	case Storm:
+		rainfall = 20 + random.Float64()*40
+	case Drought:
+		temperature += 6
+	case Frost:
+		temperature = math.Min(temperature, -1-random.Float64()*5)
+	}
+
+	return models.WeatherDay{
+		WorldID:      worldID,
+		Day:          day,
+		Date:         DateOf(day),
+		Season:       season,
+		Condition:    condition,
+		TemperatureC: math.Round(temperature*10) / 10,
+		RainfallMM:   math.Round(rainfall*10) / 10,
+	}
+}
+
+// CropEffect returns how a day's weather and season affect the crop. A crop grows 20% faster in a
+// preferred season and 20% slower outside them, unless it prefers none. Its sensitivity to a
+// condition scales that condition's effect, defaulting to 1.
+func CropEffect(crop *models.Crop, weather models.WeatherDay) models.WeatherEffect {
+	base, ok := conditionEffects[weather.Condition]
+	if !ok {
+		return models.WeatherEffect{Growth: 1}
+	}
+
+	sensitivity := 1.0
+	if value, ok := crop.WeatherSensitivity[weather.Condition]; ok {
+		sensitivity = value
+	}
+
+	effect := models.WeatherEffect{
+		Growth:  1 + (base.Growth-1)*sensitivity,
+		Quality: base.Quality * sensitivity,
+	}
+
+	if len(crop.PreferredSeasons) > 0 {
+		if slices.Contains(crop.PreferredSeasons, weather.Season) {
+			effect.Growth *= 1.2
+			effect.Quality += 0.01
+		} else {
+			effect.Growth *= 0.8
+		}
+	}
+
+	effect.Growth = math.Max(0.1, effect.Growth)
+
+	return effect
+}
+
+// ShiftHarvest returns when a planting due at expectedAt is ready if the day starting at now grows
+// it at rate. Only that day's share of the remaining time runs at the rate, so the effects of
+// several days add up instead of compounding.
+func ShiftHarvest(expectedAt time.Time, now time.Time, rate float64) time.Time {
+	remaining := expectedAt.Sub(now)
+	if remaining <= 0 || rate <= 0 {
+		return expectedAt
+	}
+
+	share := min(remaining, 24*time.Hour)
+
+	return expectedAt.Add(time.Duration(float64(share)/rate) - share)
+}
+
+func pick(random *rand.Rand, weights []float64) int {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	target := random.Float64() * total
+	for i, weight := range weights {
+		if target < weight {
+			return i
+		}
+		target -= weight
+	}
+
+	return len(weights) - 1
+}
@@ -0,0 +1,67 @@
+package world
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerateReplaysSeed(t *testing.T) {
+	for day := -30; day < 400; day++ {
+		first := Generate("farm-a", 42, day)
+		second := Generate("farm-b", 42, day)
+
+		first.WorldID, second.WorldID = "", ""
+		if first != second {
+			t.Fatalf("day %d: seed 42 gave %+v and then %+v", day, first, second)
+		}
+	}
+}
+
+func TestGenerateDependsOnSeed(t *testing.T) {
+	differs := false
+
+	for day := 0; day < 60 && !differs; day++ {
+		differs = Generate("farm", 1, day) != Generate("farm", 2, day)
+	}
+
+	if !differs {
+		t.Fatal("seeds 1 and 2 gave the same 60 days of weather")
+	}
+}
+
+func TestGenerateFollowsSeasons(t *testing.T) {
+	for day := 0; day < 4*SeasonLengthDays; day++ {
+		if weather := Generate("farm", 7, day); weather.Season != SeasonOf(day) {
+			t.Fatalf("day %d is %s, want %s", day, weather.Season, SeasonOf(day))
+		}
+	}
+}
+
+func TestShiftHarvestDoesNotCompound(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := now.Add(10 * 24 * time.Hour)
+
+	// Half speed for one day costs that day again, whatever is left after it.
+	if got, want := ShiftHarvest(expected, now, 0.5), expected.Add(24*time.Hour); !got.Equal(want) {
+		t.Fatalf("half speed: ready at %s, want %s", got, want)
+	}
+
+	// Ten slow days in a row add ten days, not 2^10 times the rest.
+	at := expected
+	for day := 0; day < 10; day++ {
+		at = ShiftHarvest(at, now.Add(time.Duration(day)*24*time.Hour), 0.5)
+	}
+	if want := expected.Add(10 * 24 * time.Hour); !at.Equal(want) {
+		t.Fatalf("ten slow days: ready at %s, want %s", at, want)
+	}
+
+	// A fast day can't finish more than what is left.
+	soon := now.Add(6 * time.Hour)
+	if got, want := ShiftHarvest(soon, now, 2), now.Add(3*time.Hour); !got.Equal(want) {
+		t.Fatalf("double speed with 6h left: ready at %s, want %s", got, want)
+	}
+
+	if got := ShiftHarvest(now, now, 2); !got.Equal(now) {
+		t.Fatalf("already due: ready at %s, want %s", got, now)
+	}
+}